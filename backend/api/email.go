@@ -0,0 +1,288 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/captcha"
+	"github.com/jayden/personal-blog-backend/loginguard"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/tourist"
+	"github.com/jayden/personal-blog-backend/verifycode"
+)
+
+// 通用响应结构体
+// @Description 只包含提示信息的响应结果
+type MessageResponse struct {
+	// 响应消息
+	Message string `json:"message" example:"操作成功"`
+	// 是否成功
+	Success bool `json:"success" example:"true"`
+	// 下次请求是否需要图形验证码
+	CaptchaRequired bool `json:"captcha_required,omitempty" example:"false"`
+}
+
+// 发送邮件验证码请求结构体
+// @Description 发送邮件验证码请求参数
+type SendEmailVerifyCodeRequest struct {
+	// 邮箱
+	Email string `json:"email" example:"newuser@example.com"`
+	// 类型 register,reset_password,bind_email
+	Type string `json:"type" example:"register"`
+}
+
+// 邮箱登录请求结构体
+// @Description 邮箱登录请求参数
+type EmailLoginRequest struct {
+	// 邮箱
+	Email string `json:"email" example:"admin@example.com"`
+	// 密码
+	Password string `json:"password" example:"password123"`
+	// 图形验证码key
+	CaptchaKey string `json:"captcha_key" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	// 图形验证码
+	CaptchaCode string `json:"captcha_code" example:"1234"`
+}
+
+// 重置密码请求结构体
+// @Description 重置密码请求参数
+type ResetPasswordRequest struct {
+	// 邮箱
+	Email string `json:"email" example:"admin@example.com"`
+	// 新密码
+	Password string `json:"password" example:"newpassword123"`
+	// 确认密码
+	ConfirmPassword string `json:"confirm_password" example:"newpassword123"`
+	// 邮件验证码
+	VerifyCode string `json:"verify_code" example:"123456"`
+	// 图形验证码key
+	CaptchaKey string `json:"captcha_key" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	// 图形验证码
+	CaptchaCode string `json:"captcha_code" example:"1234"`
+}
+
+// @Summary 发送邮件验证码
+// @Description 向邮箱发送验证码，用于注册、重置密码和绑定邮箱
+// @Tags 用户认证
+// @Accept  json
+// @Produce  json
+// @Param sendReq body SendEmailVerifyCodeRequest true "发送验证码请求参数"
+// @Success 200 {object} MessageResponse "验证码已发送"
+// @Failure 400 {object} MessageResponse "参数错误"
+// @Failure 409 {object} MessageResponse "邮箱已被使用"
+// @Failure 429 {object} MessageResponse "发送过于频繁"
+// @Failure 500 {object} MessageResponse "服务器错误"
+// @Router /send_email_verify_code [post]
+func SendEmailVerifyCodeHandler(w http.ResponseWriter, r *http.Request) {
+	var req SendEmailVerifyCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "无效的请求体", http.StatusBadRequest)
+		return
+	}
+
+	email, ok := verifycode.NormalizeEmail(req.Email)
+	if !ok {
+		writeMessage(w, http.StatusBadRequest, "邮箱格式不正确", false)
+		return
+	}
+
+	existingUser, err := models.GetUserByEmail(email)
+	if err != nil {
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	switch req.Type {
+	case verifycode.SceneRegister, verifycode.SceneBindEmail:
+		if existingUser != nil {
+			writeMessage(w, http.StatusConflict, "邮箱已被使用", false)
+			return
+		}
+	case verifycode.SceneResetPassword:
+		// 邮箱未注册时同样返回成功，避免通过该接口探测邮箱是否注册
+		if existingUser == nil {
+			writeMessage(w, http.StatusOK, "验证码已发送", true)
+			return
+		}
+	default:
+		writeMessage(w, http.StatusBadRequest, verifycode.ErrInvalidScene.Error(), false)
+		return
+	}
+
+	if err := verifycode.EmailCodes.Send(r.Context(), req.Type, email); err != nil {
+		writeVerifyCodeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "验证码已发送", true)
+}
+
+// @Summary 邮箱登录
+// @Description 使用邮箱和密码登录，成功后返回JWT Token
+// @Tags 用户认证
+// @Accept  json
+// @Produce  json
+// @Param loginReq body EmailLoginRequest true "邮箱登录请求参数"
+// @Success 200 {object} LoginResponse "登录成功"
+// @Failure 400 {object} map[string]string "无效的请求体"
+// @Failure 401 {object} LoginResponse "邮箱或密码错误"
+// @Failure 429 {object} LoginResponse "连续失败次数过多，账号或IP被临时锁定"
+// @Failure 500 {object} map[string]string "服务器错误"
+// @Router /email_login [post]
+func EmailLoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req EmailLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "无效的请求体", http.StatusBadRequest)
+		return
+	}
+
+	email, _ := verifycode.NormalizeEmail(req.Email)
+	subjects := captchaSubjects(r, email)
+	if err := captcha.Default().Check(req.CaptchaKey, req.CaptchaCode, subjects...); err != nil {
+		writeCaptchaError(w, err)
+		return
+	}
+
+	var user *models.User
+	if email != "" {
+		var err error
+		user, err = models.GetUserByEmail(email)
+		if err != nil {
+			http.Error(w, "服务器错误", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	account := loginguard.AccountKey(user, email)
+	if !checkLoginLock(w, r, account) {
+		return
+	}
+
+	var ok bool
+	if user == nil {
+		models.DummyVerifyPassword(req.Password)
+	} else {
+		ok = models.VerifyPassword(user.Password, req.Password)
+	}
+	if !ok {
+		loginguard.Default().RecordFailure(account, netutil.ClientIP(r))
+		captcha.Default().RecordFailure(subjects...)
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(LoginResponse{
+			Message:         "邮箱或密码错误",
+			CaptchaRequired: captcha.Default().Required(subjects...),
+		})
+		return
+	}
+
+	loginguard.Default().RecordSuccess(account)
+	captcha.Default().Reset("account:" + email)
+	writeLoginResponse(w, r, user)
+}
+
+// @Summary 重置密码
+// @Description 通过邮件验证码重置密码
+// @Tags 用户认证
+// @Accept  json
+// @Produce  json
+// @Param resetReq body ResetPasswordRequest true "重置密码请求参数"
+// @Success 200 {object} MessageResponse "重置密码成功"
+// @Failure 400 {object} MessageResponse "参数错误或验证码错误"
+// @Failure 500 {object} MessageResponse "服务器错误"
+// @Router /reset_password [post]
+func ResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "无效的请求体", http.StatusBadRequest)
+		return
+	}
+
+	email, ok := verifycode.NormalizeEmail(req.Email)
+	if !ok {
+		writeMessage(w, http.StatusBadRequest, "邮箱格式不正确", false)
+		return
+	}
+	if req.Password != req.ConfirmPassword {
+		writeMessage(w, http.StatusBadRequest, "两次输入的密码不一致", false)
+		return
+	}
+	if err := models.ValidatePassword(req.Password); err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error(), false)
+		return
+	}
+
+	subjects := captchaSubjects(r, email)
+	if err := captcha.Default().Check(req.CaptchaKey, req.CaptchaCode, subjects...); err != nil {
+		writeCaptchaError(w, err)
+		return
+	}
+
+	if err := verifycode.EmailCodes.Verify(verifycode.SceneResetPassword, email, req.VerifyCode); err != nil {
+		captcha.Default().RecordFailure(subjects...)
+		writeVerifyCodeError(w, err)
+		return
+	}
+	captcha.Default().Reset("account:" + email)
+
+	user, err := models.GetUserByEmail(email)
+	if err != nil {
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		// 未注册的邮箱不会收到验证码，能走到这里说明账号在此期间被修改
+		writeMessage(w, http.StatusBadRequest, verifycode.ErrCodeNotFound.Error(), false)
+		return
+	}
+
+	if err := models.UpdateUserPassword(user.ID, req.Password); err != nil {
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "重置密码成功", true)
+}
+
+// writeLoginResponse 为用户签发token并返回登录成功响应，同时把请求携带的游客身份下的数据合并到该用户
+func writeLoginResponse(w http.ResponseWriter, r *http.Request, user *models.User) {
+	tokenString, _, err := auth.GenerateToken(user.ID, user.Username, user.Role)
+	if err != nil {
+		http.Error(w, "无法生成token", http.StatusInternalServerError)
+		return
+	}
+	tourist.Merge(r, user.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(LoginResponse{
+		Token:   tokenString,
+		Message: "登录成功",
+	})
+}
+
+// writeMessage 返回只包含提示信息的JSON响应
+func writeMessage(w http.ResponseWriter, status int, message string, success bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(MessageResponse{
+		Message: message,
+		Success: success,
+	})
+}
+
+// writeVerifyCodeError 根据验证码服务返回的错误写入对应响应
+func writeVerifyCodeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, verifycode.ErrTooFrequent), errors.Is(err, verifycode.ErrDailyLimit):
+		writeMessage(w, http.StatusTooManyRequests, err.Error(), false)
+	case errors.Is(err, verifycode.ErrInvalidScene),
+		errors.Is(err, verifycode.ErrCodeNotFound),
+		errors.Is(err, verifycode.ErrCodeMismatch),
+		errors.Is(err, verifycode.ErrTooManyAttempts):
+		writeMessage(w, http.StatusBadRequest, err.Error(), false)
+	default:
+		writeMessage(w, http.StatusInternalServerError, "验证码发送失败，请稍后再试", false)
+	}
+}
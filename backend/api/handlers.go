@@ -2,12 +2,20 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/captcha"
+	"github.com/jayden/personal-blog-backend/loginguard"
 	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/search"
+	"github.com/jayden/personal-blog-backend/tourist"
+	"github.com/jayden/personal-blog-backend/verifycode"
+	"github.com/jayden/personal-blog-backend/viewstat"
 )
 
 // 登录请求结构体
@@ -17,6 +25,10 @@ type LoginRequest struct {
 	Username string `json:"username" example:"admin"`
 	// 密码
 	Password string `json:"password" example:"password123"`
+	// 图形验证码key
+	CaptchaKey string `json:"captcha_key" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	// 图形验证码
+	CaptchaCode string `json:"captcha_code" example:"1234"`
 }
 
 // 登录响应结构体
@@ -26,6 +38,8 @@ type LoginResponse struct {
 	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 	// 响应消息
 	Message string `json:"message" example:"登录成功"`
+	// 下次登录是否需要图形验证码
+	CaptchaRequired bool `json:"captcha_required,omitempty" example:"false"`
 }
 
 // 注册请求结构体
@@ -35,8 +49,16 @@ type RegisterRequest struct {
 	Username string `json:"username" example:"newuser"`
 	// 密码
 	Password string `json:"password" example:"newpassword123"`
+	// 确认密码
+	ConfirmPassword string `json:"confirm_password" example:"newpassword123"`
 	// 邮箱
 	Email string `json:"email" example:"newuser@example.com"`
+	// 邮件验证码
+	VerifyCode string `json:"verify_code" example:"123456"`
+	// 图形验证码key
+	CaptchaKey string `json:"captcha_key" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	// 图形验证码
+	CaptchaCode string `json:"captcha_code" example:"1234"`
 }
 
 // 注册响应结构体
@@ -48,15 +70,6 @@ type RegisterResponse struct {
 	Success bool `json:"success" example:"true"`
 }
 
-// JWT密钥（在实际应用中应该从环境变量读取）
-var jwtKey = []byte("my_secret_key")
-
-// Claims JWT声明结构体
-type Claims struct {
-	Username string `json:"username"`
-	jwt.RegisteredClaims
-}
-
 // @Summary 用户登录
 // @Description 用户登录接口，成功后返回JWT Token
 // @Tags 用户认证
@@ -65,7 +78,9 @@ type Claims struct {
 // @Param loginReq body LoginRequest true "登录请求参数"
 // @Success 200 {object} LoginResponse "登录成功"
 // @Failure 400 {object} map[string]string "无效的请求体"
-// @Failure 401 {object} map[string]string "用户名或密码错误"
+// @Failure 400 {object} MessageResponse "需要图形验证码或验证码错误"
+// @Failure 401 {object} LoginResponse "用户名或密码错误"
+// @Failure 429 {object} LoginResponse "连续失败次数过多，账号或IP被临时锁定"
 // @Failure 500 {object} map[string]string "服务器错误"
 // @Router /login [post]
 func LoginHandler(w http.ResponseWriter, r *http.Request) {
@@ -76,6 +91,13 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 校验图形验证码，自适应模式下只有连续失败后才需要
+	subjects := captchaSubjects(r, req.Username)
+	if err := captcha.Default().Check(req.CaptchaKey, req.CaptchaCode, subjects...); err != nil {
+		writeCaptchaError(w, err)
+		return
+	}
+
 	// 从数据库查询用户
 	user, err := models.GetUserByUsername(req.Username)
 	if err != nil {
@@ -83,48 +105,41 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 检查用户是否存在
+	// 检查是否因连续失败被锁定
+	account := loginguard.AccountKey(user, req.Username)
+	if !checkLoginLock(w, r, account) {
+		return
+	}
+
+	// 检查用户是否存在，不存在时同样做一次密码比对，保持响应时间一致
 	if user == nil {
+		models.DummyVerifyPassword(req.Password)
+		loginguard.Default().RecordFailure(account, netutil.ClientIP(r))
+		captcha.Default().RecordFailure(subjects...)
 		w.WriteHeader(http.StatusUnauthorized)
 		json.NewEncoder(w).Encode(LoginResponse{
-			Message: "用户名或密码错误",
+			Message:         "用户名或密码错误",
+			CaptchaRequired: captcha.Default().Required(subjects...),
 		})
 		return
 	}
 
 	// 验证密码
 	if !models.VerifyPassword(user.Password, req.Password) {
+		loginguard.Default().RecordFailure(account, netutil.ClientIP(r))
+		captcha.Default().RecordFailure(subjects...)
 		w.WriteHeader(http.StatusUnauthorized)
 		json.NewEncoder(w).Encode(LoginResponse{
-			Message: "用户名或密码错误",
+			Message:         "用户名或密码错误",
+			CaptchaRequired: captcha.Default().Required(subjects...),
 		})
 		return
 	}
 
-	// 创建JWT token
-	expirationTime := time.Now().Add(24 * time.Hour)
-	claims := &Claims{
-		Username: user.Username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
-	if err != nil {
-		http.Error(w, "无法生成token", http.StatusInternalServerError)
-		return
-	}
+	loginguard.Default().RecordSuccess(account)
+	captcha.Default().Reset("account:" + req.Username)
 
-	// 返回token
-	response := LoginResponse{
-		Token:   tokenString,
-		Message: "登录成功",
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	writeLoginResponse(w, r, user)
 }
 
 // @Summary 健康检查
@@ -139,13 +154,13 @@ func HealthCheck(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 用户注册
-// @Description 用户注册接口
+// @Description 用户注册接口，需要先获取邮件验证码
 // @Tags 用户认证
 // @Accept  json
 // @Produce  json
 // @Param registerReq body RegisterRequest true "注册请求参数"
 // @Success 201 {object} RegisterResponse "注册成功"
-// @Failure 400 {object} RegisterResponse "用户名、密码和邮箱不能为空"
+// @Failure 400 {object} RegisterResponse "参数错误或验证码错误"
 // @Failure 409 {object} RegisterResponse "用户名或邮箱已存在"
 // @Failure 500 {object} RegisterResponse "服务器错误"
 // @Router /register [post]
@@ -166,6 +181,27 @@ func RegisterHandler(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if req.Password != req.ConfirmPassword {
+		writeMessage(w, http.StatusBadRequest, "两次输入的密码不一致", false)
+		return
+	}
+	if err := models.ValidatePassword(req.Password); err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error(), false)
+		return
+	}
+	email, ok := verifycode.NormalizeEmail(req.Email)
+	if !ok {
+		writeMessage(w, http.StatusBadRequest, "邮箱格式不正确", false)
+		return
+	}
+	req.Email = email
+
+	// 校验图形验证码
+	subjects := captchaSubjects(r, req.Email)
+	if err := captcha.Default().Check(req.CaptchaKey, req.CaptchaCode, subjects...); err != nil {
+		writeCaptchaError(w, err)
+		return
+	}
 
 	// 检查用户名是否已存在
 	existingUser, err := models.GetUserByUsername(req.Username)
@@ -197,12 +233,21 @@ func RegisterHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 校验邮件验证码，放在重复性检查之后，避免用户名冲突时白白消耗验证码
+	if err := verifycode.EmailCodes.Verify(verifycode.SceneRegister, req.Email, req.VerifyCode); err != nil {
+		captcha.Default().RecordFailure(subjects...)
+		writeVerifyCodeError(w, err)
+		return
+	}
+	captcha.Default().Reset("account:" + req.Email)
+
 	// 创建新用户
-	_, err = models.CreateUser(req.Username, req.Password, req.Email)
+	user, err := models.CreateUser(req.Username, req.Password, req.Email)
 	if err != nil {
 		http.Error(w, "注册失败", http.StatusInternalServerError)
 		return
 	}
+	tourist.Merge(r, user.ID)
 
 	// 返回注册成功响应
 	w.Header().Set("Content-Type", "application/json")
@@ -214,7 +259,7 @@ func RegisterHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 获取文章列表
-// @Description 获取所有文章的列表，支持分页
+// @Description 获取公开文章的列表，置顶文章在前，支持分页
 // @Tags 文章
 // @Produce  json
 // @Param page query int false "页码" default(1)
@@ -260,7 +305,7 @@ func GetArticlesHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 获取文章详情
-// @Description 根据文章ID获取文章详情
+// @Description 根据文章ID获取文章详情，未公开的文章只有管理员可以查看
 // @Tags 文章
 // @Produce  json
 // @Param id query string true "文章ID"
@@ -283,11 +328,17 @@ func GetArticleDetailHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if article == nil {
+	// 未公开的文章只有管理员可以查看
+	if article == nil || (article.Status != models.ArticleStatusPublic && !auth.IsAdminRequest(r)) {
 		http.Error(w, "文章不存在", http.StatusNotFound)
 		return
 	}
 
+	// 只记录公开文章的浏览量，管理员预览未公开的文章不计入；使用查到的文章ID，避免请求中的ID格式不一致
+	if article.Status == models.ArticleStatusPublic {
+		viewstat.RecordArticleView(r, article.ID)
+	}
+
 	// 返回 JSON 响应
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -302,9 +353,12 @@ func GetArticleDetailHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags 文章
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param article body models.Article true "文章信息"
 // @Success 200 {object} map[string]interface{} "创建成功"
-// @Failure 400 {object} map[string]string "标题、内容和分类不能为空"
+// @Failure 400 {object} map[string]string "标题、内容和分类不能为空，或分类、标签不存在"
+// @Failure 401 {object} map[string]string "请先登录"
+// @Failure 403 {object} map[string]string "没有权限"
 // @Failure 500 {object} map[string]string "服务器错误"
 // @Router /article [post]
 func CreateArticleHandler(w http.ResponseWriter, r *http.Request) {
@@ -327,9 +381,14 @@ func CreateArticleHandler(w http.ResponseWriter, r *http.Request) {
 
 	// 创建文章
 	if err := models.CreateArticle(&article); err != nil {
+		if errors.Is(err, models.ErrCategoryNotFound) || errors.Is(err, models.ErrTagNotFound) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "创建文章失败: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	search.IndexArticle(article.ID)
 
 	// 返回 JSON 响应
 	w.Header().Set("Content-Type", "application/json")
@@ -345,9 +404,13 @@ func CreateArticleHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags 文章
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param article body models.Article true "文章信息"
 // @Success 200 {object} map[string]interface{} "更新成功"
-// @Failure 400 {object} map[string]string "ID、标题、内容和分类不能为空"
+// @Failure 400 {object} map[string]string "ID、标题、内容和分类不能为空，或分类、标签不存在"
+// @Failure 404 {object} map[string]string "文章不存在"
+// @Failure 401 {object} map[string]string "请先登录"
+// @Failure 403 {object} map[string]string "没有权限"
 // @Failure 500 {object} map[string]string "服务器错误"
 // @Router /article [put]
 func UpdateArticleHandler(w http.ResponseWriter, r *http.Request) {
@@ -369,9 +432,17 @@ func UpdateArticleHandler(w http.ResponseWriter, r *http.Request) {
 
 	// 更新文章
 	if err := models.UpdateArticle(&article); err != nil {
-		http.Error(w, "更新文章失败: "+err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, models.ErrArticleNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrCategoryNotFound), errors.Is(err, models.ErrTagNotFound):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "更新文章失败: "+err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
+	search.IndexArticle(article.ID)
 
 	// 返回 JSON 响应
 	w.Header().Set("Content-Type", "application/json")
@@ -386,9 +457,13 @@ func UpdateArticleHandler(w http.ResponseWriter, r *http.Request) {
 // @Description 根据文章ID删除文章
 // @Tags 文章
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param id query string true "文章ID"
 // @Success 200 {object} map[string]interface{} "删除成功"
 // @Failure 400 {object} map[string]string "文章ID不能为空"
+// @Failure 404 {object} map[string]string "文章不存在"
+// @Failure 401 {object} map[string]string "请先登录"
+// @Failure 403 {object} map[string]string "没有权限"
 // @Failure 500 {object} map[string]string "服务器错误"
 // @Router /article [delete]
 func DeleteArticleHandler(w http.ResponseWriter, r *http.Request) {
@@ -400,9 +475,14 @@ func DeleteArticleHandler(w http.ResponseWriter, r *http.Request) {
 
 	// 删除文章
 	if err := models.DeleteArticle(id); err != nil {
+		if errors.Is(err, models.ErrArticleNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
 		http.Error(w, "删除文章失败: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	search.RemoveArticle(id)
 
 	// 返回 JSON 响应
 	w.Header().Set("Content-Type", "application/json")
@@ -462,10 +542,11 @@ func GetTagsHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 根据分类ID获取文章
-// @Description 根据分类ID获取该分类下的文章列表，支持分页
+// @Description 根据分类ID获取该分类下的文章列表，支持分页；includeChildren 为 true 时包含子孙分类的文章
 // @Tags 文章
 // @Produce  json
 // @Param categoryId query string true "分类ID"
+// @Param includeChildren query bool false "是否包含子孙分类的文章" default(false)
 // @Param page query int false "页码" default(1)
 // @Param limit query int false "每页数量" default(10)
 // @Success 200 {object} map[string]interface{} "文章列表"
@@ -498,9 +579,10 @@ func GetArticlesByCategoryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	offset := (page - 1) * limit
+	includeChildren, _ := strconv.ParseBool(r.URL.Query().Get("includeChildren"))
 
 	// 根据分类ID获取文章
-	articles, err := models.GetArticlesByCategoryID(categoryID, limit, offset)
+	articles, _, err := models.GetArticlesByCategoryID(categoryID, includeChildren, limit, offset)
 	if err != nil {
 		http.Error(w, "获取分类文章失败: "+err.Error(), http.StatusInternalServerError)
 		return
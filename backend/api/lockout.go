@@ -0,0 +1,29 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/jayden/personal-blog-backend/loginguard"
+	"github.com/jayden/personal-blog-backend/netutil"
+)
+
+// checkLoginLock 检查账号和IP是否因连续登录失败被锁定，锁定时写入429响应并返回 false
+func checkLoginLock(w http.ResponseWriter, r *http.Request, account string) bool {
+	wait := loginguard.Default().Check(account, netutil.ClientIP(r))
+	if wait <= 0 {
+		return true
+	}
+
+	seconds := int(math.Ceil(wait.Seconds()))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(LoginResponse{
+		Message: fmt.Sprintf("登录失败次数过多，请%d秒后再试", seconds),
+	})
+	return false
+}
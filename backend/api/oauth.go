@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/oauth"
+)
+
+// 获取第三方授权地址请求结构体
+// @Description 获取第三方授权地址请求参数
+type GetOauthAuthorizeUrlRequest struct {
+	// 平台 github,gitee,qq
+	Platform string `json:"platform" example:"github"`
+	// 客户端生成的随机串（16到128个字符），需要保存在本地，回调后登录或绑定时原样提交
+	ClientNonce string `json:"client_nonce" example:"3f9a1c7e5b2d4a608e1f7c9b2a4d6e8f"`
+}
+
+// 获取第三方授权地址响应结构体
+// @Description 获取第三方授权地址响应结果
+type GetOauthAuthorizeUrlResponse struct {
+	// 授权地址
+	AuthorizeUrl string `json:"authorize_url" example:"https://github.com/login/oauth/authorize?client_id=..."`
+}
+
+// 第三方登录请求结构体
+// @Description 第三方登录请求参数
+type ThirdLoginRequest struct {
+	// 平台
+	Platform string `json:"platform" example:"github"`
+	// 授权码
+	Code string `json:"code" example:"a1b2c3"`
+	// 授权时下发的state，原样带回
+	State string `json:"state" example:"eyJwIjoiZ2l0aHViIn0.c2lnbmF0dXJl"`
+	// 获取授权地址时提交的客户端随机串
+	ClientNonce string `json:"client_nonce" example:"3f9a1c7e5b2d4a608e1f7c9b2a4d6e8f"`
+}
+
+// @Summary 获取第三方授权地址
+// @Description 获取跳转到第三方平台授权页的地址。携带登录token时，授权结果可用于绑定当前账号。
+// @Description client_nonce 由客户端生成并保存，授权回调后提交登录或绑定时需要带回，用于确认是同一个客户端
+// @Tags 用户认证
+// @Accept  json
+// @Produce  json
+// @Param authorizeReq body GetOauthAuthorizeUrlRequest true "平台"
+// @Success 200 {object} GetOauthAuthorizeUrlResponse "授权地址"
+// @Failure 400 {object} MessageResponse "不支持的第三方平台或 client_nonce 无效"
+// @Failure 500 {object} map[string]string "服务器错误"
+// @Router /get_oauth_authorize_url [post]
+func GetOauthAuthorizeUrlHandler(w http.ResponseWriter, r *http.Request) {
+	var req GetOauthAuthorizeUrlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "无效的请求体", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := oauth.Get(req.Platform)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error(), false)
+		return
+	}
+
+	userID := 0
+	if claims := auth.ClaimsFromRequest(r); claims != nil {
+		userID = claims.UserID
+	}
+	state, err := oauth.NewState(provider.Name(), userID, req.ClientNonce)
+	if errors.Is(err, oauth.ErrInvalidClientNonce) {
+		writeMessage(w, http.StatusBadRequest, err.Error(), false)
+		return
+	}
+	if err != nil {
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(GetOauthAuthorizeUrlResponse{
+		AuthorizeUrl: provider.AuthorizeURL(state),
+	})
+}
+
+// @Summary 第三方登录
+// @Description 使用第三方平台回调的授权码登录，第三方账号未绑定任何用户时自动创建用户；
+// @Description 需要带回获取授权地址时提交的 client_nonce，与state不匹配时拒绝登录
+// @Tags 用户认证
+// @Accept  json
+// @Produce  json
+// @Param loginReq body ThirdLoginRequest true "第三方登录请求参数"
+// @Success 200 {object} LoginResponse "登录成功"
+// @Failure 400 {object} MessageResponse "授权状态无效或不支持的平台"
+// @Failure 502 {object} MessageResponse "第三方平台授权失败"
+// @Failure 500 {object} map[string]string "服务器错误"
+// @Router /third_login [post]
+func ThirdLoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req ThirdLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "无效的请求体", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := oauth.Authenticate(r.Context(), req.Platform, req.Code, req.State, req.ClientNonce, 0)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, oauth.ErrProviderFailure) {
+			status = http.StatusBadGateway
+		}
+		writeMessage(w, status, err.Error(), false)
+		return
+	}
+
+	binding, err := models.GetUserOauth(req.Platform, profile.OpenID)
+	if err != nil {
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	var user *models.User
+	if binding != nil {
+		user, err = models.GetUserAuthByID(binding.UserID)
+	} else {
+		user, err = models.CreateUserByOauth(req.Platform, profile.OpenID, profile.Nickname, profile.Avatar)
+	}
+	if err != nil || user == nil {
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	writeLoginResponse(w, r, user)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/sms"
+	"github.com/jayden/personal-blog-backend/verifycode"
+)
+
+// 发送短信验证码请求结构体
+// @Description 发送短信验证码请求参数
+type SendPhoneVerifyCodeRequest struct {
+	// 手机号
+	Phone string `json:"phone" example:"13800138000"`
+	// 类型 login,bind_phone,unbind_phone
+	Type string `json:"type" example:"login"`
+}
+
+// 手机号登录请求结构体
+// @Description 手机号验证码登录请求参数
+type PhoneLoginRequest struct {
+	// 手机号
+	Phone string `json:"phone" example:"13800138000"`
+	// 短信验证码
+	VerifyCode string `json:"verify_code" example:"123456"`
+}
+
+// @Summary 发送短信验证码
+// @Description 向手机号发送验证码，用于验证码登录、绑定和解绑手机号
+// @Tags 用户认证
+// @Accept  json
+// @Produce  json
+// @Param sendReq body SendPhoneVerifyCodeRequest true "发送验证码请求参数"
+// @Success 200 {object} MessageResponse "验证码已发送"
+// @Failure 400 {object} MessageResponse "参数错误"
+// @Failure 409 {object} MessageResponse "手机号已被绑定"
+// @Failure 429 {object} MessageResponse "发送过于频繁"
+// @Failure 500 {object} MessageResponse "服务器错误"
+// @Router /send_phone_verify_code [post]
+func SendPhoneVerifyCodeHandler(w http.ResponseWriter, r *http.Request) {
+	var req SendPhoneVerifyCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "无效的请求体", http.StatusBadRequest)
+		return
+	}
+
+	phone, err := sms.NormalizePhone(req.Phone, sms.DefaultCountryCode)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error(), false)
+		return
+	}
+
+	switch req.Type {
+	case verifycode.SceneLogin:
+	case verifycode.SceneBindPhone:
+		existingUser, err := models.GetUserByPhone(phone)
+		if err != nil {
+			http.Error(w, "服务器错误", http.StatusInternalServerError)
+			return
+		}
+		if existingUser != nil {
+			writeMessage(w, http.StatusConflict, "手机号已被绑定", false)
+			return
+		}
+	case verifycode.SceneUnbindPhone:
+		existingUser, err := models.GetUserByPhone(phone)
+		if err != nil {
+			http.Error(w, "服务器错误", http.StatusInternalServerError)
+			return
+		}
+		// 手机号未绑定时同样返回成功，避免通过该接口探测手机号是否绑定
+		if existingUser == nil {
+			writeMessage(w, http.StatusOK, "验证码已发送", true)
+			return
+		}
+	default:
+		writeMessage(w, http.StatusBadRequest, verifycode.ErrInvalidScene.Error(), false)
+		return
+	}
+
+	if err := verifycode.PhoneCodes.Send(r.Context(), req.Type, phone); err != nil {
+		writeVerifyCodeError(w, err)
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "验证码已发送", true)
+}
+
+// @Summary 手机号登录
+// @Description 使用短信验证码登录，手机号未绑定任何账号时自动创建账号
+// @Tags 用户认证
+// @Accept  json
+// @Produce  json
+// @Param loginReq body PhoneLoginRequest true "手机号登录请求参数"
+// @Success 200 {object} LoginResponse "登录成功"
+// @Failure 400 {object} MessageResponse "手机号格式不正确或验证码错误"
+// @Failure 500 {object} map[string]string "服务器错误"
+// @Router /phone_login [post]
+func PhoneLoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req PhoneLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "无效的请求体", http.StatusBadRequest)
+		return
+	}
+
+	phone, err := sms.NormalizePhone(req.Phone, sms.DefaultCountryCode)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error(), false)
+		return
+	}
+
+	if err := verifycode.PhoneCodes.Verify(verifycode.SceneLogin, phone, req.VerifyCode); err != nil {
+		writeVerifyCodeError(w, err)
+		return
+	}
+
+	user, err := models.GetUserByPhone(phone)
+	if err != nil {
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		user, err = models.CreateUserByPhone(phone)
+		if err != nil {
+			// 并发登录时可能已被另一个请求创建，重新查询一次
+			user, _ = models.GetUserByPhone(phone)
+			if user == nil {
+				http.Error(w, "服务器错误", http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	writeLoginResponse(w, r, user)
+}
@@ -0,0 +1,363 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/upload"
+)
+
+// 保存相册请求结构体
+// @Description 新建或修改相册参数
+type AlbumNewReq struct {
+	// 相册ID，修改时必填
+	ID int64 `json:"id" example:"1"`
+	// 相册名
+	AlbumName string `json:"album_name" example:"旅行"`
+	// 相册描述
+	AlbumDesc string `json:"album_desc" example:"2024年的旅行照片"`
+	// 相册封面
+	AlbumCover string `json:"album_cover" example:"http://localhost:8083/uploads/3f/3f2a9c.jpg"`
+	// 是否私密
+	IsPrivate bool `json:"is_private" example:"false"`
+	// 访问密码，修改时为空表示不修改
+	AlbumPassword string `json:"album_password" example:""`
+	// 是否清除访问密码，仅修改时有效
+	ClearPassword bool `json:"clear_password" example:"false"`
+}
+
+// validate 校验相册参数
+func (req *AlbumNewReq) validate() string {
+	req.AlbumName = strings.TrimSpace(req.AlbumName)
+	if req.AlbumName == "" {
+		return "相册名不能为空"
+	}
+	if utf8.RuneCountInString(req.AlbumName) > 64 {
+		return "相册名不能超过64个字符"
+	}
+	if utf8.RuneCountInString(req.AlbumDesc) > 255 {
+		return "相册描述不能超过255个字符"
+	}
+	if req.AlbumPassword != "" && (len(req.AlbumPassword) < 4 || len(req.AlbumPassword) > 32) {
+		return "相册密码长度应为4-32位"
+	}
+	return ""
+}
+
+// @Summary 新建相册
+// @Description 新建相册，可以设置为私密或设置访问密码
+// @Tags 相册管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body AlbumNewReq true "相册信息"
+// @Success 200 {object} Response{data=models.Album} "新建相册成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/album/add_album [post]
+func AddAlbumHandler(w http.ResponseWriter, r *http.Request) {
+	var req AlbumNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	album := &models.Album{
+		AlbumName:  req.AlbumName,
+		AlbumDesc:  req.AlbumDesc,
+		AlbumCover: req.AlbumCover,
+		IsPrivate:  req.IsPrivate,
+	}
+	if req.AlbumPassword != "" {
+		hash, err := models.HashAlbumPassword(req.AlbumPassword)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		album.PasswordHash = hash
+	}
+	if err := models.CreateAlbum(album); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	album.HasPassword = album.PasswordHash != ""
+
+	writeSuccess(w, album, "新建相册成功")
+}
+
+// @Summary 修改相册
+// @Description 修改相册信息，访问密码为空时保持不变，clear_password 为 true 时清除密码
+// @Tags 相册管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body AlbumNewReq true "相册信息"
+// @Success 200 {object} Response "修改相册成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "相册不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/album/update_album [post]
+func UpdateAlbumHandler(w http.ResponseWriter, r *http.Request) {
+	var req AlbumNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	album, err := models.GetAlbumByID(req.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if album == nil {
+		writeError(w, http.StatusNotFound, "相册不存在")
+		return
+	}
+
+	album.AlbumName = req.AlbumName
+	album.AlbumDesc = req.AlbumDesc
+	album.AlbumCover = req.AlbumCover
+	album.IsPrivate = req.IsPrivate
+	switch {
+	case req.ClearPassword:
+		album.PasswordHash = ""
+	case req.AlbumPassword != "":
+		if album.PasswordHash, err = models.HashAlbumPassword(req.AlbumPassword); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if err := models.UpdateAlbum(album); err != nil {
+		writeAlbumError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改相册成功")
+}
+
+// @Summary 删除相册
+// @Description 删除相册及其中的照片记录，已上传的文件不会被删除
+// @Tags 相册管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body IdReq true "相册ID"
+// @Success 200 {object} Response "删除相册成功"
+// @Failure 404 {object} Response "相册不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/album/delete_album [post]
+func DeleteAlbumHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if err := models.DeleteAlbum(req.ID); err != nil {
+		writeAlbumError(w, err)
+		return
+	}
+	writeSuccess(w, nil, "删除相册成功")
+}
+
+// @Summary 上传照片到相册
+// @Description 上传一张或多张照片到相册，照片保存在 album/{album_id} 目录下，排在已有照片之后
+// @Tags 相册管理
+// @Accept  multipart/form-data
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param album_id formData int true "相册ID"
+// @Param files formData file true "照片列表"
+// @Success 200 {object} Response{data=[]models.Photo} "上传照片成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "相册不存在"
+// @Failure 413 {object} Response "文件大小超过限制"
+// @Failure 415 {object} Response "不支持的文件类型"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/album/add_photos [post]
+func AddPhotosHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFromContext(r.Context())
+	svc := upload.Default()
+
+	r.Body = http.MaxBytesReader(w, r.Body, svc.MaxSize()*int64(svc.MaxFiles())+1<<20)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "解析上传表单失败: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	albumID, err := strconv.ParseInt(r.FormValue("album_id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "无效的相册ID")
+		return
+	}
+	album, err := models.GetAlbumByID(albumID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if album == nil {
+		writeError(w, http.StatusNotFound, "相册不存在")
+		return
+	}
+
+	headers := r.MultipartForm.File["files"]
+	if len(headers) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要上传的照片")
+		return
+	}
+	if len(headers) > svc.MaxFiles() {
+		writeError(w, http.StatusBadRequest, "一次上传的照片数量超过限制")
+		return
+	}
+
+	dir := "album/" + strconv.FormatInt(albumID, 10)
+	photos := make([]models.Photo, 0, len(headers))
+	for _, header := range headers {
+		file, err := svc.SaveImage(r.Context(), userID, dir, header)
+		if err != nil {
+			writeUploadError(w, fmt.Errorf("%s: %w", header.Filename, err))
+			return
+		}
+		photos = append(photos, models.Photo{PhotoUrl: file.FileURL, PhotoName: file.FileName})
+	}
+
+	if err := models.AddPhotos(albumID, photos); err != nil {
+		writeAlbumError(w, err)
+		return
+	}
+	if err := models.AttachPhotoVariants(photos); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, photos, "上传照片成功")
+}
+
+// 删除照片请求结构体
+// @Description 删除相册中照片的参数
+type DeletePhotosReq struct {
+	// 相册ID
+	AlbumID int64 `json:"album_id" example:"1"`
+	// 照片ID列表
+	IDs []int64 `json:"ids" example:"1,2"`
+}
+
+// @Summary 删除照片
+// @Description 删除相册中的照片记录，已上传的文件不会被删除
+// @Tags 相册管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body DeletePhotosReq true "照片ID列表"
+// @Success 200 {object} Response{data=BatchResp} "删除照片成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/album/delete_photos [post]
+func DeletePhotosHandler(w http.ResponseWriter, r *http.Request) {
+	var req DeletePhotosReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要删除的照片")
+		return
+	}
+
+	count, err := models.DeletePhotos(req.AlbumID, req.IDs)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, BatchResp{SuccessCount: int(count)}, "删除照片成功")
+}
+
+// 照片排序请求结构体
+// @Description 照片排序参数
+type SortPhotosReq struct {
+	// 相册ID
+	AlbumID int64 `json:"album_id" example:"1"`
+	// 按新顺序排列的相册全部照片ID
+	PhotoIDs []int64 `json:"photo_ids" example:"3,1,2"`
+}
+
+// @Summary 照片排序
+// @Description 按给定顺序重排相册中的照片，必须包含相册中的全部照片
+// @Tags 相册管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body SortPhotosReq true "排序参数"
+// @Success 200 {object} Response "排序成功"
+// @Failure 400 {object} Response "照片列表与相册不一致"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/album/sort_photos [post]
+func SortPhotosHandler(w http.ResponseWriter, r *http.Request) {
+	var req SortPhotosReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := models.SortPhotos(req.AlbumID, req.PhotoIDs); err != nil {
+		if errors.Is(err, models.ErrPhotoNotFound) {
+			writeError(w, http.StatusBadRequest, "照片列表与相册中的照片不一致")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, nil, "排序成功")
+}
+
+// 设置相册封面请求结构体
+// @Description 设置相册封面参数
+type SetAlbumCoverReq struct {
+	// 相册ID
+	AlbumID int64 `json:"album_id" example:"1"`
+	// 作为封面的照片ID
+	PhotoID int64 `json:"photo_id" example:"2"`
+}
+
+// @Summary 设置相册封面
+// @Description 把相册中的一张照片设为封面
+// @Tags 相册管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body SetAlbumCoverReq true "封面参数"
+// @Success 200 {object} Response "设置封面成功"
+// @Failure 404 {object} Response "照片不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/album/set_album_cover [post]
+func SetAlbumCoverHandler(w http.ResponseWriter, r *http.Request) {
+	var req SetAlbumCoverReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if err := models.SetAlbumCover(req.AlbumID, req.PhotoID); err != nil {
+		writeAlbumError(w, err)
+		return
+	}
+	writeSuccess(w, nil, "设置封面成功")
+}
+
+// writeAlbumError 相册或照片不存在时返回404，其余返回500
+func writeAlbumError(w http.ResponseWriter, err error) {
+	if errors.Is(err, models.ErrAlbumNotFound) || errors.Is(err, models.ErrPhotoNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,298 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/slug"
+	"github.com/jayden/personal-blog-backend/taxonomy"
+)
+
+// 分类列表查询请求结构体
+// @Description 分类列表查询参数
+type CategoryQueryReq struct {
+	PageQueryReq
+	// 分类名，模糊匹配
+	CategoryName string `json:"category_name" example:"技术"`
+}
+
+// @Summary 获取分类列表
+// @Description 分页获取分类列表及每个分类的公开文章数（含子孙分类），按文章数倒序
+// @Tags 分类
+// @Accept  json
+// @Produce  json
+// @Param data body CategoryQueryReq false "查询参数"
+// @Success 200 {object} Response{data=PageResponse{list=[]models.CategoryVO}} "获取分类列表成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/category/find_category_list [post]
+func FindCategoryListHandler(w http.ResponseWriter, r *http.Request) {
+	var req CategoryQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	list, total, err := models.GetCategoryList(strings.TrimSpace(req.CategoryName), limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取分类列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     list,
+	}, "获取分类列表成功")
+}
+
+// @Summary 获取分类树
+// @Description 获取全部分类组成的树，每个分类的文章数包含其全部子孙分类的公开文章
+// @Tags 分类
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} Response{data=[]models.CategoryNode} "获取分类树成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/category/find_category_tree [post]
+func FindCategoryTreeHandler(w http.ResponseWriter, r *http.Request) {
+	tree, err := models.GetCategoryTree()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取分类树失败: "+err.Error())
+		return
+	}
+	writeSuccess(w, tree, "获取分类树成功")
+}
+
+// 保存分类请求结构体
+// @Description 添加或修改分类参数
+type CategoryNewReq struct {
+	// 分类ID，修改时必填
+	ID int64 `json:"id" example:"1"`
+	// 上级分类ID，顶级分类为0
+	ParentID int64 `json:"parent_id" example:"0"`
+	// 分类名
+	CategoryName string `json:"category_name" example:"技术"`
+	// 别名，只能包含小写字母、数字和-，为空时按分类名生成
+	Slug string `json:"slug" example:"ji-shu"`
+}
+
+// toCategory 校验参数并转换为分类模型
+func (req *CategoryNewReq) toCategory() (*models.Category, string) {
+	category := &models.Category{
+		ParentID: strconv.FormatInt(req.ParentID, 10),
+		Name:     strings.TrimSpace(req.CategoryName),
+		Slug:     strings.ToLower(strings.TrimSpace(req.Slug)),
+	}
+	if req.ID > 0 {
+		category.ID = strconv.FormatInt(req.ID, 10)
+	}
+	if req.ParentID < 0 {
+		return nil, "无效的上级分类"
+	}
+	if category.Name == "" || utf8.RuneCountInString(category.Name) > 32 {
+		return nil, "分类名不能为空且不能超过32个字符"
+	}
+	if category.Slug != "" && !slug.Valid(category.Slug) {
+		return nil, "别名只能包含小写字母、数字和-，且不能超过64个字符"
+	}
+	return category, ""
+}
+
+// @Summary 添加分类
+// @Description 添加分类，未指定别名时按分类名生成（中文转为拼音）；parent_id 不为0时作为该分类的子分类
+// @Tags 分类管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body CategoryNewReq true "分类信息"
+// @Success 200 {object} Response{data=models.Category} "添加分类成功"
+// @Failure 400 {object} Response "参数错误或上级分类不存在"
+// @Failure 409 {object} Response "分类名或别名已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/category/add_category [post]
+func AddCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req CategoryNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	req.ID = 0
+	category, msg := req.toCategory()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.CreateCategory(category); err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	writeSuccess(w, category, "添加分类成功")
+}
+
+// @Summary 修改分类
+// @Description 修改分类名、别名和上级分类，别名为空时按新分类名重新生成；上级分类不能是自身或自身的子孙分类。
+// @Description 分类下文章的检索索引随之更新
+// @Tags 分类管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body CategoryNewReq true "分类信息"
+// @Success 200 {object} Response{data=models.Category} "修改分类成功"
+// @Failure 400 {object} Response "参数错误、上级分类不存在或形成循环"
+// @Failure 404 {object} Response "分类不存在"
+// @Failure 409 {object} Response "分类名或别名已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/category/update_category [post]
+func UpdateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req CategoryNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.ID <= 0 {
+		writeError(w, http.StatusBadRequest, "分类ID不能为空")
+		return
+	}
+	category, msg := req.toCategory()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.UpdateCategory(category); err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	ids, err := models.GetArticleIDsByCategoryID(category.ID)
+	if err != nil {
+		log.Printf("同步分类 %s 的文章索引失败: %v", category.ID, err)
+	}
+	reindexArticles(ids)
+
+	writeSuccess(w, category, "修改分类成功")
+}
+
+// @Summary 删除分类
+// @Description 删除没有子分类和文章的分类；还有子分类或文章时需要先移动或合并到其他分类
+// @Tags 分类管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body IdReq true "分类ID"
+// @Success 200 {object} Response "删除分类成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "分类不存在"
+// @Failure 409 {object} Response "分类下还有子分类或文章"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/category/delete_category [post]
+func DeleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := models.DeleteCategory(strconv.FormatInt(req.ID, 10)); err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "删除分类成功")
+}
+
+// 合并请求结构体
+// @Description 把来源分类或标签合并到目标分类或标签
+type MergeReq struct {
+	// 来源ID，合并后删除
+	SourceID int64 `json:"source_id" example:"2"`
+	// 目标ID
+	TargetID int64 `json:"target_id" example:"1"`
+}
+
+// 合并结果
+// @Description 合并分类或标签的结果
+type MergeResp struct {
+	// 受影响的文章数
+	ArticleCount int `json:"article_count" example:"3"`
+}
+
+// validate 校验合并参数
+func (req *MergeReq) validate() string {
+	if req.SourceID <= 0 || req.TargetID <= 0 {
+		return "来源和目标不能为空"
+	}
+	if req.SourceID == req.TargetID {
+		return "来源和目标不能相同"
+	}
+	return ""
+}
+
+// @Summary 合并分类
+// @Description 把来源分类下的文章和子分类移到目标分类，然后删除来源分类；目标分类不能是来源分类的子孙分类
+// @Tags 分类管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body MergeReq true "合并参数"
+// @Success 200 {object} Response{data=MergeResp} "合并分类成功"
+// @Failure 400 {object} Response "参数错误或形成循环"
+// @Failure 404 {object} Response "分类不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/category/merge_category [post]
+func MergeCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req MergeReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	articleIDs, err := models.MergeCategory(strconv.FormatInt(req.SourceID, 10), strconv.FormatInt(req.TargetID, 10))
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	reindexArticles(articleIDs)
+
+	writeSuccess(w, MergeResp{ArticleCount: len(articleIDs)}, "合并分类成功")
+}
+
+// @Summary 校正分类和标签计数
+// @Description 按公开文章重新计算全部分类和标签的文章数，并为没有别名的分类和标签补齐别名
+// @Tags 分类管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} Response{data=models.TaxonomyReconcileResult} "校正成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/category/reconcile_count [post]
+func ReconcileCountHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := taxonomy.Reconcile()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeSuccess(w, result, "校正成功")
+}
+
+// writeCategoryError 分类不存在时返回404，上级分类不存在或形成循环时返回400，
+// 名称或别名重复、分类下还有子分类或文章时返回409，其余返回500
+func writeCategoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrCategoryNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrCategoryParentNotFound), errors.Is(err, models.ErrCategoryCycle):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrCategoryNameExists), errors.Is(err, models.ErrCategorySlugExists),
+		errors.Is(err, models.ErrCategoryInUse), errors.Is(err, models.ErrCategoryHasChildren):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
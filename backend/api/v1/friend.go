@@ -0,0 +1,404 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/friendcheck"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/verifycode"
+)
+
+// 友链列表查询请求结构体
+// @Description 友链列表查询参数
+type FriendQueryReq struct {
+	PageQueryReq
+}
+
+// @Summary 获取友链列表
+// @Description 分页获取已通过审核且未失效的友链
+// @Tags 友链
+// @Accept  json
+// @Produce  json
+// @Param data body FriendQueryReq false "分页参数"
+// @Success 200 {object} Response{data=PageResponse} "获取友链列表成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/friend_link/find_friend_list [post]
+func FindFriendListHandler(w http.ResponseWriter, r *http.Request) {
+	var req FriendQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	status, isDead := models.FriendStatusApproved, 0
+	friends, total, err := models.GetFriends(limit, offset, models.FriendFilter{Status: &status, IsDead: &isDead})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取友链列表失败: "+err.Error())
+		return
+	}
+	for _, friend := range friends {
+		friend.ContactEmail = ""
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     friends,
+	}, "获取友链列表成功")
+}
+
+// 申请友链请求结构体
+// @Description 申请友链参数
+type FriendApplyReq struct {
+	// 链接名
+	LinkName string `json:"link_name" example:"Jayden的博客"`
+	// 链接头像
+	LinkAvatar string `json:"link_avatar" example:"https://example.com/avatar.png"`
+	// 链接地址
+	LinkAddress string `json:"link_address" example:"https://example.com"`
+	// 链接介绍
+	LinkIntro string `json:"link_intro" example:"记录学习和生活"`
+	// 联系邮箱，审核结果会通过邮箱告知
+	ContactEmail string `json:"contact_email" example:"jayden@example.com"`
+}
+
+// toFriend 校验参数并转换为友链模型
+func (req *FriendApplyReq) toFriend() (*models.Friend, string) {
+	friend := &models.Friend{
+		LinkName:    strings.TrimSpace(req.LinkName),
+		LinkAvatar:  strings.TrimSpace(req.LinkAvatar),
+		LinkAddress: strings.TrimSpace(req.LinkAddress),
+		LinkIntro:   strings.TrimSpace(req.LinkIntro),
+	}
+	if friend.LinkName == "" || utf8.RuneCountInString(friend.LinkName) > 64 {
+		return nil, "链接名不能为空且不能超过64个字符"
+	}
+	if !isHTTPURL(friend.LinkAddress) {
+		return nil, "链接地址必须是 http 或 https 地址"
+	}
+	if friend.LinkAvatar != "" && !isHTTPURL(friend.LinkAvatar) {
+		return nil, "链接头像必须是 http 或 https 地址"
+	}
+	if utf8.RuneCountInString(friend.LinkIntro) > 255 {
+		return nil, "链接介绍不能超过255个字符"
+	}
+	if email := strings.TrimSpace(req.ContactEmail); email != "" {
+		normalized, ok := verifycode.NormalizeEmail(email)
+		if !ok {
+			return nil, "邮箱格式不正确"
+		}
+		friend.ContactEmail = normalized
+	}
+	return friend, ""
+}
+
+// isHTTPURL 判断是否为不超过255个字符的 http 或 https 绝对地址
+func isHTTPURL(raw string) bool {
+	if len(raw) > 255 {
+		return false
+	}
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// @Summary 申请友链
+// @Description 提交友链申请，管理员审核通过后展示
+// @Tags 友链
+// @Accept  json
+// @Produce  json
+// @Param data body FriendApplyReq true "友链信息"
+// @Success 200 {object} Response "申请已提交"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 409 {object} Response "友链地址已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/friend_link/apply_friend_link [post]
+func ApplyFriendLinkHandler(w http.ResponseWriter, r *http.Request) {
+	var req FriendApplyReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	friend, msg := req.toFriend()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	friend.Status = models.FriendStatusPending
+	if err := models.CreateFriend(friend); err != nil {
+		writeFriendError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "申请已提交，审核通过后展示")
+}
+
+// 后台友链列表查询请求结构体
+// @Description 后台友链列表查询参数
+type AdminFriendQueryReq struct {
+	PageQueryReq
+	// 审核状态 0待审核 1已通过 2已拒绝，为空时返回全部
+	Status *int `json:"status" example:"0"`
+	// 是否已失效 0否 1是，为空时返回全部
+	IsDead *int `json:"is_dead" example:"1"`
+}
+
+// @Summary 获取后台友链列表
+// @Description 分页获取全部友链及检测结果，可按审核状态和是否失效过滤
+// @Tags 友链管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body AdminFriendQueryReq false "查询参数"
+// @Success 200 {object} Response{data=PageResponse} "获取友链列表成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/friend_link/find_friend_list [post]
+func AdminFindFriendListHandler(w http.ResponseWriter, r *http.Request) {
+	var req AdminFriendQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	friends, total, err := models.GetFriends(limit, offset, models.FriendFilter{Status: req.Status, IsDead: req.IsDead})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取友链列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     friends,
+	}, "获取友链列表成功")
+}
+
+// 保存友链请求结构体
+// @Description 添加或修改友链参数
+type FriendNewReq struct {
+	FriendApplyReq
+	// 友链ID，修改时必填
+	ID int64 `json:"id" example:"1"`
+	// 审核状态 0待审核 1已通过 2已拒绝，添加时默认已通过
+	Status *int `json:"status" example:"1"`
+}
+
+// toFriend 校验参数并转换为友链模型
+func (req *FriendNewReq) toFriend() (*models.Friend, string) {
+	friend, msg := req.FriendApplyReq.toFriend()
+	if msg != "" {
+		return nil, msg
+	}
+	friend.ID = req.ID
+	friend.Status = models.FriendStatusApproved
+	if req.Status != nil {
+		if !validFriendStatus(*req.Status) {
+			return nil, "无效的审核状态"
+		}
+		friend.Status = *req.Status
+	}
+	return friend, ""
+}
+
+// validFriendStatus 判断审核状态是否合法
+func validFriendStatus(status int) bool {
+	return status == models.FriendStatusPending || status == models.FriendStatusApproved || status == models.FriendStatusRejected
+}
+
+// @Summary 添加友链
+// @Description 管理员直接添加友链，默认已通过审核
+// @Tags 友链管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body FriendNewReq true "友链信息"
+// @Success 200 {object} Response{data=models.Friend} "添加友链成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 409 {object} Response "友链地址已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/friend_link/add_friend [post]
+func AddFriendHandler(w http.ResponseWriter, r *http.Request) {
+	var req FriendNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	friend, msg := req.toFriend()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.CreateFriend(friend); err != nil {
+		writeFriendError(w, err)
+		return
+	}
+
+	writeSuccess(w, friend, "添加友链成功")
+}
+
+// @Summary 修改友链
+// @Description 修改友链信息和审核状态，链接地址变化时清空检测结果
+// @Tags 友链管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body FriendNewReq true "友链信息"
+// @Success 200 {object} Response "修改友链成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "友链不存在"
+// @Failure 409 {object} Response "友链地址已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/friend_link/update_friend [post]
+func UpdateFriendHandler(w http.ResponseWriter, r *http.Request) {
+	var req FriendNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	friend, msg := req.toFriend()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.UpdateFriend(friend); err != nil {
+		writeFriendError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改友链成功")
+}
+
+// 审核友链请求结构体
+// @Description 批量审核友链参数
+type UpdateFriendStatusReq struct {
+	// 友链ID列表
+	IDs []int64 `json:"ids" example:"1,2"`
+	// 审核状态 0待审核 1已通过 2已拒绝
+	Status int `json:"status" example:"1"`
+}
+
+// @Summary 审核友链
+// @Description 批量修改友链的审核状态
+// @Tags 友链管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateFriendStatusReq true "友链ID列表和审核状态"
+// @Success 200 {object} Response{data=BatchResp} "审核友链成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/friend_link/update_friend_status [post]
+func UpdateFriendStatusHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateFriendStatusReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要审核的友链")
+		return
+	}
+	if !validFriendStatus(req.Status) {
+		writeError(w, http.StatusBadRequest, "无效的审核状态")
+		return
+	}
+
+	count, err := models.UpdateFriendsStatus(req.IDs, req.Status)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, BatchResp{SuccessCount: int(count)}, "审核友链成功")
+}
+
+// 删除友链请求结构体
+// @Description 批量删除友链参数
+type DeletesFriendReq struct {
+	// 友链ID列表
+	IDs []int64 `json:"ids" example:"1,2"`
+}
+
+// @Summary 删除友链
+// @Description 批量删除友链及其评论
+// @Tags 友链管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body DeletesFriendReq true "友链ID列表"
+// @Success 200 {object} Response{data=BatchResp} "删除友链成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/friend_link/deletes_friend [post]
+func DeletesFriendHandler(w http.ResponseWriter, r *http.Request) {
+	var req DeletesFriendReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要删除的友链")
+		return
+	}
+
+	count, err := models.DeleteFriends(req.IDs)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, BatchResp{SuccessCount: int(count)}, "删除友链成功")
+}
+
+// @Summary 检测友链
+// @Description 立即检测一个友链是否可以访问、是否有指向本站的链接，并返回检测后的友链
+// @Tags 友链管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body IdReq true "友链ID"
+// @Success 200 {object} Response{data=models.Friend} "检测友链完成"
+// @Failure 404 {object} Response "友链不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/friend_link/check_friend [post]
+func CheckFriendHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	friend, err := models.GetFriendByID(req.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if friend == nil {
+		writeError(w, http.StatusNotFound, "友链不存在")
+		return
+	}
+
+	if err := friendcheck.Default().CheckFriend(r.Context(), friend); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if friend, err = models.GetFriendByID(req.ID); err != nil || friend == nil {
+		writeError(w, http.StatusInternalServerError, "获取检测结果失败")
+		return
+	}
+
+	writeSuccess(w, friend, "检测友链完成")
+}
+
+// writeFriendError 友链不存在时返回404，地址重复时返回409，其余返回500
+func writeFriendError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrFriendNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrFriendExists):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
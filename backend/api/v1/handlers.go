@@ -2,10 +2,25 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/ipgeo"
 	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/oauth"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+	"github.com/jayden/personal-blog-backend/sms"
+	"github.com/jayden/personal-blog-backend/spamfilter"
+	"github.com/jayden/personal-blog-backend/tourist"
+	"github.com/jayden/personal-blog-backend/verifycode"
+	"github.com/jayden/personal-blog-backend/viewstat"
 )
 
 // 响应结构体
@@ -34,61 +49,165 @@ type PageResponse struct {
 	List interface{} `json:"list"`
 }
 
+// ID请求结构体
+// @Description 通用ID请求参数
+type IdReq struct {
+	// ID
+	ID int64 `json:"id" example:"1"`
+}
+
+// 分页查询请求结构体
+// @Description 通用分页查询参数
+type PageQueryReq struct {
+	// 页码，从1开始
+	Page int `json:"page" example:"1"`
+	// 每页数量，默认10，最多100
+	PageSize int `json:"page_size" example:"10"`
+}
+
+// normalize 补全默认分页参数，返回数据库查询的 limit 和 offset
+func (req *PageQueryReq) normalize() (limit, offset int) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 10
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+	return req.PageSize, (req.Page - 1) * req.PageSize
+}
+
+// 相册列表查询请求结构体
+// @Description 相册列表查询参数
+type AlbumQueryReq struct {
+	PageQueryReq
+}
+
 // @Summary 获取相册列表
-// @Description 获取相册列表，支持分页
+// @Description 获取相册列表，支持分页；私密相册只有管理员可见
 // @Tags 相册
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取相册列表成功"
+// @Param data body AlbumQueryReq false "分页参数"
+// @Success 200 {object} Response{data=PageResponse} "获取相册列表成功"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/album/find_album_list [post]
 func FindAlbumListHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取相册列表逻辑
-	response := Response{
-		Code: 200,
-		Data: []models.Album{},
-		Msg:  "获取相册列表成功",
+	var req AlbumQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	albums, total, err := models.GetAlbums(limit, offset, auth.IsAdminRequest(r))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取相册列表失败: "+err.Error())
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     albums,
+	}, "获取相册列表成功")
+}
+
+// 照片列表查询请求结构体
+// @Description 照片列表查询参数
+type PhotoQueryReq struct {
+	PageQueryReq
+	// 相册ID
+	AlbumID int64 `json:"album_id" example:"1"`
+	// 相册访问密码，相册设置了密码时必填
+	AlbumPassword string `json:"album_password" example:""`
 }
 
 // @Summary 获取相册下的照片列表
-// @Description 根据相册ID获取照片列表，支持分页
+// @Description 根据相册ID获取照片列表，支持分页，未指定每页数量时返回前100张；设置了密码的相册需要提供密码
 // @Tags 相册
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取照片列表成功"
+// @Param data body PhotoQueryReq true "查询参数"
+// @Success 200 {object} Response{data=PageResponse} "获取照片列表成功"
+// @Failure 403 {object} Response "相册密码错误"
+// @Failure 404 {object} Response "相册不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/album/find_photo_list [post]
 func FindPhotoListHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取相册下的照片列表逻辑
-	response := Response{
-		Code: 200,
-		Data: []models.Photo{},
-		Msg:  "获取照片列表成功",
+	var req PhotoQueryReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	if req.PageSize <= 0 {
+		req.PageSize = 100
+	}
+	limit, offset := req.normalize()
+
+	album, ok := visibleAlbum(w, r, req.AlbumID)
+	if !ok {
+		return
+	}
+	if !auth.IsAdminRequest(r) && !models.VerifyAlbumPassword(album, req.AlbumPassword) {
+		if req.AlbumPassword == "" {
+			writeError(w, http.StatusForbidden, "该相册需要密码才能查看")
+		} else {
+			writeError(w, http.StatusForbidden, "相册密码错误")
+		}
+		return
+	}
+
+	photos, total, err := models.GetPhotosByAlbumID(album.ID, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取照片列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     photos,
+	}, "获取照片列表成功")
 }
 
 // @Summary 获取相册详情
-// @Description 根据相册ID获取相册详情
+// @Description 根据相册ID获取相册详情，has_password 为 true 时获取照片需要提供密码
 // @Tags 相册
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取相册成功"
+// @Param data body IdReq true "相册ID"
+// @Success 200 {object} Response{data=models.Album} "获取相册成功"
+// @Failure 404 {object} Response "相册不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/album/get_album [post]
 func GetAlbumHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取相册详情逻辑
-	response := Response{
-		Code: 200,
-		Data: models.Album{},
-		Msg:  "获取相册成功",
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	album, ok := visibleAlbum(w, r, req.ID)
+	if !ok {
+		return
+	}
+
+	writeSuccess(w, album, "获取相册成功")
+}
+
+// visibleAlbum 获取当前请求可见的相册，私密相册对非管理员视为不存在；失败时写入错误响应并返回 false
+func visibleAlbum(w http.ResponseWriter, r *http.Request, id int64) (*models.Album, bool) {
+	album, err := models.GetAlbumByID(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取相册失败: "+err.Error())
+		return nil, false
+	}
+	if album == nil || (album.IsPrivate && !auth.IsAdminRequest(r)) {
+		writeError(w, http.StatusNotFound, "相册不存在")
+		return nil, false
+	}
+	return album, true
 }
 
 // @Summary 文章归档
@@ -110,23 +229,70 @@ func GetArticleArchivesHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// 分类文章列表查询请求结构体
+// @Description 按分类获取文章列表的查询参数，分类ID和分类名二选一
+type ArticleClassifyQueryReq struct {
+	PageQueryReq
+	// 分类ID
+	CategoryID int64 `json:"category_id" example:"1"`
+	// 分类名，未传分类ID时使用
+	ClassifyName string `json:"classify_name" example:"后端"`
+	// 是否包含子孙分类的文章
+	IncludeChildren bool `json:"include_children" example:"true"`
+}
+
 // @Summary 通过分类获取文章列表
-// @Description 通过分类获取文章列表，支持分页
+// @Description 通过分类获取公开文章列表，支持分页；include_children 为 true 时包含全部子孙分类的文章
 // @Tags 文章
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取分类文章列表成功"
+// @Param data body ArticleClassifyQueryReq true "查询参数"
+// @Success 200 {object} Response{data=PageResponse{list=[]models.Article}} "获取分类文章列表成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "分类不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/article/get_article_classify_category [post]
 func GetArticleClassifyCategoryHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现通过分类获取文章列表逻辑
-	response := Response{
-		Code: 200,
-		Data: []models.Article{},
-		Msg:  "获取分类文章列表成功",
+	var req ArticleClassifyQueryReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	limit, offset := req.normalize()
+
+	var (
+		category *models.Category
+		err      error
+	)
+	switch {
+	case req.CategoryID > 0:
+		category, err = models.GetCategoryByID(strconv.FormatInt(req.CategoryID, 10))
+	case strings.TrimSpace(req.ClassifyName) != "":
+		category, err = models.GetCategoryByName(strings.TrimSpace(req.ClassifyName))
+	default:
+		writeError(w, http.StatusBadRequest, "分类ID和分类名不能都为空")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取分类失败: "+err.Error())
+		return
+	}
+	if category == nil {
+		writeError(w, http.StatusNotFound, "分类不存在")
+		return
+	}
+
+	articles, total, err := models.GetArticlesByCategoryID(category.ID, req.IncludeChildren, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取分类文章列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     articles,
+	}, "获取分类文章列表成功")
 }
 
 // @Summary 通过标签获取文章列表
@@ -149,35 +315,105 @@ func GetArticleClassifyTagHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 获取文章详情
-// @Description 根据文章ID获取文章详情
+// @Description 根据文章ID获取文章详情，category_path 为从顶级分类到所属分类的面包屑；未公开的文章只有管理员可以查看
 // @Tags 文章
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取文章详情成功"
+// @Param data body IdReq true "文章ID"
+// @Success 200 {object} Response{data=models.ArticleDetails} "获取文章详情成功"
+// @Failure 400 {object} Response "无效的请求体"
+// @Failure 404 {object} Response "文章不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/article/get_article_details [post]
 func GetArticleDetailsHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取文章详情逻辑
+	var req IdReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response := Response{
+			Code: 400,
+			Data: nil,
+			Msg:  "无效的请求体",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	articleID := strconv.FormatInt(req.ID, 10)
+	article, err := models.GetArticleByID(articleID)
+	if err != nil {
+		response := Response{
+			Code: 500,
+			Data: nil,
+			Msg:  "获取文章详情失败: " + err.Error(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+	// 未公开的文章只有管理员可以查看
+	if article == nil || (article.Status != models.ArticleStatusPublic && !auth.IsAdminRequest(r)) {
+		response := Response{
+			Code: 404,
+			Data: nil,
+			Msg:  "文章不存在",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// 只记录公开文章的浏览量，管理员预览未公开的文章不计入；使用查到的文章ID，避免请求中的ID格式不一致
+	if article.Status == models.ArticleStatusPublic {
+		viewstat.RecordArticleView(r, article.ID)
+	}
+
+	// 所属分类的面包屑，获取失败时不影响文章详情
+	categoryPath, err := models.GetCategoryPath(article.CategoryID)
+	if err != nil {
+		log.Printf("获取文章 %s 的分类路径失败: %v", articleID, err)
+		categoryPath = []models.CategoryCrumb{}
+	}
+
 	response := Response{
 		Code: 200,
-		Data: map[string]interface{}{},
+		Data: models.ArticleDetails{Article: *article, CategoryPath: categoryPath},
 		Msg:  "获取文章详情成功",
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// 首页文章列表查询请求结构体
+// @Description 首页文章列表查询参数
+type ArticleHomeQueryReq struct {
+	PageQueryReq
+	// 标题关键词，不为空时按关键词检索文章
+	ArticleTitle string `json:"article_title" example:"Go"`
+}
+
 // @Summary 获取首页文章列表
-// @Description 获取首页文章列表，支持分页
+// @Description 获取首页文章列表，支持分页；请求体带 article_title 时按关键词检索，结果同检索文章接口
 // @Tags 文章
 // @Accept  json
 // @Produce  json
 // @Param page query int false "页码" default(1)
 // @Param limit query int false "每页数量" default(10)
+// @Param data body ArticleHomeQueryReq false "检索参数"
 // @Success 200 {object} Response "获取首页文章列表成功"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/article/get_article_home_list [post]
 func GetArticleHomeListHandler(w http.ResponseWriter, r *http.Request) {
+	// 请求体可以为空，解析失败时使用默认值
+	var req ArticleHomeQueryReq
+	json.NewDecoder(r.Body).Decode(&req)
+	if strings.TrimSpace(req.ArticleTitle) != "" {
+		writeArticleSearch(w, req.ArticleTitle, req.PageQueryReq)
+		return
+	}
+
 	// 实现获取首页文章列表逻辑
 	// 获取分页参数
 	pageStr := r.URL.Query().Get("page")
@@ -214,8 +450,18 @@ func GetArticleHomeListHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取文章总数
-	// 暂时使用固定值，后续需要实现获取文章总数的方法
-	total := int64(0)
+	total, err := models.GetArticleCount()
+	if err != nil {
+		response := Response{
+			Code: 500,
+			Data: nil,
+			Msg:  "获取文章总数失败: " + err.Error(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
 
 	pageResponse := PageResponse{
 		Page:     page,
@@ -273,23 +519,94 @@ func LikeArticleHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// 评论列表查询请求结构体
+// @Description 评论列表查询参数
+type CommentQueryReq struct {
+	PageQueryReq
+	// 主题ID，文章或说说的ID，友链页面为0
+	TopicID int64 `json:"topic_id" example:"1"`
+	// 父评论ID，查询回复列表时为所在楼层的评论ID
+	ParentID int64 `json:"parent_id" example:"0"`
+	// 评论类型 1文章 2友链 3说说
+	Type int `json:"type" example:"1"`
+}
+
+// checkCommentTopic 检查评论的主题是否存在且公开，不通过时写入错误响应；
+// 友链评论没有主题，返回的主题ID为0
+func checkCommentTopic(w http.ResponseWriter, commentType int, topicID int64) (int64, bool) {
+	switch commentType {
+	case models.CommentTypeArticle:
+		article, err := models.GetPublicArticle(strconv.FormatInt(topicID, 10))
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return 0, false
+		}
+		if article == nil {
+			writeError(w, http.StatusNotFound, "文章不存在")
+			return 0, false
+		}
+	case models.CommentTypeTalk:
+		talk, err := models.GetTalkByID(topicID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return 0, false
+		}
+		if talk == nil || talk.Status != models.TalkStatusPublic {
+			writeError(w, http.StatusNotFound, "说说不存在")
+			return 0, false
+		}
+	case models.CommentTypeFriend:
+		topicID = 0
+	default:
+		writeError(w, http.StatusBadRequest, "无效的评论类型")
+		return 0, false
+	}
+	return topicID, true
+}
+
+// maskCommentIPs 公开列表只展示评论人IP的前半部分
+func maskCommentIPs(comments []*models.Comment) {
+	for _, comment := range comments {
+		comment.IpAddress = netutil.MaskIP(comment.IpAddress)
+		maskCommentIPs(comment.CommentReplyList)
+	}
+}
+
 // @Summary 查询评论列表
-// @Description 查询评论列表，支持分页
+// @Description 分页查询文章、友链页面或说说下的顶层评论，按发布时间倒序，每条评论附带回复数和最新的3条回复；评论人的IP只展示前半部分
 // @Tags 评论
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取评论列表成功"
+// @Param data body CommentQueryReq true "查询参数"
+// @Success 200 {object} Response{data=PageResponse{list=[]models.Comment}} "获取评论列表成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "评论对象不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/comment/find_comment_list [post]
 func FindCommentListHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现查询评论列表逻辑
-	response := Response{
-		Code: 200,
-		Data: []models.Comment{},
-		Msg:  "获取评论列表成功",
+	var req CommentQueryReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	limit, offset := req.normalize()
+	topicID, ok := checkCommentTopic(w, req.Type, req.TopicID)
+	if !ok {
+		return
+	}
+
+	comments, total, err := models.GetComments(req.Type, topicID, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取评论列表失败: "+err.Error())
+		return
+	}
+	maskCommentIPs(comments)
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     comments,
+	}, "获取评论列表成功")
 }
 
 // @Summary 查询最新评论回复列表
@@ -312,41 +629,140 @@ func FindCommentRecentListHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary 查询评论回复列表
-// @Description 查询评论回复列表，支持分页
+// @Description 分页查询一条顶层评论下的回复，按发布时间倒序；评论人的IP只展示前半部分
 // @Tags 评论
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取评论回复列表成功"
+// @Param data body CommentQueryReq true "查询参数，parent_id 为顶层评论ID"
+// @Success 200 {object} Response{data=PageResponse{list=[]models.Comment}} "获取评论回复列表成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "评论对象不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/comment/find_comment_reply_list [post]
 func FindCommentReplyListHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现查询评论回复列表逻辑
-	response := Response{
-		Code: 200,
-		Data: []models.Comment{},
-		Msg:  "获取评论回复列表成功",
+	var req CommentQueryReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	limit, offset := req.normalize()
+	if req.ParentID <= 0 {
+		writeError(w, http.StatusBadRequest, "请选择要查看回复的评论")
+		return
+	}
+	topicID, ok := checkCommentTopic(w, req.Type, req.TopicID)
+	if !ok {
+		return
+	}
+
+	replies, total, err := models.GetCommentReplies(req.Type, topicID, req.ParentID, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取评论回复列表失败: "+err.Error())
+		return
+	}
+	maskCommentIPs(replies)
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     replies,
+	}, "获取评论回复列表成功")
+}
+
+// maxCommentLength 评论内容的最大字符数
+const maxCommentLength = 500
+
+// 创建评论请求结构体
+// @Description 创建评论参数
+type CommentNewReq struct {
+	// 主题ID，文章或说说的ID，友链页面为0
+	TopicID int64 `json:"topic_id" example:"1"`
+	// 父评论ID，直接评论主题时为0
+	ParentID int64 `json:"parent_id" example:"0"`
+	// 会话ID，回复时为所在楼层的评论ID
+	ReplyMsgID int64 `json:"reply_msg_id" example:"0"`
+	// 被回复用户ID
+	ReplyUserID string `json:"reply_user_id" example:""`
+	// 评论内容
+	CommentContent string `json:"comment_content" example:"写得很好"`
+	// 评论类型 1文章 2友链 3说说
+	Type int `json:"type" example:"1"`
 }
 
 // @Summary 创建评论
-// @Description 创建评论
+// @Description 登录用户发表评论或回复，按请求IP解析归属地一并保存
 // @Tags 评论
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "创建评论成功"
+// @Security ApiKeyAuth
+// @Param data body CommentNewReq true "评论内容"
+// @Success 200 {object} Response{data=models.Comment} "创建评论成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 401 {object} Response "请先登录"
+// @Failure 404 {object} Response "评论对象不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/comment/add_comment [post]
 func AddCommentHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现创建评论逻辑
-	response := Response{
-		Code: 200,
-		Data: models.Comment{},
-		Msg:  "创建评论成功",
+	claims := auth.ClaimsFromRequest(r)
+	if claims == nil {
+		writeError(w, http.StatusUnauthorized, "请先登录")
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	var req CommentNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	content := strings.TrimSpace(req.CommentContent)
+	if content == "" {
+		writeError(w, http.StatusBadRequest, "评论内容不能为空")
+		return
+	}
+	if utf8.RuneCountInString(content) > maxCommentLength {
+		writeError(w, http.StatusBadRequest, "评论内容不能超过500个字符")
+		return
+	}
+	if err := spamfilter.Check(content); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	topicID, ok := checkCommentTopic(w, req.Type, req.TopicID)
+	if !ok {
+		return
+	}
+	req.TopicID = topicID
+	if req.ParentID > 0 {
+		exists, err := models.CommentExists(req.ParentID, req.Type, req.TopicID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !exists {
+			writeError(w, http.StatusNotFound, "回复的评论不存在")
+			return
+		}
+	} else {
+		req.ReplyMsgID, req.ReplyUserID = 0, ""
+	}
+
+	ip := netutil.ClientIP(r)
+	comment := &models.Comment{
+		TopicID:        req.TopicID,
+		ParentID:       req.ParentID,
+		ReplyMsgID:     req.ReplyMsgID,
+		UserID:         models.FormatUserID(claims.UserID),
+		ReplyUserID:    strings.TrimSpace(req.ReplyUserID),
+		CommentContent: content,
+		IpAddress:      ip,
+		IpSource:       ipgeo.Lookup(ip),
+		Type:           req.Type,
+	}
+	if err := models.CreateComment(comment); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, comment, "创建评论成功")
 }
 
 // @Summary 点赞评论
@@ -387,65 +803,141 @@ func UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// 博客前台首页信息响应结构体
+// @Description 博客前台首页信息
+type BlogHomeInfoResp struct {
+	// 公开文章数量
+	ArticleCount int64 `json:"article_count" example:"10"`
+	// 分类数量
+	CategoryCount int64 `json:"category_count" example:"3"`
+	// 标签数量
+	TagCount int64 `json:"tag_count" example:"8"`
+	// 总访客数
+	TotalUserViewCount int64 `json:"total_user_view_count" example:"100"`
+	// 总浏览量
+	TotalPageViewCount int64 `json:"total_page_view_count" example:"1000"`
+	// 页面列表
+	PageList []models.PageVO `json:"page_list"`
+	// 网站配置
+	WebsiteConfig WebsiteConfigVO `json:"website_config"`
+}
+
 // @Summary 获取博客前台首页信息
-// @Description 获取博客前台首页信息
+// @Description 获取博客前台首页信息，包括文章、分类、标签数量，访问统计，页面列表和网站配置
 // @Tags 博客
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取博客前台首页信息成功"
+// @Success 200 {object} Response{data=BlogHomeInfoResp} "获取博客前台首页信息成功"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/blog [get]
 func GetBlogHomeInfoHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取博客前台首页信息逻辑
-	response := Response{
-		Code: 200,
-		Data: map[string]interface{}{
-			"article_count":         0,
-			"category_count":        0,
-			"tag_count":             0,
-			"total_user_view_count": 0,
-			"total_page_view_count": 0,
-			"page_list":             []interface{}{},
-			"website_config": map[string]interface{}{
-				"admin_url":      "",
-				"websocket_url":  "",
-				"tourist_avatar": "",
-				"user_avatar":    "",
-				"website_feature": map[string]interface{}{
-					"is_chat_room":      0,
-					"is_comment_review": 0,
-					"is_email_notice":   0,
-					"is_message_review": 0,
-					"is_music_player":   0,
-					"is_reward":         0,
-				},
-				"social_login_list": []interface{}{},
-				"social_url_list":   []interface{}{},
-			},
-		},
-		Msg: "获取博客前台首页信息成功",
+	// 前台每次进入站点都会请求首页信息，以此记录一次页面浏览
+	viewstat.RecordPageView(r)
+
+	pageViewCount, userViewCount, err := viewstat.Totals()
+	if err != nil {
+		response := Response{
+			Code: 500,
+			Data: nil,
+			Msg:  "获取访问统计失败: " + err.Error(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	pageList, err := models.GetPageVOs()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	websiteConfig, _, err := siteconfig.Website()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	articleCount, err := models.GetArticleCount()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	categoryCount, err := models.CountCategories()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	tagCount, err := models.CountTags()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, BlogHomeInfoResp{
+		ArticleCount:       articleCount,
+		CategoryCount:      categoryCount,
+		TagCount:           tagCount,
+		TotalUserViewCount: userViewCount,
+		TotalPageViewCount: pageViewCount,
+		PageList:           pageList,
+		WebsiteConfig: WebsiteConfigVO{
+			WebsiteConfig:   *websiteConfig,
+			SocialLoginList: socialLoginList(),
+		},
+	}, "获取博客前台首页信息成功")
+}
+
+// 删除用户绑定第三方平台账号请求结构体
+// @Description 删除用户绑定第三方平台账号请求参数
+type DeleteUserBindThirdPartyReq struct {
+	// 平台
+	Platform string `json:"platform" example:"github"`
 }
 
 // @Summary 删除用户绑定第三方平台账号
-// @Description 删除用户绑定第三方平台账号
+// @Description 删除用户绑定第三方平台账号。通过第三方登录注册且没有其他登录方式的账号不能解绑
 // @Tags 用户
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param data body DeleteUserBindThirdPartyReq true "平台"
 // @Success 200 {object} Response "删除用户绑定第三方平台账号成功"
+// @Failure 400 {object} Response "无法解绑"
+// @Failure 401 {object} Response "请先登录"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/delete_user_bind_third_party [post]
 func DeleteUserBindThirdPartyHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现删除用户绑定第三方平台账号逻辑
-	response := Response{
-		Code: 200,
-		Data: nil,
-		Msg:  "删除用户绑定第三方平台账号成功",
+	var req DeleteUserBindThirdPartyReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	if req.Platform == "" {
+		writeError(w, http.StatusBadRequest, "平台不能为空")
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	userInfo, err := models.GetUserByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "服务器错误")
+		return
+	}
+	if userInfo == nil {
+		writeError(w, http.StatusNotFound, "用户不存在")
+		return
+	}
+	// 第三方账号是这类账号唯一的登录方式，解绑后将无法再登录
+	if userInfo.RegisterType == req.Platform && userInfo.Email == "" && userInfo.Phone == "" {
+		writeError(w, http.StatusBadRequest, "请先绑定邮箱或手机号后再解绑")
+		return
+	}
+
+	if err := models.DeleteUserBindThirdParty(userID, req.Platform); err != nil {
+		writeError(w, http.StatusInternalServerError, "服务器错误")
+		return
+	}
+
+	writeSuccess(w, nil, "删除用户绑定第三方平台账号成功")
 }
 
 // @Summary 获取用户信息
@@ -453,18 +945,32 @@ func DeleteUserBindThirdPartyHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags 用户
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取用户信息成功"
+// @Security ApiKeyAuth
+// @Success 200 {object} Response{data=models.UserInfo} "获取用户信息成功"
+// @Failure 401 {object} Response "请先登录"
+// @Failure 404 {object} Response "用户不存在"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/get_user_info [get]
 func GetUserInfoHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取用户信息逻辑
-	response := Response{
-		Code: 200,
-		Data: models.UserInfo{},
-		Msg:  "获取用户信息成功",
+	userID, _ := auth.UserIDFromContext(r.Context())
+
+	userInfo, err := models.GetUserByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取用户信息失败: "+err.Error())
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	if userInfo == nil {
+		writeError(w, http.StatusNotFound, "用户不存在")
+		return
+	}
+
+	userInfo.ThirdParty, err = models.GetUserOauthList(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取第三方账号绑定失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, userInfo, "获取用户信息成功")
 }
 
 // @Summary 获取用户点赞列表
@@ -472,18 +978,28 @@ func GetUserInfoHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags 用户
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取用户点赞列表成功"
+// @Security ApiKeyAuth
+// @Success 200 {object} Response{data=models.UserLike} "获取用户点赞列表成功"
+// @Failure 401 {object} Response "请先登录"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/get_user_like [get]
 func GetUserLikeHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取用户点赞列表逻辑
-	response := Response{
-		Code: 200,
-		Data: models.UserLike{},
-		Msg:  "获取用户点赞列表成功",
+	userID, _ := auth.UserIDFromContext(r.Context())
+
+	userLike, err := models.GetUserLike(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取用户点赞列表失败: "+err.Error())
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	writeSuccess(w, userLike, "获取用户点赞列表成功")
+}
+
+// 修改用户头像请求结构体
+// @Description 修改用户头像请求参数
+type UpdateUserAvatarReq struct {
+	// 头像地址
+	Avatar string `json:"avatar" example:"https://example.com/avatar.png"`
 }
 
 // @Summary 修改用户头像
@@ -491,75 +1007,219 @@ func GetUserLikeHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags 用户
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateUserAvatarReq true "头像信息"
 // @Success 200 {object} Response "修改用户头像成功"
+// @Failure 400 {object} Response "头像不能为空"
+// @Failure 401 {object} Response "请先登录"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/update_user_avatar [post]
 func UpdateUserAvatarHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现修改用户头像逻辑
-	response := Response{
-		Code: 200,
-		Data: nil,
-		Msg:  "修改用户头像成功",
+	var req UpdateUserAvatarReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	req.Avatar = strings.TrimSpace(req.Avatar)
+	if req.Avatar == "" {
+		writeError(w, http.StatusBadRequest, "头像不能为空")
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	if err := models.UpdateUserAvatar(userID, req.Avatar); err != nil {
+		writeUserUpdateError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改用户头像成功")
+}
+
+// 修改用户绑定邮箱请求结构体
+// @Description 修改用户绑定邮箱请求参数
+type UpdateUserBindEmailReq struct {
+	// 邮箱
+	Email string `json:"email" example:"newuser@example.com"`
+	// 邮件验证码
+	VerifyCode string `json:"verify_code" example:"123456"`
 }
 
 // @Summary 修改用户绑定邮箱
-// @Description 修改用户绑定邮箱
+// @Description 修改用户绑定邮箱，需要先向新邮箱发送 bind_email 类型的验证码
 // @Tags 用户
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateUserBindEmailReq true "邮箱信息"
 // @Success 200 {object} Response "修改用户绑定邮箱成功"
+// @Failure 400 {object} Response "参数错误或验证码错误"
+// @Failure 401 {object} Response "请先登录"
+// @Failure 409 {object} Response "邮箱已被使用"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/update_user_bind_email [post]
 func UpdateUserBindEmailHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现修改用户绑定邮箱逻辑
-	response := Response{
-		Code: 200,
-		Data: nil,
-		Msg:  "修改用户绑定邮箱成功",
+	var req UpdateUserBindEmailReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	email, ok := verifycode.NormalizeEmail(req.Email)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "邮箱格式不正确")
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	existingUser, err := models.GetUserByEmail(email)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "服务器错误")
+		return
+	}
+	if existingUser != nil && existingUser.ID != userID {
+		writeError(w, http.StatusConflict, "邮箱已被使用")
+		return
+	}
+
+	if err := verifycode.EmailCodes.Verify(verifycode.SceneBindEmail, email, req.VerifyCode); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := models.UpdateUserBindEmail(userID, email); err != nil {
+		writeUserUpdateError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改用户绑定邮箱成功")
+}
+
+// 修改用户绑定手机号请求结构体
+// @Description 修改用户绑定手机号请求参数
+type UpdateUserBindPhoneReq struct {
+	// 手机号
+	Phone string `json:"phone" example:"13800138000"`
+	// 短信验证码
+	VerifyCode string `json:"verify_code" example:"123456"`
 }
 
 // @Summary 修改用户绑定手机号
-// @Description 修改用户绑定手机号
+// @Description 修改用户绑定手机号，需要先向新手机号发送 bind_phone 类型的验证码
 // @Tags 用户
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateUserBindPhoneReq true "手机号信息"
 // @Success 200 {object} Response "修改用户绑定手机号成功"
+// @Failure 400 {object} Response "参数错误或验证码错误"
+// @Failure 401 {object} Response "请先登录"
+// @Failure 409 {object} Response "手机号已被绑定"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/update_user_bind_phone [post]
 func UpdateUserBindPhoneHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现修改用户绑定手机号逻辑
-	response := Response{
-		Code: 200,
-		Data: nil,
-		Msg:  "修改用户绑定手机号成功",
+	var req UpdateUserBindPhoneReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	phone, err := sms.NormalizePhone(req.Phone, sms.DefaultCountryCode)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	existingUser, err := models.GetUserByPhone(phone)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "服务器错误")
+		return
+	}
+	if existingUser != nil && existingUser.ID != userID {
+		writeError(w, http.StatusConflict, "手机号已被绑定")
+		return
+	}
+
+	// 验证码校验必须在写入之前完成
+	if err := verifycode.PhoneCodes.Verify(verifycode.SceneBindPhone, phone, req.VerifyCode); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := models.UpdateUserBindPhone(userID, phone); err != nil {
+		writeUserUpdateError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改用户绑定手机号成功")
+}
+
+// 修改用户绑定第三方平台账号请求结构体
+// @Description 修改用户绑定第三方平台账号请求参数
+type UpdateUserBindThirdPartyReq struct {
+	// 平台
+	Platform string `json:"platform" example:"github"`
+	// 授权码
+	Code string `json:"code" example:"a1b2c3"`
+	// 登录状态下获取授权地址时下发的state
+	State string `json:"state" example:"eyJwIjoiZ2l0aHViIn0.c2lnbmF0dXJl"`
+	// 获取授权地址时提交的客户端随机串
+	ClientNonce string `json:"client_nonce" example:"3f9a1c7e5b2d4a608e1f7c9b2a4d6e8f"`
 }
 
 // @Summary 修改用户绑定第三方平台账号
-// @Description 修改用户绑定第三方平台账号
+// @Description 把第三方平台账号绑定到当前用户，授权地址需要在登录状态下获取，并带回获取授权地址时提交的 client_nonce
 // @Tags 用户
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateUserBindThirdPartyReq true "授权信息"
 // @Success 200 {object} Response "修改用户绑定第三方平台账号成功"
+// @Failure 400 {object} Response "授权状态无效或不支持的平台"
+// @Failure 401 {object} Response "请先登录"
+// @Failure 409 {object} Response "该第三方账号已绑定其他用户"
+// @Failure 502 {object} Response "第三方平台授权失败"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/update_user_bind_third_party [post]
 func UpdateUserBindThirdPartyHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现修改用户绑定第三方平台账号逻辑
-	response := Response{
-		Code: 200,
-		Data: nil,
-		Msg:  "修改用户绑定第三方平台账号成功",
+	var req UpdateUserBindThirdPartyReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	profile, err := oauth.Authenticate(r.Context(), req.Platform, req.Code, req.State, req.ClientNonce, userID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, oauth.ErrProviderFailure) {
+			status = http.StatusBadGateway
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	err = models.UpdateUserBindThirdParty(userID, req.Platform, profile.OpenID, profile.Nickname, profile.Avatar)
+	if err != nil {
+		if errors.Is(err, models.ErrThirdPartyBound) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "服务器错误")
+		return
+	}
+
+	writeSuccess(w, nil, "修改用户绑定第三方平台账号成功")
+}
+
+// 修改用户信息请求结构体
+// @Description 修改用户信息请求参数
+type UpdateUserInfoReq struct {
+	// 昵称
+	Nickname string `json:"nickname" example:"小明"`
+	// 性别 0未知 1男 2女
+	Gender int `json:"gender" example:"1"`
+	// 简介
+	Intro string `json:"intro" example:"热爱编程"`
+	// 网站
+	Website string `json:"website" example:"https://example.com"`
 }
 
 // @Summary 修改用户信息
@@ -567,18 +1227,52 @@ func UpdateUserBindThirdPartyHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags 用户
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateUserInfoReq true "用户信息"
 // @Success 200 {object} Response "修改用户信息成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 401 {object} Response "请先登录"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/update_user_info [post]
 func UpdateUserInfoHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现修改用户信息逻辑
-	response := Response{
-		Code: 200,
-		Data: nil,
-		Msg:  "修改用户信息成功",
+	var req UpdateUserInfoReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	req.Nickname = strings.TrimSpace(req.Nickname)
+	req.Intro = strings.TrimSpace(req.Intro)
+	req.Website = strings.TrimSpace(req.Website)
+	if req.Nickname == "" {
+		writeError(w, http.StatusBadRequest, "昵称不能为空")
+		return
+	}
+	if utf8.RuneCountInString(req.Nickname) > 32 {
+		writeError(w, http.StatusBadRequest, "昵称不能超过32个字符")
+		return
+	}
+	if utf8.RuneCountInString(req.Intro) > 255 {
+		writeError(w, http.StatusBadRequest, "简介不能超过255个字符")
+		return
+	}
+	if req.Gender < 0 || req.Gender > 2 {
+		writeError(w, http.StatusBadRequest, "无效的性别")
+		return
+	}
+	if req.Website != "" {
+		if u, err := url.Parse(req.Website); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			writeError(w, http.StatusBadRequest, "无效的网站地址")
+			return
+		}
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	if err := models.UpdateUserInfo(userID, req.Nickname, req.Intro, req.Website, req.Gender); err != nil {
+		writeUserUpdateError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改用户信息成功")
 }
 
 // @Summary 获取关于我的信息
@@ -586,83 +1280,144 @@ func UpdateUserInfoHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags 博客
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取关于我的信息成功"
+// @Success 200 {object} Response{data=models.AboutMe} "获取关于我的信息成功"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/blog/about_me [get]
 func GetAboutMeHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取关于我的信息逻辑
-	response := Response{
-		Code: 200,
-		Data: map[string]interface{}{
-			"content": "关于我的信息内容",
-		},
-		Msg: "获取关于我的信息成功",
+	content, err := siteconfig.AboutMe()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	writeSuccess(w, models.AboutMe{Content: content}, "获取关于我的信息成功")
+}
+
+// 修改用户密码请求结构体
+// @Description 修改用户密码请求参数
+type UpdateUserPasswordReq struct {
+	// 旧密码
+	OldPassword string `json:"old_password" example:"oldpassword123"`
+	// 新密码
+	NewPassword string `json:"new_password" example:"newpassword123"`
+	// 确认密码
+	ConfirmPassword string `json:"confirm_password" example:"newpassword123"`
 }
 
 // @Summary 修改用户密码
-// @Description 修改用户密码
+// @Description 修改用户密码，需要校验旧密码
 // @Tags 用户
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateUserPasswordReq true "密码信息"
 // @Success 200 {object} Response "修改用户密码成功"
+// @Failure 400 {object} Response "参数错误或旧密码错误"
+// @Failure 401 {object} Response "请先登录"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/user/update_user_password [post]
 func UpdateUserPasswordHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现修改用户密码逻辑
-	response := Response{
-		Code: 200,
-		Data: nil,
-		Msg:  "修改用户密码成功",
+	var req UpdateUserPasswordReq
+	if !decodeRequest(w, r, &req) {
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	if req.OldPassword == "" || req.NewPassword == "" {
+		writeError(w, http.StatusBadRequest, "旧密码和新密码不能为空")
+		return
+	}
+	if req.NewPassword != req.ConfirmPassword {
+		writeError(w, http.StatusBadRequest, "两次输入的密码不一致")
+		return
+	}
+	if err := models.ValidatePassword(req.NewPassword); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	user, err := models.GetUserAuthByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "服务器错误")
+		return
+	}
+	if user == nil {
+		writeError(w, http.StatusNotFound, "用户不存在")
+		return
+	}
+	if !models.VerifyPassword(user.Password, req.OldPassword) {
+		writeError(w, http.StatusBadRequest, "旧密码错误")
+		return
+	}
+
+	if err := models.UpdateUserPassword(userID, req.NewPassword); err != nil {
+		writeUserUpdateError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改用户密码成功")
+}
+
+// 游客信息响应结构体
+// @Description 游客信息
+type GetTouristInfoResp struct {
+	// 游客ID，之后的请求通过 X-Terminal-Id 请求头携带
+	TouristID string `json:"tourist_id" example:"9f86d081884c7d659a2feaa0c55ad015-1b4f0e9851971998"`
 }
 
 // @Summary 获取游客信息
-// @Description 获取游客信息
+// @Description 获取游客ID；请求已携带本站签发的有效游客ID时原样返回，否则签发新的游客ID
 // @Tags 游客
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取游客信息成功"
+// @Success 200 {object} Response{data=GetTouristInfoResp} "获取游客信息成功"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/get_tourist_info [get]
 func GetTouristInfoHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取游客信息逻辑
-	response := Response{
-		Code: 200,
-		Data: map[string]interface{}{
-			"nickname": "游客",
-			"avatar":   "",
-		},
-		Msg: "获取游客信息成功",
+	id := tourist.FromRequest(r)
+	if id == "" {
+		var err error
+		if id, err = tourist.NewID(); err != nil {
+			writeError(w, http.StatusInternalServerError, "生成游客ID失败")
+			return
+		}
+		tourist.Touch(r, id)
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	writeSuccess(w, GetTouristInfoResp{TouristID: id}, "获取游客信息成功")
+}
+
+// 说说列表查询请求结构体
+// @Description 说说列表查询参数
+type TalkQueryReq struct {
+	PageQueryReq
 }
 
 // @Summary 获取说说列表
-// @Description 获取说说列表，支持分页
+// @Description 获取说说列表，支持分页，置顶的排在前面；私密说说只有管理员可见
 // @Tags 说说
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} Response "获取说说列表成功"
+// @Param data body TalkQueryReq false "分页参数"
+// @Success 200 {object} Response{data=PageResponse} "获取说说列表成功"
 // @Failure 500 {object} Response "服务器错误"
 // @Router /blog-api/v1/talk/find_talk_list [post]
 func FindTalkListHandler(w http.ResponseWriter, r *http.Request) {
-	// 实现获取说说列表逻辑
-	response := Response{
-		Code: 200,
-		Data: PageResponse{
-			Page:     1,
-			PageSize: 10,
-			Total:    0,
-			List:     []interface{}{},
-		},
-		Msg: "获取说说列表成功",
+	var req TalkQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	talks, total, err := models.GetTalks(limit, offset, auth.IsAdminRequest(r))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取说说列表失败: "+err.Error())
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     talks,
+	}, "获取说说列表成功")
 }
@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// 页面列表查询请求结构体
+// @Description 页面列表查询参数
+type PageListQueryReq struct {
+	PageQueryReq
+}
+
+// @Summary 获取页面列表
+// @Description 分页获取页面列表，按排序号正序
+// @Tags 页面
+// @Accept  json
+// @Produce  json
+// @Param data body PageListQueryReq false "分页参数"
+// @Success 200 {object} Response{data=PageResponse} "获取页面列表成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/page/find_page_list [post]
+func FindPageListHandler(w http.ResponseWriter, r *http.Request) {
+	var req PageListQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	pages, total, err := models.GetPages(limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取页面列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     pages,
+	}, "获取页面列表成功")
+}
+
+// 保存页面请求结构体
+// @Description 添加或修改页面参数
+type PageNewReq struct {
+	// 页面ID，修改时必填
+	ID int64 `json:"id" example:"1"`
+	// 页面名
+	PageName string `json:"page_name" example:"说说"`
+	// 页面标签，与前台页面对应
+	PageLabel string `json:"page_label" example:"talk"`
+	// 页面封面
+	PageCover string `json:"page_cover" example:"http://localhost:8083/uploads/3f/3f2a9c.jpg"`
+	// 是否在首页轮播 0否 1是
+	IsCarousel int `json:"is_carousel" example:"0"`
+}
+
+// toPage 校验参数并转换为页面模型
+func (req *PageNewReq) toPage() (*models.Page, string) {
+	page := &models.Page{
+		ID:         req.ID,
+		PageName:   strings.TrimSpace(req.PageName),
+		PageLabel:  strings.ToLower(strings.TrimSpace(req.PageLabel)),
+		PageCover:  strings.TrimSpace(req.PageCover),
+		IsCarousel: req.IsCarousel,
+	}
+	if page.PageName == "" || utf8.RuneCountInString(page.PageName) > 32 {
+		return nil, "页面名不能为空且不能超过32个字符"
+	}
+	if page.PageLabel == "" || len(page.PageLabel) > 32 {
+		return nil, "页面标签不能为空且不能超过32个字符"
+	}
+	for _, c := range page.PageLabel {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return nil, "页面标签只能包含小写字母、数字、-和_"
+		}
+	}
+	if len(page.PageCover) > 255 {
+		return nil, "页面封面地址不能超过255个字符"
+	}
+	if page.IsCarousel != 0 && page.IsCarousel != 1 {
+		return nil, "无效的轮播参数"
+	}
+	if page.IsCarousel == 1 && page.PageCover == "" {
+		return nil, "轮播页面必须设置封面"
+	}
+	return page, ""
+}
+
+// @Summary 添加页面
+// @Description 添加页面，排在已有页面之后
+// @Tags 页面管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body PageNewReq true "页面信息"
+// @Success 200 {object} Response{data=models.Page} "添加页面成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 409 {object} Response "页面标签已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/page/add_page [post]
+func AddPageHandler(w http.ResponseWriter, r *http.Request) {
+	var req PageNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	page, msg := req.toPage()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.CreatePage(page); err != nil {
+		writePageError(w, err)
+		return
+	}
+
+	writeSuccess(w, page, "添加页面成功")
+}
+
+// @Summary 修改页面
+// @Description 修改页面名、标签、封面和轮播标记
+// @Tags 页面管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body PageNewReq true "页面信息"
+// @Success 200 {object} Response "修改页面成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "页面不存在"
+// @Failure 409 {object} Response "页面标签已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/page/update_page [post]
+func UpdatePageHandler(w http.ResponseWriter, r *http.Request) {
+	var req PageNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	page, msg := req.toPage()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.UpdatePage(page); err != nil {
+		writePageError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改页面成功")
+}
+
+// 删除页面请求结构体
+// @Description 批量删除页面参数
+type DeletesPageReq struct {
+	// 页面ID列表
+	IDs []int64 `json:"ids" example:"1,2"`
+}
+
+// @Summary 删除页面
+// @Description 批量删除页面
+// @Tags 页面管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body DeletesPageReq true "页面ID列表"
+// @Success 200 {object} Response{data=BatchResp} "删除页面成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/page/deletes_page [post]
+func DeletesPageHandler(w http.ResponseWriter, r *http.Request) {
+	var req DeletesPageReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要删除的页面")
+		return
+	}
+
+	count, err := models.DeletePages(req.IDs)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, BatchResp{SuccessCount: int(count)}, "删除页面成功")
+}
+
+// 页面排序请求结构体
+// @Description 页面排序参数
+type SortPagesReq struct {
+	// 按新顺序排列的全部页面ID
+	PageIDs []int64 `json:"page_ids" example:"3,1,2"`
+}
+
+// @Summary 页面排序
+// @Description 按给定顺序重排页面，必须包含全部页面；首页轮播按该顺序展示
+// @Tags 页面管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body SortPagesReq true "排序参数"
+// @Success 200 {object} Response "排序成功"
+// @Failure 400 {object} Response "页面列表不一致"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/page/sort_pages [post]
+func SortPagesHandler(w http.ResponseWriter, r *http.Request) {
+	var req SortPagesReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := models.SortPages(req.PageIDs); err != nil {
+		if errors.Is(err, models.ErrPageNotFound) {
+			writeError(w, http.StatusBadRequest, "页面列表与现有页面不一致")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, nil, "排序成功")
+}
+
+// writePageError 页面不存在时返回404，标签重复时返回409，其余返回500
+func writePageError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrPageNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrPageLabelExists):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
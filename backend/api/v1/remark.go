@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/ipgeo"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+	"github.com/jayden/personal-blog-backend/spamfilter"
+	"github.com/jayden/personal-blog-backend/tourist"
+)
+
+// maxRemarkLength 留言内容的最大字符数
+const maxRemarkLength = 500
+
+// 留言列表查询请求结构体
+// @Description 留言列表查询参数
+type RemarkQueryReq struct {
+	PageQueryReq
+}
+
+// @Summary 获取留言列表
+// @Description 分页获取审核通过的留言，按发布时间倒序；留言人的IP只展示前半部分
+// @Tags 留言
+// @Accept  json
+// @Produce  json
+// @Param data body RemarkQueryReq false "分页参数"
+// @Success 200 {object} Response{data=PageResponse} "获取留言列表成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/remark/find_remark_list [post]
+func FindRemarkListHandler(w http.ResponseWriter, r *http.Request) {
+	var req RemarkQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	isReview := models.RemarkReviewPassed
+	remarks, total, err := models.GetRemarks(limit, offset, &isReview)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取留言列表失败: "+err.Error())
+		return
+	}
+	// 公开列表只展示IP的前半部分，完整IP只在后台留言列表中返回
+	for _, remark := range remarks {
+		remark.IpAddress = netutil.MaskIP(remark.IpAddress)
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     remarks,
+	}, "获取留言列表成功")
+}
+
+// 发布留言请求结构体
+// @Description 发布留言参数
+type RemarkNewReq struct {
+	// 留言内容
+	MessageContent string `json:"message_content" example:"博主好，来踩一踩"`
+}
+
+// @Summary 发布留言
+// @Description 发布留言，未登录时以请求头 X-Terminal-Id 中本站签发的游客ID发布，游客之后注册或登录时留言会归属到账号；开启留言审核时需审核通过后才会展示
+// @Tags 留言
+// @Accept  json
+// @Produce  json
+// @Param data body RemarkNewReq true "留言内容"
+// @Success 200 {object} Response{data=models.Remark} "发布留言成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 401 {object} Response "缺少游客身份"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/remark/add_remark [post]
+func AddRemarkHandler(w http.ResponseWriter, r *http.Request) {
+	var req RemarkNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	content := strings.TrimSpace(req.MessageContent)
+	if content == "" {
+		writeError(w, http.StatusBadRequest, "留言内容不能为空")
+		return
+	}
+	if utf8.RuneCountInString(content) > maxRemarkLength {
+		writeError(w, http.StatusBadRequest, "留言内容不能超过500个字符")
+		return
+	}
+
+	if err := spamfilter.Check(content); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID := 0
+	if claims := auth.ClaimsFromRequest(r); claims != nil {
+		userID = claims.UserID
+	}
+	terminal := tourist.FromRequest(r)
+	if userID == 0 && terminal == "" {
+		writeError(w, http.StatusUnauthorized, "缺少游客身份，请刷新页面后重试")
+		return
+	}
+
+	ip := netutil.ClientIP(r)
+	remark := &models.Remark{
+		TerminalID:     terminal,
+		MessageContent: content,
+		IpAddress:      ip,
+		IpSource:       ipgeo.Lookup(ip),
+		IsReview:       models.RemarkReviewPassed,
+	}
+	if siteconfig.Feature().IsMessageReview == 1 {
+		remark.IsReview = models.RemarkReviewPending
+	}
+	if err := models.CreateRemark(remark, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if remark.IsReview == models.RemarkReviewPending {
+		writeSuccess(w, remark, "留言成功，审核通过后展示")
+		return
+	}
+	writeSuccess(w, remark, "发布留言成功")
+}
+
+// 后台留言列表查询请求结构体
+// @Description 后台留言列表查询参数
+type AdminRemarkQueryReq struct {
+	PageQueryReq
+	// 审核状态 0待审核 1已通过，为空时返回全部
+	IsReview *int `json:"is_review" example:"0"`
+}
+
+// @Summary 获取后台留言列表
+// @Description 分页获取全部留言，可按审核状态过滤
+// @Tags 留言管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body AdminRemarkQueryReq false "查询参数"
+// @Success 200 {object} Response{data=PageResponse} "获取留言列表成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/remark/find_remark_list [post]
+func AdminFindRemarkListHandler(w http.ResponseWriter, r *http.Request) {
+	var req AdminRemarkQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	remarks, total, err := models.GetRemarks(limit, offset, req.IsReview)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取留言列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     remarks,
+	}, "获取留言列表成功")
+}
+
+// 审核留言请求结构体
+// @Description 批量审核留言参数
+type UpdateRemarkReviewReq struct {
+	// 留言ID列表
+	IDs []int64 `json:"ids" example:"1,2"`
+	// 审核状态 0待审核 1已通过
+	IsReview int `json:"is_review" example:"1"`
+}
+
+// @Summary 审核留言
+// @Description 批量修改留言的审核状态
+// @Tags 留言管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateRemarkReviewReq true "留言ID列表和审核状态"
+// @Success 200 {object} Response{data=BatchResp} "审核留言成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/remark/update_remark_review [post]
+func UpdateRemarkReviewHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateRemarkReviewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要审核的留言")
+		return
+	}
+	if req.IsReview != models.RemarkReviewPending && req.IsReview != models.RemarkReviewPassed {
+		writeError(w, http.StatusBadRequest, "无效的审核状态")
+		return
+	}
+
+	count, err := models.UpdateRemarksReview(req.IDs, req.IsReview)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, BatchResp{SuccessCount: int(count)}, "审核留言成功")
+}
+
+// 删除留言请求结构体
+// @Description 批量删除留言参数
+type DeletesRemarkReq struct {
+	// 留言ID列表
+	IDs []int64 `json:"ids" example:"1,2"`
+}
+
+// @Summary 删除留言
+// @Description 批量删除留言
+// @Tags 留言管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body DeletesRemarkReq true "留言ID列表"
+// @Success 200 {object} Response{data=BatchResp} "删除留言成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/remark/deletes_remark [post]
+func DeletesRemarkHandler(w http.ResponseWriter, r *http.Request) {
+	var req DeletesRemarkReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要删除的留言")
+		return
+	}
+
+	count, err := models.DeleteRemarks(req.IDs)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, BatchResp{SuccessCount: int(count)}, "删除留言成功")
+}
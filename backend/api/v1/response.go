@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// writeSuccess 返回成功响应
+func writeSuccess(w http.ResponseWriter, data interface{}, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Response{
+		Code: 200,
+		Data: data,
+		Msg:  msg,
+	})
+}
+
+// writeError 返回错误响应，响应体中的 code 与 HTTP 状态码一致
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Response{
+		Code: status,
+		Data: nil,
+		Msg:  msg,
+	})
+}
+
+// decodeRequest 解析JSON请求体，失败时写入400响应并返回 false
+func decodeRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, "无效的请求体")
+		return false
+	}
+	return true
+}
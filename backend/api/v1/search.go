@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/search"
+)
+
+// maxKeywordLength 检索关键词的最大字符数
+const maxKeywordLength = 50
+
+// 文章检索请求结构体
+// @Description 文章检索参数
+type ArticleSearchReq struct {
+	PageQueryReq
+	// 关键词，匹配标题、正文、分类和标签
+	Keyword string `json:"keyword" example:"Go 并发"`
+}
+
+// @Summary 检索文章
+// @Description 按关键词检索公开文章，按相关度排序；标题和正文摘要中的关键词用 <mark> 标签高亮，其余内容已转义
+// @Tags 文章
+// @Accept  json
+// @Produce  json
+// @Param data body ArticleSearchReq true "检索参数"
+// @Success 200 {object} Response{data=PageResponse{list=[]search.Hit}} "检索文章成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/article/search_article [post]
+func SearchArticleHandler(w http.ResponseWriter, r *http.Request) {
+	var req ArticleSearchReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	writeArticleSearch(w, req.Keyword, req.PageQueryReq)
+}
+
+// writeArticleSearch 检索文章并返回分页结果
+func writeArticleSearch(w http.ResponseWriter, keyword string, page PageQueryReq) {
+	keyword = strings.TrimSpace(keyword)
+	if utf8.RuneCountInString(keyword) > maxKeywordLength {
+		writeError(w, http.StatusBadRequest, "关键词不能超过50个字符")
+		return
+	}
+	limit, offset := page.normalize()
+
+	hits, total, err := search.Default().Search(keyword, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     page.Page,
+		PageSize: page.PageSize,
+		Total:    total,
+		List:     hits,
+	}, "检索文章成功")
+}
+
+// 重建索引响应结构体
+// @Description 重建文章索引结果
+type RebuildSearchIndexResp struct {
+	// 索引的文章数
+	ArticleCount int `json:"article_count" example:"42"`
+}
+
+// @Summary 重建文章索引
+// @Description 从数据库重新加载全部公开文章并重建检索索引
+// @Tags 文章管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} Response{data=RebuildSearchIndexResp} "重建文章索引成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/article/rebuild_search_index [post]
+func RebuildSearchIndexHandler(w http.ResponseWriter, r *http.Request) {
+	count, err := search.Default().Rebuild()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeSuccess(w, RebuildSearchIndexResp{ArticleCount: count}, "重建文章索引成功")
+}
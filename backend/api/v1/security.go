@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/loginguard"
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// 解除登录锁定请求结构体
+// @Description 解除登录锁定参数，用户名和IP至少填写一个
+type UnlockLoginReq struct {
+	// 用户名或邮箱
+	Username string `json:"username" example:"admin"`
+	// 客户端IP
+	IP string `json:"ip" example:"127.0.0.1"`
+}
+
+// @Summary 解除登录锁定
+// @Description 管理员解除因连续登录失败被锁定的账号或IP
+// @Tags 安全
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UnlockLoginReq true "解除锁定参数"
+// @Success 200 {object} Response "解除锁定成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/security/unlock_login [post]
+func UnlockLoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req UnlockLoginReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	req.Username = strings.TrimSpace(req.Username)
+	req.IP = strings.TrimSpace(req.IP)
+	if req.Username == "" && req.IP == "" {
+		writeError(w, http.StatusBadRequest, "用户名和IP至少填写一个")
+		return
+	}
+
+	var account string
+	if req.Username != "" {
+		user, err := models.GetUserByUsername(req.Username)
+		if err == nil && user == nil {
+			user, err = models.GetUserByEmail(strings.ToLower(req.Username))
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "查询用户失败: "+err.Error())
+			return
+		}
+		account = loginguard.AccountKey(user, req.Username)
+	}
+
+	operator := ""
+	if claims, ok := auth.FromContext(r.Context()); ok {
+		operator = claims.Username
+	}
+	loginguard.Default().Unlock(account, req.IP, operator)
+
+	writeSuccess(w, nil, "解除锁定成功")
+}
+
+// @Summary 获取登录审计事件列表
+// @Description 分页获取账号锁定、IP锁定和解除锁定等登录审计事件
+// @Tags 安全
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body PageQueryReq false "分页参数"
+// @Success 200 {object} Response{data=PageResponse} "获取登录审计事件成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/security/find_login_audit_list [post]
+func FindLoginAuditListHandler(w http.ResponseWriter, r *http.Request) {
+	var req PageQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	audits, total, err := models.GetLoginAudits(limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取登录审计事件失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     audits,
+	}, "获取登录审计事件成功")
+}
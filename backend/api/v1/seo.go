@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/seo"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+)
+
+// @Summary 获取文章SEO信息
+// @Description 获取公开文章的页面标题、描述、规范地址、Open Graph、Twitter 卡片和 JSON-LD 结构化数据，供前台写入页面头部
+// @Tags 文章
+// @Accept  json
+// @Produce  json
+// @Param data body IdReq true "文章ID"
+// @Success 200 {object} Response{data=seo.ArticleMeta} "获取文章SEO信息成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "文章不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/article/get_article_seo [post]
+func GetArticleSeoHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	article, err := models.GetPublicArticle(strconv.FormatInt(req.ID, 10))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取文章SEO信息失败: "+err.Error())
+		return
+	}
+	if article == nil {
+		writeError(w, http.StatusNotFound, "文章不存在")
+		return
+	}
+
+	website, _, err := siteconfig.Website()
+	if err != nil {
+		log.Printf("读取网站配置失败: %v", err)
+		website = siteconfig.DefaultWebsiteConfig()
+	}
+
+	writeSuccess(w, seo.NewArticleMeta(seo.SiteURL(r), article, website.WebsiteInfo), "获取文章SEO信息成功")
+}
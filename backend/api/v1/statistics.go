@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// 每日访问统计查询请求结构体
+// @Description 每日访问统计查询参数
+type DailyViewQueryReq struct {
+	// 查询最近多少天，默认7天，最多365天
+	Days int `json:"days" example:"7"`
+}
+
+// @Summary 获取每日访问统计
+// @Description 获取最近若干天每天的浏览量(PV)和访客数(UV)，供后台仪表盘使用
+// @Tags 统计
+// @Accept  json
+// @Produce  json
+// @Param data body DailyViewQueryReq false "查询参数"
+// @Success 200 {object} Response "获取每日访问统计成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/statistics/find_daily_view_list [post]
+func FindDailyViewListHandler(w http.ResponseWriter, r *http.Request) {
+	var req DailyViewQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	if req.Days <= 0 {
+		req.Days = 7
+	}
+	if req.Days > 365 {
+		req.Days = 365
+	}
+
+	stats, err := models.GetDailyViewStats(req.Days)
+	if err != nil {
+		response := Response{
+			Code: 500,
+			Data: nil,
+			Msg:  "获取每日访问统计失败: " + err.Error(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response := Response{
+		Code: 200,
+		Data: stats,
+		Msg:  "获取每日访问统计成功",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
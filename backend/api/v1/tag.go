@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/search"
+	"github.com/jayden/personal-blog-backend/slug"
+)
+
+// 标签列表查询请求结构体
+// @Description 标签列表查询参数
+type TagQueryReq struct {
+	PageQueryReq
+	// 标签名，模糊匹配
+	TagName string `json:"tag_name" example:"Go"`
+}
+
+// @Summary 获取标签列表
+// @Description 分页获取标签列表及每个标签的公开文章数，按文章数倒序
+// @Tags 标签
+// @Accept  json
+// @Produce  json
+// @Param data body TagQueryReq false "查询参数"
+// @Success 200 {object} Response{data=PageResponse{list=[]models.TagVO}} "获取标签列表成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/tag/find_tag_list [post]
+func FindTagListHandler(w http.ResponseWriter, r *http.Request) {
+	var req TagQueryReq
+	// 请求体可以为空，解析失败时使用默认值
+	json.NewDecoder(r.Body).Decode(&req)
+	limit, offset := req.normalize()
+
+	list, total, err := models.GetTagList(strings.TrimSpace(req.TagName), limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取标签列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     list,
+	}, "获取标签列表成功")
+}
+
+// 保存标签请求结构体
+// @Description 添加或修改标签参数
+type TagNewReq struct {
+	// 标签ID，修改时必填
+	ID int64 `json:"id" example:"1"`
+	// 标签名
+	TagName string `json:"tag_name" example:"Go"`
+	// 别名，只能包含小写字母、数字和-，为空时按标签名生成
+	Slug string `json:"slug" example:"go"`
+}
+
+// toTag 校验参数并转换为标签模型
+func (req *TagNewReq) toTag() (*models.Tag, string) {
+	tag := &models.Tag{
+		Name: strings.TrimSpace(req.TagName),
+		Slug: strings.ToLower(strings.TrimSpace(req.Slug)),
+	}
+	if req.ID > 0 {
+		tag.ID = strconv.FormatInt(req.ID, 10)
+	}
+	if tag.Name == "" || utf8.RuneCountInString(tag.Name) > 32 {
+		return nil, "标签名不能为空且不能超过32个字符"
+	}
+	if tag.Slug != "" && !slug.Valid(tag.Slug) {
+		return nil, "别名只能包含小写字母、数字和-，且不能超过64个字符"
+	}
+	return tag, ""
+}
+
+// @Summary 添加标签
+// @Description 添加标签，未指定别名时按标签名生成（中文转为拼音）
+// @Tags 标签管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body TagNewReq true "标签信息"
+// @Success 200 {object} Response{data=models.Tag} "添加标签成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 409 {object} Response "标签名或别名已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/tag/add_tag [post]
+func AddTagHandler(w http.ResponseWriter, r *http.Request) {
+	var req TagNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	req.ID = 0
+	tag, msg := req.toTag()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.CreateTag(tag); err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	writeSuccess(w, tag, "添加标签成功")
+}
+
+// @Summary 修改标签
+// @Description 修改标签名和别名，别名为空时按新标签名重新生成；带有该标签的文章的检索索引随之更新
+// @Tags 标签管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body TagNewReq true "标签信息"
+// @Success 200 {object} Response{data=models.Tag} "修改标签成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "标签不存在"
+// @Failure 409 {object} Response "标签名或别名已存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/tag/update_tag [post]
+func UpdateTagHandler(w http.ResponseWriter, r *http.Request) {
+	var req TagNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.ID <= 0 {
+		writeError(w, http.StatusBadRequest, "标签ID不能为空")
+		return
+	}
+	tag, msg := req.toTag()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.UpdateTag(tag); err != nil {
+		writeTagError(w, err)
+		return
+	}
+	ids, err := models.GetArticleIDsByTagID(tag.ID)
+	if err != nil {
+		log.Printf("同步标签 %s 的文章索引失败: %v", tag.ID, err)
+	}
+	reindexArticles(ids)
+
+	writeSuccess(w, tag, "修改标签成功")
+}
+
+// @Summary 删除标签
+// @Description 删除标签及其与文章的关联
+// @Tags 标签管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body IdReq true "标签ID"
+// @Success 200 {object} Response{data=MergeResp} "删除标签成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "标签不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/tag/delete_tag [post]
+func DeleteTagHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	articleIDs, err := models.DeleteTag(strconv.FormatInt(req.ID, 10))
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	reindexArticles(articleIDs)
+
+	writeSuccess(w, MergeResp{ArticleCount: len(articleIDs)}, "删除标签成功")
+}
+
+// @Summary 合并标签
+// @Description 把来源标签的文章关联改到目标标签，同时带有两个标签的文章只保留一个，然后删除来源标签
+// @Tags 标签管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body MergeReq true "合并参数"
+// @Success 200 {object} Response{data=MergeResp} "合并标签成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "标签不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/tag/merge_tag [post]
+func MergeTagHandler(w http.ResponseWriter, r *http.Request) {
+	var req MergeReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	articleIDs, err := models.MergeTag(strconv.FormatInt(req.SourceID, 10), strconv.FormatInt(req.TargetID, 10))
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	reindexArticles(articleIDs)
+
+	writeSuccess(w, MergeResp{ArticleCount: len(articleIDs)}, "合并标签成功")
+}
+
+// reindexArticles 分类或标签变化后同步相关文章的检索索引
+func reindexArticles(ids []string) {
+	for _, id := range ids {
+		search.IndexArticle(id)
+	}
+}
+
+// writeTagError 标签不存在时返回404，名称或别名重复时返回409，其余返回500
+func writeTagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrTagNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrTagNameExists), errors.Is(err, models.ErrTagSlugExists):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,247 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/tourist"
+)
+
+// maxTalkImages 一条说说最多的图片数
+const maxTalkImages = 9
+
+// @Summary 获取说说
+// @Description 根据ID获取说说详情；私密说说只有管理员可见
+// @Tags 说说
+// @Accept  json
+// @Produce  json
+// @Param data body IdReq true "说说ID"
+// @Success 200 {object} Response{data=models.Talk} "获取说说成功"
+// @Failure 404 {object} Response "说说不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/talk/get_talk [post]
+func GetTalkHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	talk, err := models.GetTalkByID(req.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if talk == nil || (talk.Status != models.TalkStatusPublic && !auth.IsAdminRequest(r)) {
+		writeError(w, http.StatusNotFound, "说说不存在")
+		return
+	}
+
+	writeSuccess(w, talk, "获取说说成功")
+}
+
+// @Summary 点赞说说
+// @Description 点赞说说，已点赞时取消点赞；未登录时以请求头 X-Terminal-Id 中的游客身份点赞
+// @Tags 说说
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body IdReq true "说说ID"
+// @Success 200 {object} Response "点赞成功"
+// @Failure 401 {object} Response "缺少游客身份"
+// @Failure 404 {object} Response "说说不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/talk/like_talk [put]
+func LikeTalkHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	userID := 0
+	if claims := auth.ClaimsFromRequest(r); claims != nil {
+		userID = claims.UserID
+	}
+	terminal := tourist.FromRequest(r)
+	if userID == 0 && terminal == "" {
+		writeError(w, http.StatusUnauthorized, "缺少游客身份，请刷新页面后重试")
+		return
+	}
+
+	talk, err := models.GetTalkByID(req.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if talk == nil || (talk.Status != models.TalkStatusPublic && !auth.IsAdminRequest(r)) {
+		writeError(w, http.StatusNotFound, "说说不存在")
+		return
+	}
+
+	var liked bool
+	if userID > 0 {
+		liked, err = models.ToggleLike(userID, models.LikeTypeTalk, req.ID)
+	} else {
+		liked, err = models.ToggleTouristLike(terminal, models.LikeTypeTalk, req.ID)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if liked {
+		writeSuccess(w, nil, "点赞成功")
+	} else {
+		writeSuccess(w, nil, "取消点赞成功")
+	}
+}
+
+// 保存说说请求结构体
+// @Description 发布或修改说说参数
+type TalkNewReq struct {
+	// 说说ID，修改时必填
+	ID int64 `json:"id" example:"1"`
+	// 内容
+	Content string `json:"content" example:"今天天气不错"`
+	// 图片地址列表，最多9张
+	ImgList []string `json:"img_list" example:"http://localhost:8083/uploads/3f/3f2a9c.jpg"`
+	// 是否置顶 0否 1是
+	IsTop int `json:"is_top" example:"0"`
+	// 状态 1公开 2私密
+	Status int `json:"status" example:"1"`
+}
+
+// toTalk 校验参数并转换为说说模型
+func (req *TalkNewReq) toTalk() (*models.Talk, string) {
+	req.Content = strings.TrimSpace(req.Content)
+	if req.Content == "" && len(req.ImgList) == 0 {
+		return nil, "内容和图片不能同时为空"
+	}
+	if utf8.RuneCountInString(req.Content) > 2000 {
+		return nil, "内容不能超过2000个字符"
+	}
+	if len(req.ImgList) > maxTalkImages {
+		return nil, "图片不能超过9张"
+	}
+	if req.IsTop != 0 && req.IsTop != 1 {
+		return nil, "无效的置顶参数"
+	}
+	if req.Status == 0 {
+		req.Status = models.TalkStatusPublic
+	}
+	if req.Status != models.TalkStatusPublic && req.Status != models.TalkStatusPrivate {
+		return nil, "无效的说说状态"
+	}
+
+	imgList := make([]string, 0, len(req.ImgList))
+	for _, img := range req.ImgList {
+		if img = strings.TrimSpace(img); img != "" {
+			imgList = append(imgList, img)
+		}
+	}
+	return &models.Talk{
+		ID:      req.ID,
+		Content: req.Content,
+		ImgList: imgList,
+		IsTop:   req.IsTop,
+		Status:  req.Status,
+	}, ""
+}
+
+// @Summary 发布说说
+// @Description 发布说说
+// @Tags 说说管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body TalkNewReq true "说说内容"
+// @Success 200 {object} Response{data=models.Talk} "发布说说成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/talk/add_talk [post]
+func AddTalkHandler(w http.ResponseWriter, r *http.Request) {
+	var req TalkNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	talk, msg := req.toTalk()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	if err := models.CreateTalk(talk, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, talk, "发布说说成功")
+}
+
+// @Summary 修改说说
+// @Description 修改说说内容、图片、置顶和状态
+// @Tags 说说管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body TalkNewReq true "说说内容"
+// @Success 200 {object} Response "修改说说成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "说说不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/talk/update_talk [post]
+func UpdateTalkHandler(w http.ResponseWriter, r *http.Request) {
+	var req TalkNewReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	talk, msg := req.toTalk()
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := models.UpdateTalk(talk); err != nil {
+		writeTalkError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "修改说说成功")
+}
+
+// @Summary 删除说说
+// @Description 删除说说及其评论和点赞记录
+// @Tags 说说管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body IdReq true "说说ID"
+// @Success 200 {object} Response "删除说说成功"
+// @Failure 404 {object} Response "说说不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/talk/delete_talk [post]
+func DeleteTalkHandler(w http.ResponseWriter, r *http.Request) {
+	var req IdReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := models.DeleteTalk(req.ID); err != nil {
+		writeTalkError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "删除说说成功")
+}
+
+// writeTalkError 说说不存在时返回404，其余返回500
+func writeTalkError(w http.ResponseWriter, err error) {
+	if errors.Is(err, models.ErrTalkNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
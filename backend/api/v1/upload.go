@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/upload"
+)
+
+// 获取文件列表请求结构体
+// @Description 获取上传文件列表参数
+type ListUploadFileReq struct {
+	// 文件目录，为空时不限目录
+	FilePath string `json:"file_path" example:"avatar"`
+	// 最多返回多少条，默认20，最多200
+	Limit int `json:"limit" example:"20"`
+}
+
+// 删除文件请求结构体
+// @Description 批量删除上传文件参数
+type DeletesUploadFileReq struct {
+	// 文件路径列表
+	FilePaths []string `json:"file_paths" example:"avatar/3f2a9c.png"`
+}
+
+// 批量操作响应结构体
+// @Description 批量操作结果
+type BatchResp struct {
+	// 成功数量
+	SuccessCount int `json:"success_count" example:"1"`
+}
+
+// multipartMemory 解析表单时保存在内存中的最大字节数，超出部分写入临时文件
+const multipartMemory = 8 << 20
+
+// @Summary 上传文件
+// @Description 上传单个文件，文件类型按内容识别，相同内容只存储一份
+// @Tags 文件
+// @Accept  multipart/form-data
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param file formData file true "文件"
+// @Param file_path formData string false "文件目录"
+// @Success 200 {object} Response{data=models.UploadFile} "上传成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 413 {object} Response "文件大小超过限制"
+// @Failure 415 {object} Response "不支持的文件类型"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/upload/upload_file [post]
+func UploadFileHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFromContext(r.Context())
+	svc := upload.Default()
+
+	r.Body = http.MaxBytesReader(w, r.Body, svc.MaxSize()+1<<20)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "解析上传表单失败: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	headers := r.MultipartForm.File["file"]
+	if len(headers) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要上传的文件")
+		return
+	}
+
+	file, err := svc.Save(r.Context(), userID, r.FormValue("file_path"), headers[0])
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	writeSuccess(w, file, "上传成功")
+}
+
+// @Summary 批量上传文件
+// @Description 一次上传多个文件，数量受配置限制
+// @Tags 文件
+// @Accept  multipart/form-data
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param files formData file true "文件列表"
+// @Param file_path formData string false "文件目录"
+// @Success 200 {object} Response{data=[]models.UploadFile} "上传成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 413 {object} Response "文件大小超过限制"
+// @Failure 415 {object} Response "不支持的文件类型"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/upload/multi_upload_file [post]
+func MultiUploadFileHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFromContext(r.Context())
+	svc := upload.Default()
+
+	r.Body = http.MaxBytesReader(w, r.Body, svc.MaxSize()*int64(svc.MaxFiles())+1<<20)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "解析上传表单失败: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	headers := r.MultipartForm.File["files"]
+	if len(headers) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要上传的文件")
+		return
+	}
+	if len(headers) > svc.MaxFiles() {
+		writeError(w, http.StatusBadRequest, "一次上传的文件数量超过限制")
+		return
+	}
+
+	dir := r.FormValue("file_path")
+	files := make([]*models.UploadFile, 0, len(headers))
+	for _, header := range headers {
+		file, err := svc.Save(r.Context(), userID, dir, header)
+		if err != nil {
+			// 错误信息中带上文件名，方便定位是哪个文件失败
+			writeUploadError(w, fmt.Errorf("%s: %w", header.Filename, err))
+			return
+		}
+		files = append(files, file)
+	}
+
+	writeSuccess(w, files, "上传成功")
+}
+
+// @Summary 获取文件列表
+// @Description 获取目录下的上传文件，按更新时间倒序；管理员可以看到所有人上传的文件
+// @Tags 文件
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body ListUploadFileReq false "查询参数"
+// @Success 200 {object} Response{data=PageResponse} "获取文件列表成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/upload/list_upload_file [post]
+func ListUploadFileHandler(w http.ResponseWriter, r *http.Request) {
+	var req ListUploadFileReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 200 {
+		req.Limit = 200
+	}
+
+	dir := ""
+	if req.FilePath != "" {
+		var err error
+		if dir, err = upload.CleanDir(req.FilePath); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	claims, _ := auth.FromContext(r.Context())
+	ownerID := claims.UserID
+	if auth.IsAdmin(claims) {
+		ownerID = 0
+	}
+
+	files, total, err := models.GetUploadFileList(dir, ownerID, req.Limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "获取文件列表失败: "+err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     1,
+		PageSize: req.Limit,
+		Total:    total,
+		List:     files,
+	}, "获取文件列表成功")
+}
+
+// @Summary 删除文件
+// @Description 批量删除上传文件，只能删除自己上传的文件，管理员可以删除所有文件
+// @Tags 文件
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body DeletesUploadFileReq true "文件路径列表"
+// @Success 200 {object} Response{data=BatchResp} "删除成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/upload/deletes_upload_file [delete]
+func DeletesUploadFileHandler(w http.ResponseWriter, r *http.Request) {
+	var req DeletesUploadFileReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if len(req.FilePaths) == 0 {
+		writeError(w, http.StatusBadRequest, "请选择要删除的文件")
+		return
+	}
+
+	claims, _ := auth.FromContext(r.Context())
+	ownerID := claims.UserID
+	if auth.IsAdmin(claims) {
+		ownerID = 0
+	}
+	count := 0
+	for _, filePath := range req.FilePaths {
+		// 普通用户只查到自己的记录，不存在或不属于自己的文件直接跳过，不计入成功数量
+		files, err := models.GetUploadFilesByPath(filePath, ownerID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "删除文件失败: "+err.Error())
+			return
+		}
+		if len(files) == 0 {
+			continue
+		}
+		for _, file := range files {
+			if err := upload.Default().Delete(r.Context(), file); err != nil {
+				writeError(w, http.StatusInternalServerError, "删除文件失败: "+err.Error())
+				return
+			}
+		}
+		count++
+	}
+
+	writeSuccess(w, BatchResp{SuccessCount: count}, "删除成功")
+}
+
+// writeUploadError 根据上传错误类型返回对应的状态码
+func writeUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, upload.ErrEmptyFile), errors.Is(err, upload.ErrInvalidPath), errors.Is(err, upload.ErrInvalidImage):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, upload.ErrTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, upload.ErrTypeNotAllowed):
+		writeError(w, http.StatusUnsupportedMediaType, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "上传失败: "+err.Error())
+	}
+}
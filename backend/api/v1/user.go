@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/verifycode"
+)
+
+// writeUserUpdateError 根据更新用户时的错误类型返回对应响应
+func writeUserUpdateError(w http.ResponseWriter, err error) {
+	if errors.Is(err, models.ErrUserNotFound) {
+		writeError(w, http.StatusNotFound, "用户不存在")
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "服务器错误")
+}
+
+// 解绑手机号请求结构体
+// @Description 解绑手机号请求参数，需要重新认证：提供登录密码，或提供发送到当前手机号的 unbind_phone 类型验证码
+type DeleteUserBindPhoneReq struct {
+	// 登录密码
+	Password string `json:"password" example:"password123"`
+	// 短信验证码
+	VerifyCode string `json:"verify_code" example:"123456"`
+}
+
+// @Summary 解绑手机号
+// @Description 解绑当前账号的手机号，需要重新认证。通过手机号注册且未绑定邮箱的账号不能解绑
+// @Tags 用户
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body DeleteUserBindPhoneReq true "认证信息"
+// @Success 200 {object} Response "解绑手机号成功"
+// @Failure 400 {object} Response "未绑定手机号或无法解绑"
+// @Failure 401 {object} Response "请先登录或认证失败"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/user/delete_user_bind_phone [post]
+func DeleteUserBindPhoneHandler(w http.ResponseWriter, r *http.Request) {
+	var req DeleteUserBindPhoneReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	userInfo, err := models.GetUserByID(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "服务器错误")
+		return
+	}
+	if userInfo == nil {
+		writeError(w, http.StatusNotFound, "用户不存在")
+		return
+	}
+	if userInfo.Phone == "" {
+		writeError(w, http.StatusBadRequest, "当前账号未绑定手机号")
+		return
+	}
+	// 手机号是这类账号唯一的登录方式，解绑后将无法再登录
+	if userInfo.RegisterType == "phone" && userInfo.Email == "" {
+		writeError(w, http.StatusBadRequest, "请先绑定邮箱后再解绑手机号")
+		return
+	}
+
+	switch {
+	case req.Password != "":
+		user, err := models.GetUserAuthByID(userID)
+		if err != nil || user == nil {
+			writeError(w, http.StatusInternalServerError, "服务器错误")
+			return
+		}
+		if !models.VerifyPassword(user.Password, req.Password) {
+			writeError(w, http.StatusUnauthorized, "密码错误")
+			return
+		}
+	case req.VerifyCode != "":
+		if err := verifycode.PhoneCodes.Verify(verifycode.SceneUnbindPhone, userInfo.Phone, req.VerifyCode); err != nil {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "请输入密码或验证码")
+		return
+	}
+
+	if err := models.UpdateUserBindPhone(userID, ""); err != nil {
+		writeUserUpdateError(w, err)
+		return
+	}
+
+	writeSuccess(w, nil, "解绑手机号成功")
+}
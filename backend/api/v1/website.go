@@ -0,0 +1,270 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/oauth"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+)
+
+// platformNames 第三方登录平台的展示名称
+var platformNames = map[string]string{
+	"github": "GitHub",
+	"gitee":  "Gitee",
+	"qq":     "QQ",
+}
+
+// 网站配置响应结构体
+// @Description 前台使用的网站配置
+type WebsiteConfigVO struct {
+	models.WebsiteConfig
+	// 用户第三方登录列表，由已配置的第三方平台生成
+	SocialLoginList []models.ThirdPlatformInfo `json:"social_login_list"`
+}
+
+// socialLoginList 返回已启用的第三方登录平台
+func socialLoginList() []models.ThirdPlatformInfo {
+	list := []models.ThirdPlatformInfo{}
+	for _, platform := range oauth.Platforms() {
+		name := platformNames[platform]
+		if name == "" {
+			name = platform
+		}
+		list = append(list, models.ThirdPlatformInfo{Name: name, Platform: platform, Enabled: true})
+	}
+	return list
+}
+
+// 后台网站配置响应结构体
+// @Description 网站配置及其当前版本号
+type WebsiteConfigResp struct {
+	// 当前版本号，还没有保存过配置时为0
+	Version int `json:"version" example:"3"`
+	// 网站配置
+	Config *models.WebsiteConfig `json:"config"`
+}
+
+// 保存配置响应结构体
+// @Description 保存后的版本号
+type ConfigVersionResp struct {
+	// 新的版本号
+	Version int `json:"version" example:"4"`
+}
+
+// @Summary 获取网站配置
+// @Description 获取当前网站配置及其版本号
+// @Tags 网站管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} Response{data=WebsiteConfigResp} "获取网站配置成功"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/website/get_website_config [post]
+func GetWebsiteConfigHandler(w http.ResponseWriter, r *http.Request) {
+	cfg, version, err := siteconfig.Website()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, WebsiteConfigResp{Version: version, Config: cfg}, "获取网站配置成功")
+}
+
+// validateWebsiteConfig 校验网站配置，返回错误提示
+func validateWebsiteConfig(cfg *models.WebsiteConfig) string {
+	f := cfg.WebsiteFeature
+	for _, flag := range []int{f.IsChatRoom, f.IsCommentReview, f.IsEmailNotice, f.IsMessageReview, f.IsMusicPlayer, f.IsReward} {
+		if flag != 0 && flag != 1 {
+			return "网站功能开关只能是0或1"
+		}
+	}
+	if cfg.WebsiteInfo.WebsiteName == "" {
+		return "网站名称不能为空"
+	}
+	if utf8.RuneCountInString(cfg.WebsiteInfo.WebsiteNotice) > 1000 || utf8.RuneCountInString(cfg.WebsiteInfo.WebsiteIntro) > 1000 {
+		return "网站介绍和公告不能超过1000个字符"
+	}
+	for _, u := range []string{cfg.AdminUrl, cfg.TouristAvatar, cfg.UserAvatar, cfg.WebsiteInfo.WebsiteAvatar,
+		cfg.RewardQrCode.AlipayQrCode, cfg.RewardQrCode.WeixinQrCode} {
+		if u != "" && !isHTTPURL(u) {
+			return "地址必须是 http 或 https 地址: " + u
+		}
+	}
+	if len(cfg.SocialUrlList) > 20 {
+		return "社交地址不能超过20个"
+	}
+	for _, social := range cfg.SocialUrlList {
+		if social.Platform == "" {
+			return "社交地址的平台不能为空"
+		}
+		if social.LinkUrl != "" && !isHTTPURL(social.LinkUrl) {
+			return "社交地址必须是 http 或 https 地址: " + social.LinkUrl
+		}
+	}
+	if cfg.SocialUrlList == nil {
+		cfg.SocialUrlList = []models.SocialAccountInfo{}
+	}
+	return ""
+}
+
+// @Summary 修改网站配置
+// @Description 保存网站配置并记录一个新版本，保存后前台立即生效
+// @Tags 网站管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body models.WebsiteConfig true "网站配置"
+// @Success 200 {object} Response{data=ConfigVersionResp} "修改网站配置成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/website/update_website_config [post]
+func UpdateWebsiteConfigHandler(w http.ResponseWriter, r *http.Request) {
+	var req models.WebsiteConfig
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if msg := validateWebsiteConfig(&req); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	version, err := siteconfig.UpdateWebsite(&req, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, ConfigVersionResp{Version: version}, "修改网站配置成功")
+}
+
+// 修改关于我请求结构体
+// @Description 修改关于我参数
+type UpdateAboutMeReq struct {
+	// 关于我的内容，Markdown格式
+	Content string `json:"content" example:"## 关于我"`
+}
+
+// @Summary 修改关于我
+// @Description 保存关于我的内容并记录一个新版本
+// @Tags 网站管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body UpdateAboutMeReq true "关于我的内容"
+// @Success 200 {object} Response{data=ConfigVersionResp} "修改关于我成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/website/update_about_me [post]
+func UpdateAboutMeHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateAboutMeReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if utf8.RuneCountInString(req.Content) > 100000 {
+		writeError(w, http.StatusBadRequest, "内容不能超过100000个字符")
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	version, err := siteconfig.UpdateAboutMe(req.Content, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, ConfigVersionResp{Version: version}, "修改关于我成功")
+}
+
+// 配置版本列表查询请求结构体
+// @Description 配置版本列表查询参数
+type ConfigVersionQueryReq struct {
+	PageQueryReq
+	// 配置项 website_config 或 about_me
+	ConfigKey string `json:"config_key" example:"website_config"`
+}
+
+// @Summary 获取配置版本列表
+// @Description 分页获取配置项的历史版本，按版本号倒序
+// @Tags 网站管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body ConfigVersionQueryReq true "查询参数"
+// @Success 200 {object} Response{data=PageResponse} "获取配置版本列表成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/website/find_config_version_list [post]
+func FindConfigVersionListHandler(w http.ResponseWriter, r *http.Request) {
+	var req ConfigVersionQueryReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if !siteconfig.ValidKey(req.ConfigKey) {
+		writeError(w, http.StatusBadRequest, "无效的配置项")
+		return
+	}
+	limit, offset := req.normalize()
+
+	versions, total, err := models.GetWebsiteConfigVersions(req.ConfigKey, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, PageResponse{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     versions,
+	}, "获取配置版本列表成功")
+}
+
+// 回滚配置请求结构体
+// @Description 回滚配置参数
+type RollbackConfigReq struct {
+	// 配置项 website_config 或 about_me
+	ConfigKey string `json:"config_key" example:"website_config"`
+	// 要恢复的版本号
+	Version int `json:"version" example:"2"`
+}
+
+// @Summary 回滚配置
+// @Description 把配置项恢复为指定历史版本的内容，恢复本身也会记录为一个新版本
+// @Tags 网站管理
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body RollbackConfigReq true "回滚参数"
+// @Success 200 {object} Response{data=ConfigVersionResp} "回滚配置成功"
+// @Failure 400 {object} Response "参数错误"
+// @Failure 404 {object} Response "版本不存在"
+// @Failure 500 {object} Response "服务器错误"
+// @Router /blog-api/v1/admin/website/rollback_config [post]
+func RollbackConfigHandler(w http.ResponseWriter, r *http.Request) {
+	var req RollbackConfigReq
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if !siteconfig.ValidKey(req.ConfigKey) {
+		writeError(w, http.StatusBadRequest, "无效的配置项")
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	version, err := siteconfig.Rollback(req.ConfigKey, req.Version, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrWebsiteConfigVersionNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, ConfigVersionResp{Version: version}, "回滚配置成功")
+}
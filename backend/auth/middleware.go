@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+type contextKey struct{}
+
+// errorResponse 与 v1 统一响应格式保持一致的错误响应
+type errorResponse struct {
+	Code    int         `json:"code"`
+	Data    interface{} `json:"data"`
+	Msg     string      `json:"msg"`
+	TraceId string      `json:"trace_id"`
+}
+
+// RequireLogin 要求请求携带有效token，并把登录信息放入请求上下文
+func RequireLogin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := ClaimsFromRequest(r)
+		if claims == nil {
+			writeError(w, http.StatusUnauthorized, "请先登录")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, claims)))
+	})
+}
+
+// RequireAdmin 要求请求携带管理员的有效token
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := ClaimsFromRequest(r)
+		if claims == nil {
+			writeError(w, http.StatusUnauthorized, "请先登录")
+			return
+		}
+		if !IsAdmin(claims) {
+			writeError(w, http.StatusForbidden, "没有权限")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, claims)))
+	})
+}
+
+// FromContext 获取 RequireLogin/RequireAdmin 放入上下文的登录信息
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
+
+// UserIDFromContext 获取当前登录用户ID
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	return claims.UserID, true
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Code: status,
+		Data: nil,
+		Msg:  msg,
+	})
+}
+
+// IsAdmin 判断token对应的用户是否为管理员；token中的角色只用来跳过普通用户的查询，
+// 以数据库中的当前角色为准，被取消管理员的用户在token过期前也会立即失去权限
+func IsAdmin(claims *Claims) bool {
+	if claims == nil || claims.Role != RoleAdmin {
+		return false
+	}
+	role, err := models.GetUserRole(claims.UserID)
+	if err != nil {
+		log.Printf("校验用户 %d 的管理员权限失败: %v", claims.UserID, err)
+		return false
+	}
+	return role == RoleAdmin
+}
+
+// IsAdminRequest 判断请求是否携带管理员的有效token，用于公开接口中对管理员放宽限制
+func IsAdminRequest(r *http.Request) bool {
+	return IsAdmin(ClaimsFromRequest(r))
+}
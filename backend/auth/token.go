@@ -0,0 +1,96 @@
+// Package auth 负责JWT的签发、解析以及登录态相关的中间件
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// RoleAdmin 管理员角色
+const RoleAdmin = "admin"
+
+// TokenTTL token有效期
+const TokenTTL = 24 * time.Hour
+
+// JWT密钥，Init 时从配置读取
+var jwtKey []byte
+
+// Claims JWT声明结构体
+type Claims struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Init 从配置中读取JWT密钥
+func Init(cfg *config.Config) {
+	jwtKey = []byte(cfg.JWTSecret)
+}
+
+// GenerateToken 为用户签发token，返回token字符串和过期时间
+func GenerateToken(userID int, username, role string) (string, time.Time, error) {
+	expirationTime := time.Now().Add(TokenTTL)
+	claims := &Claims{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(jwtKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("签发token失败: %w", err)
+	}
+	return tokenString, expirationTime, nil
+}
+
+// ParseToken 解析并校验token
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, fmt.Errorf("解析token失败: %w", err)
+	}
+	if !token.Valid || claims.UserID == 0 {
+		return nil, errors.New("无效的token")
+	}
+	return claims, nil
+}
+
+// TokenFromRequest 从请求头中提取token，支持 Token 头和 Authorization: Bearer 两种方式
+func TokenFromRequest(r *http.Request) string {
+	if token := r.Header.Get("Token"); token != "" {
+		return token
+	}
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "Bearer ") {
+		return authorization[7:]
+	}
+	return ""
+}
+
+// ClaimsFromRequest 解析请求携带的token，未携带或无效时返回 nil
+func ClaimsFromRequest(r *http.Request) *Claims {
+	tokenString := TokenFromRequest(r)
+	if tokenString == "" {
+		return nil
+	}
+	claims, err := ParseToken(tokenString)
+	if err != nil {
+		return nil
+	}
+	return claims
+}
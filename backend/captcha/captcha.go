@@ -0,0 +1,241 @@
+// Package captcha 提供图形验证码：纯Go绘制PNG图片、服务端存储（有效期内一次性使用），以及按失败次数决定是否需要验证码的自适应模式
+package captcha
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// 验证码模式
+const (
+	ModeOff      = "off"      // 不校验验证码
+	ModeAlways   = "always"   // 始终要求验证码
+	ModeAdaptive = "adaptive" // 同一IP或账号连续失败达到阈值后才要求验证码
+)
+
+// 图片尺寸限制
+const (
+	DefaultWidth  = 120
+	DefaultHeight = 40
+	minWidth      = 60
+	maxWidth      = 400
+	minHeight     = 20
+	maxHeight     = 200
+)
+
+var (
+	// ErrRequired 需要输入验证码
+	ErrRequired = errors.New("请输入图形验证码")
+	// ErrInvalid 验证码错误或已过期
+	ErrInvalid = errors.New("图形验证码错误或已过期")
+)
+
+// entry 已下发的验证码
+type entry struct {
+	code     string
+	expireAt time.Time
+}
+
+// failure 某个IP或账号的连续失败记录
+type failure struct {
+	count    int
+	expireAt time.Time
+}
+
+// Service 验证码服务
+type Service struct {
+	mu sync.Mutex
+
+	mode          string
+	length        int
+	ttl           time.Duration
+	threshold     int           // 自适应模式下要求验证码的连续失败次数
+	failureWindow time.Duration // 失败次数的统计窗口
+
+	entries  map[string]*entry
+	failures map[string]*failure
+}
+
+var defaultService = NewService(ModeOff, 4, 5*time.Minute, 3, 15*time.Minute)
+
+// Init 根据配置初始化全局验证码服务
+func Init(cfg *config.Config) {
+	defaultService = NewService(cfg.CaptchaMode, 4, cfg.CaptchaTTL, cfg.CaptchaThreshold, cfg.CaptchaFailureWindow)
+}
+
+// Default 返回全局验证码服务
+func Default() *Service {
+	return defaultService
+}
+
+// NewService 创建验证码服务
+func NewService(mode string, length int, ttl time.Duration, threshold int, failureWindow time.Duration) *Service {
+	return &Service{
+		mode:          mode,
+		length:        length,
+		ttl:           ttl,
+		threshold:     threshold,
+		failureWindow: failureWindow,
+		entries:       make(map[string]*entry),
+		failures:      make(map[string]*failure),
+	}
+}
+
+// Generate 生成验证码，返回验证码key和 data URI 形式的PNG图片
+func (s *Service) Generate(width, height int) (key, image string, err error) {
+	width = clamp(width, DefaultWidth, minWidth, maxWidth)
+	height = clamp(height, DefaultHeight, minHeight, maxHeight)
+
+	code, err := randomDigits(s.length)
+	if err != nil {
+		return "", "", err
+	}
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return "", "", fmt.Errorf("生成验证码key失败: %w", err)
+	}
+	key = hex.EncodeToString(keyBytes)
+
+	image, err = renderPNG(code, width, height)
+	if err != nil {
+		return "", "", fmt.Errorf("绘制验证码失败: %w", err)
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.cleanupLocked(now)
+	s.entries[key] = &entry{code: code, expireAt: now.Add(s.ttl)}
+	s.mu.Unlock()
+
+	return key, image, nil
+}
+
+// Verify 校验验证码，无论校验是否通过，验证码都会失效
+func (s *Service) Verify(key, code string) bool {
+	if key == "" || code == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	e, ok := s.entries[key]
+	delete(s.entries, key)
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(e.expireAt) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(e.code), []byte(code)) == 1
+}
+
+// Required 判断本次请求是否需要验证码，subjects 为需要统计失败次数的对象，如客户端IP和账号
+func (s *Service) Required(subjects ...string) bool {
+	switch s.mode {
+	case ModeAlways:
+		return true
+	case ModeAdaptive:
+		now := time.Now()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, subject := range subjects {
+			if f, ok := s.failures[subject]; ok && now.Before(f.expireAt) && f.count >= s.threshold {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// Check 按当前模式校验请求携带的验证码，不需要验证码时直接通过
+func (s *Service) Check(key, code string, subjects ...string) error {
+	if !s.Required(subjects...) {
+		return nil
+	}
+	if key == "" || code == "" {
+		return ErrRequired
+	}
+	if !s.Verify(key, code) {
+		return ErrInvalid
+	}
+	return nil
+}
+
+// RecordFailure 记录一次失败（如密码错误），用于自适应模式
+func (s *Service) RecordFailure(subjects ...string) {
+	if s.mode != ModeAdaptive {
+		return
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subject := range subjects {
+		if subject == "" {
+			continue
+		}
+		f, ok := s.failures[subject]
+		if !ok || now.After(f.expireAt) {
+			f = &failure{}
+			s.failures[subject] = f
+		}
+		f.count++
+		f.expireAt = now.Add(s.failureWindow)
+	}
+}
+
+// Reset 清除失败记录，在成功操作后调用
+func (s *Service) Reset(subjects ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subject := range subjects {
+		delete(s.failures, subject)
+	}
+}
+
+// cleanupLocked 清理过期的验证码和失败记录，调用方需持有锁
+func (s *Service) cleanupLocked(now time.Time) {
+	for key, e := range s.entries {
+		if now.After(e.expireAt) {
+			delete(s.entries, key)
+		}
+	}
+	for subject, f := range s.failures {
+		if now.After(f.expireAt) {
+			delete(s.failures, subject)
+		}
+	}
+}
+
+// randomDigits 使用 crypto/rand 生成数字验证码
+func randomDigits(length int) (string, error) {
+	digits := make([]byte, length)
+	for i := range digits {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("生成验证码失败: %w", err)
+		}
+		digits[i] = byte('0' + n.Int64())
+	}
+	return string(digits), nil
+}
+
+func clamp(value, defaultValue, min, max int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
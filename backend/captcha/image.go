@@ -0,0 +1,140 @@
+package captcha
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"math/rand/v2"
+)
+
+// 5x7 点阵数字字形，每行用5位二进制表示
+var glyphs = map[byte][7]uint8{
+	'0': {0b01110, 0b10001, 0b10011, 0b10101, 0b11001, 0b10001, 0b01110},
+	'1': {0b00100, 0b01100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110},
+	'2': {0b01110, 0b10001, 0b00001, 0b00010, 0b00100, 0b01000, 0b11111},
+	'3': {0b11111, 0b00010, 0b00100, 0b00010, 0b00001, 0b10001, 0b01110},
+	'4': {0b00010, 0b00110, 0b01010, 0b10010, 0b11111, 0b00010, 0b00010},
+	'5': {0b11111, 0b10000, 0b11110, 0b00001, 0b00001, 0b10001, 0b01110},
+	'6': {0b00110, 0b01000, 0b10000, 0b11110, 0b10001, 0b10001, 0b01110},
+	'7': {0b11111, 0b00001, 0b00010, 0b00100, 0b01000, 0b01000, 0b01000},
+	'8': {0b01110, 0b10001, 0b10001, 0b01110, 0b10001, 0b10001, 0b01110},
+	'9': {0b01110, 0b10001, 0b10001, 0b01111, 0b00001, 0b00010, 0b01100},
+}
+
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+)
+
+// renderPNG 把验证码绘制为带干扰的PNG图片，返回 data URI 形式的 base64 字符串
+func renderPNG(code string, width, height int) (string, error) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	background := color.RGBA{uint8(230 + rand.IntN(26)), uint8(230 + rand.IntN(26)), uint8(230 + rand.IntN(26)), 255}
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = background.R, background.G, background.B, 255
+	}
+
+	// 背景噪点
+	for i := 0; i < width*height/12; i++ {
+		img.Set(rand.IntN(width), rand.IntN(height), randomColor(120, 220))
+	}
+
+	// 字符，每个字符随机缩放、倾斜和上下偏移
+	cellWidth := float64(width) / float64(len(code))
+	for i := 0; i < len(code); i++ {
+		glyph, ok := glyphs[code[i]]
+		if !ok {
+			continue
+		}
+		scale := math.Min(cellWidth/(glyphWidth+2), float64(height)/(glyphHeight+3)) * (0.85 + rand.Float64()*0.3)
+		shear := (rand.Float64() - 0.5) * 0.6
+		originX := float64(i)*cellWidth + (cellWidth-scale*glyphWidth)/2 + (rand.Float64()-0.5)*cellWidth*0.2
+		originY := (float64(height)-scale*glyphHeight)/2 + (rand.Float64()-0.5)*float64(height)*0.2
+		drawGlyph(img, glyph, originX, originY, scale, shear, randomColor(20, 110))
+	}
+
+	// 干扰线
+	for i := 0; i < 3+rand.IntN(3); i++ {
+		drawLine(img, rand.IntN(width), rand.IntN(height), rand.IntN(width), rand.IntN(height), randomColor(60, 160))
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// drawGlyph 以 scale 为点大小绘制点阵字形，shear 为水平倾斜系数
+func drawGlyph(img *image.RGBA, glyph [glyphHeight]uint8, originX, originY, scale, shear float64, c color.RGBA) {
+	size := int(math.Ceil(scale))
+	for row := 0; row < glyphHeight; row++ {
+		for col := 0; col < glyphWidth; col++ {
+			if glyph[row]&(1<<(glyphWidth-1-col)) == 0 {
+				continue
+			}
+			y := originY + float64(row)*scale
+			x := originX + float64(col)*scale + shear*(float64(glyphHeight-row)*scale)
+			fillRect(img, int(x), int(y), size, size, c)
+		}
+	}
+}
+
+func fillRect(img *image.RGBA, x, y, w, h int, c color.RGBA) {
+	bounds := img.Bounds()
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			if image.Pt(x+dx, y+dy).In(bounds) {
+				img.SetRGBA(x+dx, y+dy, c)
+			}
+		}
+	}
+}
+
+// drawLine 使用 Bresenham 算法绘制宽度为2像素的直线
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		fillRect(img, x0, y0, 2, 2, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func randomColor(min, max int) color.RGBA {
+	return color.RGBA{
+		uint8(min + rand.IntN(max-min)),
+		uint8(min + rand.IntN(max-min)),
+		uint8(min + rand.IntN(max-min)),
+		255,
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
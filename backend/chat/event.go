@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// 消息类型，与前台 ChatRoom 组件一致
+const (
+	TypeOnlineCount   = 1 // 在线人数
+	TypeHistoryRecord = 2 // 历史记录
+	TypeSendMessage   = 3 // 发送消息
+	TypeRecallMessage = 4 // 撤回消息
+	TypeHeartBeat     = 5 // 心跳
+	TypeClientInfo    = 6 // 客户端信息
+	TypeError         = 7 // 错误提示
+)
+
+// Event 收发的消息外层结构，Data 是 JSON 字符串
+type Event struct {
+	Type      int    `json:"type"`
+	Data      string `json:"data"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// OnlineEvent 在线人数
+type OnlineEvent struct {
+	Count    int    `json:"count"`
+	IsOnline bool   `json:"is_online"`
+	Msg      string `json:"msg"`
+}
+
+// HistoryMessageEvent 历史记录
+type HistoryMessageEvent struct {
+	List []*models.ChatMessage `json:"list"`
+}
+
+// RecallMessageEvent 撤回消息
+type RecallMessageEvent struct {
+	ID int64 `json:"id"`
+}
+
+// ClientInfoEvent 客户端信息
+type ClientInfoEvent struct {
+	IpAddress string `json:"ip_address"`
+	IpSource  string `json:"ip_source"`
+}
+
+// ErrorEvent 错误提示
+type ErrorEvent struct {
+	Msg string `json:"msg"`
+}
+
+// SendMessageEvent 客户端发送的消息，其余字段由服务端填充
+type SendMessageEvent struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// encode 把数据编码为一条完整的消息
+func encode(typ int, data interface{}) []byte {
+	payload, _ := json.Marshal(data)
+	msg, _ := json.Marshal(Event{Type: typ, Data: string(payload), Timestamp: time.Now().UnixMilli()})
+	return msg
+}
+
+// emojiPattern 前台表情转换出的图片标签，这是消息中唯一允许保留的 HTML
+var emojiPattern = regexp.MustCompile(`<img src="(https?://[^"<>\s]+)" width="21" height="21" style="margin: 0 1px;vertical-align: text-bottom"/>`)
+
+// sanitize 转义消息中的 HTML，只保留前台生成的表情图片
+func sanitize(content string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range emojiPattern.FindAllStringSubmatchIndex(content, -1) {
+		b.WriteString(html.EscapeString(content[last:loc[0]]))
+		b.WriteString(`<img src="`)
+		b.WriteString(html.EscapeString(content[loc[2]:loc[3]]))
+		b.WriteString(`" width="21" height="21" style="margin: 0 1px;vertical-align: text-bottom"/>`)
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(content[last:]))
+	return b.String()
+}
+
+// plainText 去掉表情图片后的文本，用于长度和垃圾内容检查
+func plainText(content string) string {
+	return emojiPattern.ReplaceAllString(content, "")
+}
@@ -0,0 +1,470 @@
+// Package chat 聊天室：基于 WebSocket 的广播聊天，维护在线人数，进入时下发最近的历史消息，
+// 支持在时间窗口内撤回自己的消息；消息持久化到数据库，并经过垃圾内容过滤
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/ipgeo"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+	"github.com/jayden/personal-blog-backend/spamfilter"
+	"github.com/jayden/personal-blog-backend/tourist"
+)
+
+const (
+	// maxContentLength 消息文本的最大字符数，不含表情图片
+	maxContentLength = 500
+	// readTimeout 超过该时长没有收到任何消息（包括心跳）时断开连接
+	readTimeout = 90 * time.Second
+	// writeTimeout 单条消息的写超时
+	writeTimeout = 10 * time.Second
+	// sendBuffer 每个连接待发送消息的缓冲数，写满说明客户端太慢，直接断开
+	sendBuffer = 64
+)
+
+// Options 聊天室配置
+type Options struct {
+	HistorySize    int
+	RecallWindow   time.Duration
+	SendInterval   time.Duration
+	MaxMessageSize int
+	AllowedOrigins []string
+}
+
+// Hub 管理聊天室的全部连接
+type Hub struct {
+	opts Options
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// client 一个聊天室连接
+type client struct {
+	hub  *Hub
+	conn *websocket.Conn
+	send chan []byte
+	done chan struct{}
+	once sync.Once
+
+	userID     int
+	isAdmin    bool
+	terminalID string
+	nickname   string
+	avatar     string
+	ip         string
+	ipSource   string
+	lastSend   time.Time
+}
+
+var defaultHub *Hub
+
+// Init 根据配置创建全局聊天室
+func Init(cfg *config.Config) {
+	var origins []string
+	for _, origin := range strings.Split(cfg.ChatAllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, strings.TrimRight(origin, "/"))
+		}
+	}
+	// 没有单独配置时只允许站点自身的页面连接
+	if len(origins) == 0 {
+		if site, err := url.Parse(cfg.SiteURL); err == nil && site.Scheme != "" && site.Host != "" {
+			origins = append(origins, site.Scheme+"://"+site.Host)
+		}
+	}
+	defaultHub = NewHub(Options{
+		HistorySize:    cfg.ChatHistorySize,
+		RecallWindow:   cfg.ChatRecallWindow,
+		SendInterval:   cfg.ChatSendInterval,
+		MaxMessageSize: cfg.ChatMaxMessageSize,
+		AllowedOrigins: origins,
+	})
+}
+
+// Default 返回全局聊天室
+func Default() *Hub {
+	return defaultHub
+}
+
+// Stop 关闭全局聊天室的全部连接
+func Stop() {
+	if defaultHub != nil {
+		defaultHub.Stop()
+	}
+}
+
+// NewHub 创建聊天室
+func NewHub(opts Options) *Hub {
+	return &Hub{opts: opts, clients: make(map[*client]struct{})}
+}
+
+// Handler 返回处理 WebSocket 连接的 HTTP 处理器。浏览器无法为 WebSocket 设置请求头，
+// 登录用户通过查询参数 token 传递令牌，游客通过 terminal_id 传递终端ID
+func (h *Hub) Handler() http.Handler {
+	server := websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler:   h.serve,
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siteconfig.Feature().IsChatRoom != 1 {
+			http.Error(w, "聊天室未开启", http.StatusForbidden)
+			return
+		}
+		h.mu.Lock()
+		closed := h.closed
+		h.mu.Unlock()
+		if closed {
+			http.Error(w, "服务器正在关闭", http.StatusServiceUnavailable)
+			return
+		}
+		server.ServeHTTP(w, r)
+	})
+}
+
+// checkOrigin 校验页面来源，没有配置允许的来源时只接受与请求同源的页面
+func (h *Hub) checkOrigin(cfg *websocket.Config, r *http.Request) error {
+	origin := strings.TrimRight(r.Header.Get("Origin"), "/")
+	if len(h.opts.AllowedOrigins) == 0 {
+		if origin == "" || !strings.EqualFold(origin, netutil.RequestOrigin(r)) {
+			return fmt.Errorf("不允许的来源: %s", origin)
+		}
+		cfg.Origin, _ = url.Parse(origin)
+		return nil
+	}
+	for _, allowed := range h.opts.AllowedOrigins {
+		if strings.EqualFold(origin, allowed) {
+			cfg.Origin, _ = url.Parse(origin)
+			return nil
+		}
+	}
+	return fmt.Errorf("不允许的来源: %s", origin)
+}
+
+// Stop 拒绝新连接，关闭已有连接并等待它们退出
+func (h *Hub) Stop() {
+	h.mu.Lock()
+	h.closed = true
+	clients := make([]*client, 0, len(h.clients))
+	for c := range h.clients {
+		clients = append(clients, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range clients {
+		c.close()
+	}
+	h.wg.Wait()
+}
+
+// OnlineCount 返回当前在线人数
+func (h *Hub) OnlineCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// serve 处理一个连接，直到连接断开
+func (h *Hub) serve(conn *websocket.Conn) {
+	conn.MaxPayloadBytes = h.opts.MaxMessageSize
+	c := h.newClient(conn)
+
+	if !h.register(c) {
+		conn.Close()
+		return
+	}
+	defer h.wg.Done()
+
+	go c.writeLoop()
+
+	c.push(encode(TypeClientInfo, ClientInfoEvent{IpAddress: netutil.MaskIP(c.ip), IpSource: c.ipSource}))
+	if history, err := models.GetRecentChatMessages(h.opts.HistorySize); err != nil {
+		log.Printf("获取聊天记录失败: %v", err)
+	} else {
+		for _, msg := range history {
+			msg.IpAddress = netutil.MaskIP(msg.IpAddress)
+		}
+		c.push(encode(TypeHistoryRecord, HistoryMessageEvent{List: history}))
+	}
+	h.broadcastOnline(c.nickname+" 进入了聊天室", true)
+
+	c.readLoop()
+
+	h.unregister(c)
+	h.broadcastOnline(c.nickname+" 离开了聊天室", false)
+}
+
+// newClient 根据握手请求确定连接的身份
+func (h *Hub) newClient(conn *websocket.Conn) *client {
+	r := conn.Request()
+	c := &client{
+		hub:  h,
+		conn: conn,
+		send: make(chan []byte, sendBuffer),
+		done: make(chan struct{}),
+		ip:   netutil.ClientIP(r),
+	}
+	// 只接受本站签发的游客ID，避免冒用他人的游客身份撤回消息
+	if id := strings.TrimSpace(r.URL.Query().Get("terminal_id")); tourist.Verify(id) {
+		c.terminalID = id
+		tourist.Touch(r, id)
+	}
+	c.ipSource = ipgeo.Lookup(c.ip)
+
+	website, _, err := siteconfig.Website()
+	if err == nil {
+		c.avatar = website.TouristAvatar
+	}
+	// 昵称取公开标识的后几位，终端ID本身不能出现在广播的消息中
+	c.nickname = "游客"
+	if publicID := models.TouristPublicID(c.terminalID); publicID != "" {
+		c.nickname = "游客" + publicID[len(publicID)-4:]
+	}
+
+	if claims, err := auth.ParseToken(r.URL.Query().Get("token")); err == nil {
+		c.userID = claims.UserID
+		c.isAdmin = auth.IsAdmin(claims)
+		c.nickname = claims.Username
+		if users, err := models.GetUserInfoVOs([]int{claims.UserID}); err == nil {
+			if user := users[claims.UserID]; user != nil {
+				if user.Nickname != "" {
+					c.nickname = user.Nickname
+				}
+				if user.Avatar != "" {
+					c.avatar = user.Avatar
+				}
+			}
+		}
+	}
+	return c
+}
+
+// register 加入聊天室，聊天室已关闭时返回 false
+func (h *Hub) register(c *client) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return false
+	}
+	h.clients[c] = struct{}{}
+	h.wg.Add(1)
+	return true
+}
+
+// unregister 离开聊天室并关闭连接
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+	c.close()
+}
+
+// broadcast 把消息发给全部连接，发送缓冲已满的连接会被断开
+func (h *Hub) broadcast(msg []byte) {
+	h.mu.Lock()
+	clients := make([]*client, 0, len(h.clients))
+	for c := range h.clients {
+		clients = append(clients, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range clients {
+		c.push(msg)
+	}
+}
+
+// broadcastOnline 广播在线人数
+func (h *Hub) broadcastOnline(msg string, isOnline bool) {
+	h.broadcast(encode(TypeOnlineCount, OnlineEvent{Count: h.OnlineCount(), IsOnline: isOnline, Msg: msg}))
+}
+
+// push 把消息放入发送缓冲，缓冲已满时断开连接
+func (c *client) push(msg []byte) {
+	select {
+	case <-c.done:
+	case c.send <- msg:
+	default:
+		c.close()
+	}
+}
+
+// close 关闭连接，可以重复调用
+func (c *client) close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+// writeLoop 把发送缓冲中的消息写到连接，连接关闭后退出
+func (c *client) writeLoop() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := websocket.Message.Send(c.conn, string(msg)); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+// readLoop 读取并处理客户端消息，直到连接断开
+func (c *client) readLoop() {
+	for {
+		c.conn.SetReadDeadline(time.Now().Add(readTimeout))
+		var data string
+		if err := websocket.Message.Receive(c.conn, &data); err != nil {
+			return
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			c.pushError("消息格式错误")
+			continue
+		}
+		switch event.Type {
+		case TypeSendMessage:
+			c.handleSend(event.Data)
+		case TypeRecallMessage:
+			c.handleRecall(event.Data)
+		case TypeHeartBeat:
+			c.push(encode(TypeHeartBeat, struct{}{}))
+		}
+	}
+}
+
+// pushError 给当前连接发送错误提示
+func (c *client) pushError(msg string) {
+	c.push(encode(TypeError, ErrorEvent{Msg: msg}))
+}
+
+// handleSend 处理发送消息
+func (c *client) handleSend(data string) {
+	var req SendMessageEvent
+	if err := json.Unmarshal([]byte(data), &req); err != nil {
+		c.pushError("消息格式错误")
+		return
+	}
+	if c.userID == 0 && c.terminalID == "" {
+		c.pushError("缺少游客身份，请刷新页面后重试")
+		return
+	}
+	now := time.Now()
+	if now.Sub(c.lastSend) < c.hub.opts.SendInterval {
+		c.pushError("发送太频繁，请稍后再试")
+		return
+	}
+	c.lastSend = now
+
+	content := strings.TrimSpace(req.Content)
+	text := strings.TrimSpace(plainText(content))
+	// 只有表情时 text 为空但仍是有效消息；去掉表情后只剩空白或什么都没有时拒绝
+	if text == "" && !emojiPattern.MatchString(content) {
+		c.pushError("内容不能为空")
+		return
+	}
+	if utf8.RuneCountInString(text) > maxContentLength {
+		c.pushError("内容不能超过500个字符")
+		return
+	}
+	if err := spamfilter.Check(text); err != nil {
+		c.pushError(err.Error())
+		return
+	}
+
+	msg := &models.ChatMessage{
+		TerminalID: c.terminalID,
+		Nickname:   c.nickname,
+		Avatar:     c.avatar,
+		IpAddress:  c.ip,
+		IpSource:   c.ipSource,
+		Type:       "text",
+		Content:    sanitize(content),
+	}
+	if err := models.CreateChatMessage(msg, c.userID); err != nil {
+		log.Printf("保存聊天消息失败: %v", err)
+		c.pushError("发送失败，请稍后再试")
+		return
+	}
+
+	msg.IpAddress = netutil.MaskIP(msg.IpAddress)
+	c.hub.broadcast(encode(TypeSendMessage, msg))
+}
+
+// errRecallDenied 不能撤回该消息
+var errRecallDenied = errors.New("只能撤回自己发送的消息")
+
+// handleRecall 处理撤回消息
+func (c *client) handleRecall(data string) {
+	var req RecallMessageEvent
+	if err := json.Unmarshal([]byte(data), &req); err != nil {
+		c.pushError("消息格式错误")
+		return
+	}
+
+	msg, err := models.GetChatMessageByID(req.ID)
+	if err != nil {
+		log.Printf("获取聊天消息失败: %v", err)
+		c.pushError("撤回失败，请稍后再试")
+		return
+	}
+	if msg == nil {
+		c.pushError("消息不存在")
+		return
+	}
+	if err := c.canRecall(msg); err != nil {
+		c.pushError(err.Error())
+		return
+	}
+
+	recalled, err := models.RecallChatMessage(msg.ID)
+	if err != nil {
+		log.Printf("撤回聊天消息失败: %v", err)
+		c.pushError("撤回失败，请稍后再试")
+		return
+	}
+	if recalled {
+		c.hub.broadcast(encode(TypeRecallMessage, RecallMessageEvent{ID: msg.ID}))
+	}
+}
+
+// canRecall 判断当前连接能否撤回消息：管理员可以撤回任意消息，其他人只能在时间窗口内撤回自己的消息
+func (c *client) canRecall(msg *models.ChatMessage) error {
+	if c.isAdmin {
+		return nil
+	}
+	own := false
+	if c.userID > 0 {
+		own = msg.UserID == models.FormatUserID(c.userID)
+	} else {
+		own = msg.UserID == "" && c.terminalID != "" && msg.TerminalID == c.terminalID
+	}
+	if !own {
+		return errRecallDenied
+	}
+	if time.Since(time.Unix(msg.CreatedAt, 0)) > c.hub.opts.RecallWindow {
+		return fmt.Errorf("消息发送超过%s，不能撤回", c.hub.opts.RecallWindow)
+	}
+	return nil
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config 存储应用程序配置
@@ -12,21 +18,301 @@ type Config struct {
 	DBUser     string
 	DBPassword string
 	DBName     string
+
+	// JWT签名密钥
+	JWTSecret string
+
+	// 第三方登录配置，键为平台标识，未配置 CLIENT_ID 的平台不启用
+	OAuth map[string]OAuthConfig
+	// 第三方登录state签名密钥
+	OAuthStateSecret string
+
+	// 邮件配置
+	MailDriver   string // 邮件驱动：smtp 或 log
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string // 发件人地址
+	MailLogFile  string // log 驱动写入的文件，为空时写入标准日志
+
+	// 短信配置
+	SMSDriver             string // 短信驱动，目前只有本地假服务 fake
+	SMSDefaultCountryCode string // 手机号不带国家码时使用的国家码
+
+	// 验证码配置
+	VerifyCodeTTL        time.Duration // 验证码有效期
+	VerifyCodeInterval   time.Duration // 同一地址两次发送的最小间隔
+	VerifyCodeDailyLimit int           // 同一地址每天最多发送次数
+
+	// 图形验证码配置
+	CaptchaMode          string        // off、always 或 adaptive
+	CaptchaTTL           time.Duration // 图形验证码有效期
+	CaptchaThreshold     int           // 自适应模式下，连续失败多少次后要求验证码
+	CaptchaFailureWindow time.Duration // 失败次数的统计窗口
+
+	// 登录保护配置
+	LoginAccountThreshold int           // 账号连续失败多少次后锁定
+	LoginIPThreshold      int           // IP连续失败多少次后锁定
+	LoginFailureWindow    time.Duration // 距上次失败超过该时长后重新计数
+	LoginLockBase         time.Duration // 首次锁定时长，之后指数增长
+	LoginLockMax          time.Duration // 最长锁定时长
+
+	// 文件上传配置
+	UploadDriver       string // 存储驱动：local 或 s3
+	UploadLocalDir     string // local 驱动的保存目录
+	UploadBaseURL      string // local 驱动的文件访问前缀
+	UploadMaxSize      int64  // 单个文件大小上限（字节）
+	UploadMaxFiles     int    // 批量上传一次最多的文件数
+	UploadAllowedTypes string // 允许上传的 MIME 类型，逗号分隔，按文件内容识别
+
+	// 图片处理配置
+	ImageThumbSize  int // 缩略图最长边像素
+	ImageMediumSize int // 中图最长边像素
+	ImageQuality    int // JPEG 变体质量 1-100
+	ImageWorkers    int // 生成变体的工作协程数
+	ImageQueueSize  int // 待处理队列长度
+
+	// S3 兼容对象存储配置
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PublicURL string // 文件公开访问前缀，为空时使用 S3Endpoint/S3Bucket
+
+	// 限流配置，格式为 name=limit/period[/burst]，逗号分隔，覆盖同名默认策略
+	RateLimitPolicies string
+	// 受信任的反向代理IP或网段，逗号分隔；只有来自这些地址的请求才读取 X-Forwarded-For
+	TrustedProxies string
+
+	// IP归属地配置
+	IPGeoDBPath    string // ip2region xdb 文件路径，为空时只识别内网地址
+	IPGeoCacheSize int    // 归属地缓存的最大条数
+
+	// 浏览量统计配置
+	ViewDedupWindow   time.Duration // 同一访客重复浏览同一文章的去重窗口
+	ViewFlushInterval time.Duration // 浏览量批量写回数据库的间隔
+	ViewFlushBatch    int           // 累积多少条待写回记录时立即写回
+
+	// 网站配置缓存有效期，其他进程修改的配置最多延迟这么久生效
+	SiteConfigCacheTTL time.Duration
+
+	// 垃圾内容过滤配置
+	SpamWords    string // 违禁词，逗号分隔，不区分大小写
+	SpamMaxLinks int    // 一条内容允许的最大链接数，小于0时不限制
+
+	// 聊天室配置
+	ChatHistorySize    int           // 进入聊天室时下发的历史消息条数
+	ChatRecallWindow   time.Duration // 发送后多久内可以撤回
+	ChatSendInterval   time.Duration // 同一连接两次发送的最小间隔
+	ChatMaxMessageSize int           // 单条消息的最大字节数
+	ChatAllowedOrigins string        // 允许连接的页面来源，逗号分隔，为空时使用 SITE_URL，都为空时只允许同源
+
+	// 文章检索配置
+	SearchDriver        string // 检索引擎：memory 或 mysql
+	SearchSnippetLength int    // 检索结果摘要的最大字符数
+
+	// 订阅源配置
+	FeedItemCount     int    // 输出的文章数
+	FeedContent       string // 正文输出方式：full 输出全文，excerpt 只输出摘要
+	FeedExcerptLength int    // 摘要的最大字符数
+
+	// SEO 配置
+	SitemapMaxURLs       int           // 单个站点地图文件的最大地址数，超过后输出站点地图索引
+	SitemapCacheTTL      time.Duration // 站点地图的缓存时间
+	SeoDescriptionLength int           // 文章描述的最大字符数
+	SeoTwitterSite       string        // Twitter 卡片中的站点账号
+	PrerenderBotAgents   string        // 额外识别为爬虫的 User-Agent 片段，逗号分隔
+
+	// 游客配置
+	TouristSecret        string        // 游客ID签名密钥
+	TouristTouchInterval time.Duration // 同一游客两次刷新最后访问时间的最小间隔
+
+	// 本站前台地址，用于检测友链页面是否有指向本站的链接，以及生成订阅源、站点地图和 SEO 信息中的链接
+	SiteURL string
+
+	// 友链检测配置
+	FriendCheckInterval    time.Duration // 定期检测的间隔，为0时不启动后台检测
+	FriendCheckTimeout     time.Duration // 单个友链的请求超时
+	FriendCheckConcurrency int           // 同时检测的友链数
+	FriendDeadThreshold    int           // 连续失败多少次后标记为失效
+
+	// 分类和标签计数校正的间隔，为0时不启动定期校正
+	TaxonomyReconcileInterval time.Duration
 }
 
+// OAuthConfig 单个第三方平台的配置，各地址为空时使用平台默认地址
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	OpenIDURL    string // 仅QQ使用
+}
+
+// insecureJWTSecret 早期版本的默认JWT密钥，已随源码公开，不能再用于签名
+const insecureJWTSecret = "my_secret_key"
+
+// oauthPlatforms 支持的第三方平台
+var oauthPlatforms = []string{"github", "gitee", "qq"}
+
 // LoadConfig 从环境变量加载配置，如果环境变量不存在则使用默认值
 func LoadConfig() (*Config, error) {
+	// JWT密钥没有安全的默认值，未设置时拒绝启动，其余密钥未单独设置时由它按用途派生
+	jwtSecret := getEnv("JWT_SECRET", "")
+	if jwtSecret == "" || jwtSecret == insecureJWTSecret {
+		return nil, fmt.Errorf("必须通过环境变量 JWT_SECRET 设置JWT签名密钥，且不能使用默认值 %q", insecureJWTSecret)
+	}
+
 	config := &Config{
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "3306"),
 		DBUser:     getEnv("DB_USER", "root"),
 		DBPassword: getEnv("DB_PASSWORD", "123456"),
 		DBName:     getEnv("DB_NAME", "personal_blog_db"),
+
+		JWTSecret: jwtSecret,
+
+		OAuth:            loadOAuthConfig(),
+		OAuthStateSecret: getEnv("OAUTH_STATE_SECRET", deriveSecret(jwtSecret, "oauth-state")),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "log"),
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		MailFrom:     getEnv("MAIL_FROM", ""),
+		MailLogFile:  getEnv("MAIL_LOG_FILE", ""),
+
+		SMSDriver:             getEnv("SMS_DRIVER", "fake"),
+		SMSDefaultCountryCode: getEnv("SMS_DEFAULT_COUNTRY_CODE", "86"),
+
+		VerifyCodeTTL:        getEnvDuration("VERIFY_CODE_TTL", 10*time.Minute),
+		VerifyCodeInterval:   getEnvDuration("VERIFY_CODE_INTERVAL", time.Minute),
+		VerifyCodeDailyLimit: getEnvInt("VERIFY_CODE_DAILY_LIMIT", 10),
+
+		CaptchaMode:          getEnv("CAPTCHA_MODE", "adaptive"),
+		CaptchaTTL:           getEnvDuration("CAPTCHA_TTL", 5*time.Minute),
+		CaptchaThreshold:     getEnvInt("CAPTCHA_THRESHOLD", 3),
+		CaptchaFailureWindow: getEnvDuration("CAPTCHA_FAILURE_WINDOW", 15*time.Minute),
+
+		LoginAccountThreshold: getEnvInt("LOGIN_ACCOUNT_THRESHOLD", 5),
+		LoginIPThreshold:      getEnvInt("LOGIN_IP_THRESHOLD", 20),
+		LoginFailureWindow:    getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		LoginLockBase:         getEnvDuration("LOGIN_LOCK_BASE", time.Minute),
+		LoginLockMax:          getEnvDuration("LOGIN_LOCK_MAX", time.Hour),
+
+		UploadDriver:       getEnv("UPLOAD_DRIVER", "local"),
+		UploadLocalDir:     getEnv("UPLOAD_LOCAL_DIR", "uploads"),
+		UploadBaseURL:      getEnv("UPLOAD_BASE_URL", "http://localhost:8083/uploads"),
+		UploadMaxSize:      int64(getEnvInt("UPLOAD_MAX_SIZE", 10<<20)),
+		UploadMaxFiles:     getEnvInt("UPLOAD_MAX_FILES", 10),
+		UploadAllowedTypes: getEnv("UPLOAD_ALLOWED_TYPES", "image/jpeg,image/png,image/gif,image/webp,image/bmp,application/pdf,text/plain,video/mp4,audio/mpeg,application/zip"),
+
+		ImageThumbSize:  getEnvInt("IMAGE_THUMB_SIZE", 300),
+		ImageMediumSize: getEnvInt("IMAGE_MEDIUM_SIZE", 1280),
+		ImageQuality:    getEnvInt("IMAGE_QUALITY", 85),
+		ImageWorkers:    getEnvInt("IMAGE_WORKERS", 2),
+		ImageQueueSize:  getEnvInt("IMAGE_QUEUE_SIZE", 256),
+
+		S3Endpoint:  getEnv("S3_ENDPOINT", ""),
+		S3Region:    getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:    getEnv("S3_BUCKET", ""),
+		S3AccessKey: getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey: getEnv("S3_SECRET_KEY", ""),
+		S3PublicURL: getEnv("S3_PUBLIC_URL", ""),
+
+		RateLimitPolicies: getEnv("RATE_LIMIT_POLICIES", ""),
+		TrustedProxies:    getEnv("TRUSTED_PROXIES", ""),
+
+		IPGeoDBPath:    getEnv("IP_GEO_DB_PATH", ""),
+		IPGeoCacheSize: getEnvInt("IP_GEO_CACHE_SIZE", 10000),
+
+		ViewDedupWindow:   getEnvDuration("VIEW_DEDUP_WINDOW", 30*time.Minute),
+		ViewFlushInterval: getEnvDuration("VIEW_FLUSH_INTERVAL", 30*time.Second),
+		ViewFlushBatch:    getEnvInt("VIEW_FLUSH_BATCH", 500),
+
+		SiteConfigCacheTTL: getEnvDuration("SITE_CONFIG_CACHE_TTL", time.Minute),
+
+		SpamWords:    getEnv("SPAM_WORDS", ""),
+		SpamMaxLinks: getEnvInt("SPAM_MAX_LINKS", 3),
+
+		ChatHistorySize:    getEnvInt("CHAT_HISTORY_SIZE", 50),
+		ChatRecallWindow:   getEnvDuration("CHAT_RECALL_WINDOW", 2*time.Minute),
+		ChatSendInterval:   getEnvDuration("CHAT_SEND_INTERVAL", time.Second),
+		ChatMaxMessageSize: getEnvInt("CHAT_MAX_MESSAGE_SIZE", 8<<10),
+		ChatAllowedOrigins: getEnv("CHAT_ALLOWED_ORIGINS", ""),
+
+		SearchDriver:        getEnv("SEARCH_DRIVER", "memory"),
+		SearchSnippetLength: getEnvInt("SEARCH_SNIPPET_LENGTH", 120),
+
+		FeedItemCount:     getEnvInt("FEED_ITEM_COUNT", 20),
+		FeedContent:       getEnv("FEED_CONTENT", "full"),
+		FeedExcerptLength: getEnvInt("FEED_EXCERPT_LENGTH", 200),
+
+		SitemapMaxURLs:       getEnvInt("SITEMAP_MAX_URLS", 50000),
+		SitemapCacheTTL:      getEnvDuration("SITEMAP_CACHE_TTL", 10*time.Minute),
+		SeoDescriptionLength: getEnvInt("SEO_DESCRIPTION_LENGTH", 150),
+		SeoTwitterSite:       getEnv("SEO_TWITTER_SITE", ""),
+		PrerenderBotAgents:   getEnv("PRERENDER_BOT_AGENTS", ""),
+
+		TouristSecret:        getEnv("TOURIST_SECRET", deriveSecret(jwtSecret, "tourist")),
+		TouristTouchInterval: getEnvDuration("TOURIST_TOUCH_INTERVAL", 5*time.Minute),
+
+		SiteURL: getEnv("SITE_URL", ""),
+
+		FriendCheckInterval:    getEnvDuration("FRIEND_CHECK_INTERVAL", 6*time.Hour),
+		FriendCheckTimeout:     getEnvDuration("FRIEND_CHECK_TIMEOUT", 10*time.Second),
+		FriendCheckConcurrency: getEnvInt("FRIEND_CHECK_CONCURRENCY", 4),
+		FriendDeadThreshold:    getEnvInt("FRIEND_DEAD_THRESHOLD", 3),
+
+		TaxonomyReconcileInterval: getEnvDuration("TAXONOMY_RECONCILE_INTERVAL", 24*time.Hour),
+	}
+
+	for name, secret := range map[string]string{
+		"OAUTH_STATE_SECRET": config.OAuthStateSecret,
+		"TOURIST_SECRET":     config.TouristSecret,
+	} {
+		if secret == insecureJWTSecret {
+			return nil, fmt.Errorf("环境变量 %s 不能使用默认值 %q", name, insecureJWTSecret)
+		}
 	}
 
 	return config, nil
 }
 
+// deriveSecret 按用途从主密钥派生独立的密钥，泄露其中一个不会影响其他用途
+func deriveSecret(master, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(master))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// loadOAuthConfig 加载各第三方平台配置，环境变量形如 OAUTH_GITHUB_CLIENT_ID
+func loadOAuthConfig() map[string]OAuthConfig {
+	configs := make(map[string]OAuthConfig)
+	for _, platform := range oauthPlatforms {
+		prefix := "OAUTH_" + strings.ToUpper(platform) + "_"
+		clientID := getEnv(prefix+"CLIENT_ID", "")
+		if clientID == "" {
+			continue
+		}
+		configs[platform] = OAuthConfig{
+			ClientID:     clientID,
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", ""),
+			AuthURL:      getEnv(prefix+"AUTH_URL", ""),
+			TokenURL:     getEnv(prefix+"TOKEN_URL", ""),
+			UserInfoURL:  getEnv(prefix+"USERINFO_URL", ""),
+			OpenIDURL:    getEnv(prefix+"OPENID_URL", ""),
+		}
+	}
+	return configs
+}
+
 // getEnv 从环境变量获取值，如果不存在则返回默认值
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -36,6 +322,24 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// getEnvInt 从环境变量获取整数值，不存在或无法解析时返回默认值
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration 从环境变量获取时长（如 "30s"、"5m"），不存在或无法解析时返回默认值
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // GetDBConnectionString 构建数据库连接字符串
 func (c *Config) GetDBConnectionString() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
@@ -0,0 +1,355 @@
+-- 数据库表结构
+-- 新增功能依赖的表和字段按功能分段追加，部署时按顺序执行尚未执行的部分即可
+
+-- ----------------------------------------
+-- 浏览量统计
+-- ----------------------------------------
+ALTER TABLE article ADD COLUMN views_count BIGINT NOT NULL DEFAULT 0 COMMENT '浏览量';
+
+CREATE TABLE IF NOT EXISTS daily_view_stat (
+    day             DATE   NOT NULL COMMENT '统计日期',
+    page_view_count BIGINT NOT NULL DEFAULT 0 COMMENT '浏览量(PV)',
+    user_view_count BIGINT NOT NULL DEFAULT 0 COMMENT '访客数(UV)',
+    PRIMARY KEY (day)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '每日访问统计';
+
+-- ----------------------------------------
+-- 用户资料与角色
+-- ----------------------------------------
+ALTER TABLE user
+    ADD COLUMN role          VARCHAR(16)  NOT NULL DEFAULT '' COMMENT '角色，管理员为 admin',
+    ADD COLUMN nickname      VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '昵称',
+    ADD COLUMN avatar        VARCHAR(512) NOT NULL DEFAULT '' COMMENT '头像',
+    ADD COLUMN phone         VARCHAR(32)  NOT NULL DEFAULT '' COMMENT '手机号',
+    ADD COLUMN register_type VARCHAR(32)  NOT NULL DEFAULT 'username' COMMENT '注册方式',
+    ADD COLUMN gender        TINYINT      NOT NULL DEFAULT 0 COMMENT '性别 0未知 1男 2女',
+    ADD COLUMN intro         VARCHAR(255) NOT NULL DEFAULT '' COMMENT '简介',
+    ADD COLUMN website       VARCHAR(255) NOT NULL DEFAULT '' COMMENT '网站';
+
+-- ----------------------------------------
+-- 手机号绑定
+-- ----------------------------------------
+UPDATE user SET phone = NULL WHERE phone = '';
+ALTER TABLE user
+    MODIFY COLUMN phone VARCHAR(32) NULL DEFAULT NULL COMMENT '手机号，E.164格式',
+    ADD UNIQUE KEY uk_user_phone (phone);
+
+-- ----------------------------------------
+-- 第三方登录绑定
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS user_oauth (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    user_id      INT          NOT NULL COMMENT '用户ID',
+    platform     VARCHAR(32)  NOT NULL COMMENT '平台标识',
+    open_id      VARCHAR(128) NOT NULL COMMENT '平台用户ID',
+    nickname     VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '平台昵称',
+    avatar       VARCHAR(512) NOT NULL DEFAULT '' COMMENT '平台头像',
+    created_time DATETIME     NOT NULL COMMENT '绑定时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_platform_open_id (platform, open_id),
+    UNIQUE KEY uk_user_platform (user_id, platform)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '用户第三方账号绑定';
+
+-- ----------------------------------------
+-- 登录审计
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS login_audit (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    event        VARCHAR(32)  NOT NULL COMMENT '事件类型',
+    account      VARCHAR(128) NOT NULL DEFAULT '' COMMENT '账号标识',
+    ip           VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '客户端IP',
+    detail       VARCHAR(255) NOT NULL DEFAULT '' COMMENT '详情',
+    created_time DATETIME     NOT NULL COMMENT '发生时间',
+    PRIMARY KEY (id),
+    KEY idx_created_time (created_time)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '登录审计事件';
+
+-- ----------------------------------------
+-- 文件上传
+-- ----------------------------------------
+-- 相同内容的文件在存储后端只保存一份（storage_key 由内容摘要生成），
+-- 不同目录下的记录可以引用同一个存储对象，最后一条记录删除时才删除对象
+CREATE TABLE IF NOT EXISTS upload_file (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    user_id      INT          NOT NULL COMMENT '上传人',
+    file_path    VARCHAR(255) NOT NULL COMMENT '文件路径（目录/内容摘要.扩展名）',
+    file_name    VARCHAR(255) NOT NULL DEFAULT '' COMMENT '原始文件名',
+    file_type    VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'MIME类型',
+    file_size    BIGINT       NOT NULL DEFAULT 0 COMMENT '文件大小（字节）',
+    file_url     VARCHAR(512) NOT NULL DEFAULT '' COMMENT '访问地址',
+    file_hash    CHAR(64)     NOT NULL COMMENT '内容SHA256',
+    storage_key  VARCHAR(255) NOT NULL COMMENT '存储对象键',
+    created_time DATETIME     NOT NULL COMMENT '创建时间',
+    updated_time DATETIME     NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_file_path (file_path),
+    KEY idx_storage_key (storage_key),
+    KEY idx_user_id (user_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '上传文件';
+
+-- ----------------------------------------
+-- 图片变体
+-- ----------------------------------------
+ALTER TABLE upload_file
+    ADD COLUMN thumb_url  VARCHAR(512) NOT NULL DEFAULT '' COMMENT '缩略图地址' AFTER file_url,
+    ADD COLUMN medium_url VARCHAR(512) NOT NULL DEFAULT '' COMMENT '中图地址' AFTER thumb_url;
+
+-- ----------------------------------------
+-- 相册
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS album (
+    id             BIGINT       NOT NULL AUTO_INCREMENT,
+    album_name     VARCHAR(64)  NOT NULL COMMENT '相册名',
+    album_desc     VARCHAR(255) NOT NULL DEFAULT '' COMMENT '相册描述',
+    album_cover    VARCHAR(512) NOT NULL DEFAULT '' COMMENT '相册封面',
+    is_private     TINYINT(1)   NOT NULL DEFAULT 0 COMMENT '是否私密，私密相册只有管理员可见',
+    album_password VARCHAR(100) NOT NULL DEFAULT '' COMMENT '访问密码的bcrypt哈希，为空表示不需要密码',
+    created_time   DATETIME     NOT NULL COMMENT '创建时间',
+    updated_time   DATETIME     NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '相册';
+
+CREATE TABLE IF NOT EXISTS photo (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    album_id     BIGINT       NOT NULL COMMENT '相册ID',
+    photo_url    VARCHAR(512) NOT NULL COMMENT '照片地址',
+    photo_name   VARCHAR(255) NOT NULL DEFAULT '' COMMENT '照片名称',
+    sort         INT          NOT NULL DEFAULT 0 COMMENT '排序，越小越靠前',
+    created_time DATETIME     NOT NULL COMMENT '创建时间',
+    updated_time DATETIME     NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_album_sort (album_id, sort)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '照片';
+
+-- ----------------------------------------
+-- 评论、点赞和说说
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS comment (
+    id              BIGINT      NOT NULL AUTO_INCREMENT,
+    topic_id        BIGINT      NOT NULL DEFAULT 0 COMMENT '主题ID，文章/友链/说说的ID',
+    parent_id       BIGINT      NOT NULL DEFAULT 0 COMMENT '父评论ID',
+    reply_msg_id    BIGINT      NOT NULL DEFAULT 0 COMMENT '会话ID',
+    user_id         VARCHAR(32) NOT NULL COMMENT '评论用户ID',
+    reply_user_id   VARCHAR(32) NOT NULL DEFAULT '' COMMENT '被回复用户ID',
+    comment_content TEXT        NOT NULL COMMENT '评论内容',
+    type            TINYINT     NOT NULL COMMENT '评论类型 1文章 2友链 3说说',
+    status          TINYINT     NOT NULL DEFAULT 0 COMMENT '状态 0正常 1已编辑 2已删除',
+    created_time    DATETIME    NOT NULL COMMENT '创建时间',
+    updated_time    DATETIME    NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_type_topic (type, topic_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '评论';
+
+CREATE TABLE IF NOT EXISTS user_like (
+    user_id      INT      NOT NULL COMMENT '用户ID',
+    object_type  TINYINT  NOT NULL COMMENT '点赞对象类型 1文章 2评论 3说说',
+    object_id    BIGINT   NOT NULL COMMENT '点赞对象ID',
+    created_time DATETIME NOT NULL COMMENT '点赞时间',
+    PRIMARY KEY (user_id, object_type, object_id),
+    KEY idx_object (object_type, object_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '用户点赞';
+
+CREATE TABLE IF NOT EXISTS talk (
+    id           BIGINT   NOT NULL AUTO_INCREMENT,
+    user_id      INT      NOT NULL COMMENT '发布人ID',
+    content      TEXT     NOT NULL COMMENT '内容',
+    img_list     TEXT     NOT NULL COMMENT '图片地址列表，JSON数组',
+    is_top       TINYINT  NOT NULL DEFAULT 0 COMMENT '是否置顶',
+    status       TINYINT  NOT NULL DEFAULT 1 COMMENT '状态 1公开 2私密',
+    like_count   INT      NOT NULL DEFAULT 0 COMMENT '点赞数',
+    created_time DATETIME NOT NULL COMMENT '创建时间',
+    updated_time DATETIME NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_status_top (status, is_top)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '说说';
+
+-- ----------------------------------------
+-- 留言板
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS remark (
+    id              BIGINT        NOT NULL AUTO_INCREMENT,
+    user_id         INT           NOT NULL DEFAULT 0 COMMENT '用户ID，游客留言时为0',
+    terminal_id     VARCHAR(64)   NOT NULL DEFAULT '' COMMENT '终端ID，标识游客',
+    message_content VARCHAR(1000) NOT NULL COMMENT '留言内容',
+    ip_address      VARCHAR(64)   NOT NULL DEFAULT '' COMMENT '用户IP',
+    ip_source       VARCHAR(128)  NOT NULL DEFAULT '' COMMENT '用户地址',
+    is_review       TINYINT       NOT NULL DEFAULT 1 COMMENT '是否审核通过 0待审核 1已通过',
+    created_time    DATETIME      NOT NULL COMMENT '创建时间',
+    updated_time    DATETIME      NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_review (is_review)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '留言';
+
+-- ----------------------------------------
+-- 友链
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS friend (
+    id            BIGINT       NOT NULL AUTO_INCREMENT,
+    link_name     VARCHAR(64)  NOT NULL COMMENT '链接名',
+    link_avatar   VARCHAR(255) NOT NULL DEFAULT '' COMMENT '链接头像',
+    link_address  VARCHAR(255) NOT NULL COMMENT '链接地址',
+    link_intro    VARCHAR(255) NOT NULL DEFAULT '' COMMENT '链接介绍',
+    contact_email VARCHAR(128) NOT NULL DEFAULT '' COMMENT '申请人联系邮箱',
+    status        TINYINT      NOT NULL DEFAULT 0 COMMENT '审核状态 0待审核 1已通过 2已拒绝',
+    is_reachable  TINYINT      NOT NULL DEFAULT 0 COMMENT '最近一次检测是否可以访问',
+    has_backlink  TINYINT      NOT NULL DEFAULT 0 COMMENT '最近一次检测是否有指向本站的链接',
+    fail_count    INT          NOT NULL DEFAULT 0 COMMENT '连续检测失败次数',
+    is_dead       TINYINT      NOT NULL DEFAULT 0 COMMENT '是否已失效',
+    check_error   VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最近一次检测的错误信息',
+    checked_time  DATETIME     NULL COMMENT '最近一次检测时间',
+    created_time  DATETIME     NOT NULL COMMENT '创建时间',
+    updated_time  DATETIME     NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_link_address (link_address),
+    KEY idx_status_dead (status, is_dead)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '友链';
+
+-- ----------------------------------------
+-- 页面封面和首页轮播
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS page (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    page_name    VARCHAR(32)  NOT NULL COMMENT '页面名',
+    page_label   VARCHAR(32)  NOT NULL COMMENT '页面标签',
+    page_cover   VARCHAR(255) NOT NULL DEFAULT '' COMMENT '页面封面',
+    is_carousel  TINYINT      NOT NULL DEFAULT 0 COMMENT '是否在首页轮播',
+    sort         INT          NOT NULL DEFAULT 0 COMMENT '排序，越小越靠前',
+    created_time DATETIME     NOT NULL COMMENT '创建时间',
+    updated_time DATETIME     NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_page_label (page_label)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '页面';
+
+-- ----------------------------------------
+-- 网站配置及其历史版本
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS website_config (
+    config_key   VARCHAR(32) NOT NULL COMMENT '配置项',
+    config_value MEDIUMTEXT  NOT NULL COMMENT '配置内容，JSON',
+    version      INT         NOT NULL COMMENT '当前版本号',
+    created_time DATETIME    NOT NULL COMMENT '创建时间',
+    updated_time DATETIME    NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (config_key)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '网站配置';
+
+CREATE TABLE IF NOT EXISTS website_config_version (
+    id            BIGINT      NOT NULL AUTO_INCREMENT,
+    config_key    VARCHAR(32) NOT NULL COMMENT '配置项',
+    version       INT         NOT NULL COMMENT '版本号',
+    config_value  MEDIUMTEXT  NOT NULL COMMENT '该版本的配置内容，JSON',
+    operator_id   INT         NOT NULL DEFAULT 0 COMMENT '修改人ID',
+    rollback_from INT         NOT NULL DEFAULT 0 COMMENT '回滚来源版本，不是回滚产生的版本为0',
+    created_time  DATETIME    NOT NULL COMMENT '创建时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_key_version (config_key, version)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '网站配置历史版本';
+
+-- ----------------------------------------
+-- 聊天室
+-- ----------------------------------------
+CREATE TABLE IF NOT EXISTS chat_message (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    user_id      INT          NOT NULL DEFAULT 0 COMMENT '用户ID，游客发送时为0',
+    terminal_id  VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '终端ID，标识游客',
+    nickname     VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '发送时的昵称',
+    avatar       VARCHAR(255) NOT NULL DEFAULT '' COMMENT '发送时的头像',
+    ip_address   VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '发送人IP',
+    ip_source    VARCHAR(128) NOT NULL DEFAULT '' COMMENT '发送人地址',
+    type         VARCHAR(16)  NOT NULL DEFAULT 'text' COMMENT '消息类型',
+    content      TEXT         NOT NULL COMMENT '消息内容',
+    status       TINYINT      NOT NULL DEFAULT 0 COMMENT '状态 0正常 1已编辑 2已撤回 3已删除',
+    created_time DATETIME     NOT NULL COMMENT '创建时间',
+    updated_time DATETIME     NOT NULL COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_status (status)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '聊天室消息';
+
+-- ----------------------------------------
+-- 游客
+-- ----------------------------------------
+-- 游客注册或登录后按终端ID认领留言和聊天消息
+ALTER TABLE remark ADD KEY idx_terminal (terminal_id);
+ALTER TABLE chat_message ADD KEY idx_terminal (terminal_id);
+
+CREATE TABLE IF NOT EXISTS tourist (
+    tourist_id      VARCHAR(64)  NOT NULL COMMENT '游客ID，由服务端签发',
+    ip_address      VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '最近一次访问的IP',
+    user_agent      VARCHAR(512) NOT NULL DEFAULT '' COMMENT '最近一次访问的User-Agent',
+    merged_user_id  INT          NOT NULL DEFAULT 0 COMMENT '合并到的用户ID，未合并为0',
+    first_seen_time DATETIME     NOT NULL COMMENT '首次访问时间',
+    last_seen_time  DATETIME     NOT NULL COMMENT '最后访问时间',
+    merged_time     DATETIME     NULL COMMENT '合并时间',
+    PRIMARY KEY (tourist_id),
+    KEY idx_merged_user (merged_user_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '游客';
+
+CREATE TABLE IF NOT EXISTS tourist_like (
+    tourist_id   VARCHAR(64) NOT NULL COMMENT '游客ID',
+    object_type  TINYINT     NOT NULL COMMENT '点赞对象类型 1文章 2评论 3说说',
+    object_id    BIGINT      NOT NULL COMMENT '点赞对象ID',
+    created_time DATETIME    NOT NULL COMMENT '点赞时间',
+    PRIMARY KEY (tourist_id, object_type, object_id),
+    KEY idx_object (object_type, object_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '游客点赞';
+
+-- ----------------------------------------
+-- IP归属地
+-- ----------------------------------------
+ALTER TABLE comment
+    ADD COLUMN ip_address VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '评论人IP' AFTER comment_content,
+    ADD COLUMN ip_source  VARCHAR(128) NOT NULL DEFAULT '' COMMENT '评论人地址' AFTER ip_address;
+
+ALTER TABLE login_audit
+    ADD COLUMN ip_source VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'IP归属地' AFTER ip;
+
+-- ----------------------------------------
+-- 文章全文检索
+-- ----------------------------------------
+-- 仅 SEARCH_DRIVER=mysql 时使用；ngram 分词按 ngram_token_size（默认2）切分中文，
+-- 单个汉字的检索词需要把 ngram_token_size 调为1
+CREATE TABLE IF NOT EXISTS article_search (
+    article_id    VARCHAR(32)  NOT NULL COMMENT '文章ID',
+    title         VARCHAR(255) NOT NULL DEFAULT '' COMMENT '标题',
+    content       MEDIUMTEXT   NOT NULL COMMENT '去掉 Markdown 标记后的正文',
+    category_name VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '分类名',
+    tag_names     VARCHAR(512) NOT NULL DEFAULT '' COMMENT '标签名，换行分隔',
+    updated_time  DATETIME     NOT NULL COMMENT '索引时间',
+    PRIMARY KEY (article_id),
+    FULLTEXT KEY ft_title (title) WITH PARSER ngram,
+    FULLTEXT KEY ft_search (title, content, category_name, tag_names) WITH PARSER ngram
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章全文检索';
+
+-- ----------------------------------------
+-- 分类和标签管理
+-- ----------------------------------------
+-- slug 为地址中使用的别名，已有数据为 NULL，由计数校正任务按名称补齐；
+-- count 为公开文章数，文章增删改时在同一事务中重新计算
+ALTER TABLE category
+    ADD COLUMN slug         VARCHAR(64) NULL COMMENT '别名' AFTER name,
+    ADD COLUMN created_time DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    ADD COLUMN updated_time DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+    ADD UNIQUE KEY uk_slug (slug);
+
+ALTER TABLE tag
+    ADD COLUMN slug         VARCHAR(64) NULL COMMENT '别名' AFTER name,
+    ADD COLUMN created_time DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    ADD COLUMN updated_time DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+    ADD UNIQUE KEY uk_slug (slug);
+
+-- ----------------------------------------
+-- 多级分类
+-- ----------------------------------------
+-- 子分类和祖先分类通过递归 CTE 查询，需要 MySQL 8.0；
+-- 分类的 count 改为包含全部子孙分类的公开文章数
+ALTER TABLE category
+    ADD COLUMN parent_id BIGINT NOT NULL DEFAULT 0 COMMENT '上级分类ID，顶级分类为0' AFTER id,
+    ADD KEY idx_parent (parent_id);
+
+-- ----------------------------------------
+-- 上传记录按用户区分
+-- ----------------------------------------
+-- 不同用户上传相同内容时各自保留一条记录，存储对象仍按内容摘要共享
+ALTER TABLE upload_file
+    DROP INDEX uk_file_path,
+    ADD UNIQUE KEY uk_user_file_path (user_id, file_path),
+    ADD KEY idx_file_path (file_path);
@@ -0,0 +1,296 @@
+// Package feed 生成文章订阅源：RSS 2.0、Atom 和 JSON Feed，支持全站、单个分类和单个标签，
+// 并按 ETag/Last-Modified 处理条件请求
+package feed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/markdown"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+)
+
+// 订阅源格式
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+	FormatJSON = "json"
+)
+
+// 正文输出方式
+const (
+	ContentFull    = "full"    // 输出渲染后的全文
+	ContentExcerpt = "excerpt" // 只输出摘要
+)
+
+// maxItemCount 订阅源最多输出的文章数
+const maxItemCount = 100
+
+// language 订阅源的语言
+const language = "zh-CN"
+
+// Options 订阅源配置
+type Options struct {
+	SiteURL       string // 前台地址，用于生成文章、分类和标签的链接，为空时使用请求的地址
+	ItemCount     int    // 输出的文章数
+	Content       string // full 或 excerpt
+	ExcerptLength int    // 摘要的最大字符数
+}
+
+var options = Options{ItemCount: 20, Content: ContentFull, ExcerptLength: 200}
+
+// Init 根据配置设置订阅源选项
+func Init(cfg *config.Config) {
+	options = Options{
+		SiteURL:       strings.TrimRight(cfg.SiteURL, "/"),
+		ItemCount:     cfg.FeedItemCount,
+		Content:       cfg.FeedContent,
+		ExcerptLength: cfg.FeedExcerptLength,
+	}
+	if options.ItemCount <= 0 || options.ItemCount > maxItemCount {
+		options.ItemCount = 20
+	}
+	if options.Content != ContentExcerpt {
+		options.Content = ContentFull
+	}
+	if options.ExcerptLength <= 0 {
+		options.ExcerptLength = 200
+	}
+}
+
+// channel 订阅源的频道信息
+type channel struct {
+	Title       string
+	Description string
+	Link        string // 对应的前台页面
+	FeedURL     string // 订阅源自身的地址
+	Author      string
+	Icon        string
+	Updated     time.Time
+}
+
+// item 订阅源中的一篇文章
+type item struct {
+	ID         string
+	Title      string
+	Link       string
+	Summary    string // 纯文本摘要
+	Content    string // HTML 正文，全文或摘要
+	Image      string
+	ImageType  string
+	Categories []term
+	Published  time.Time
+	Updated    time.Time
+}
+
+// term 分类或标签
+type term struct {
+	Name   string
+	Scheme string // 分类或标签列表页的地址
+}
+
+// Handler 返回指定格式的订阅源处理器；路由中带 category_id 或 tag_id 变量（ID或别名）时只输出该分类或标签的文章
+func Handler(format string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		categoryID, tagID := vars["category_id"], vars["tag_id"]
+
+		site := baseURL(r)
+		website, version, err := siteconfig.Website()
+		if err != nil {
+			log.Printf("读取网站配置失败: %v", err)
+			website = siteconfig.DefaultWebsiteConfig()
+		}
+		info := website.WebsiteInfo
+		ch := channel{
+			Title:       info.WebsiteName,
+			Description: info.WebsiteIntro,
+			Link:        site,
+			FeedURL:     requestURL(r),
+			Author:      info.WebsiteAuthor,
+			Icon:        info.WebsiteAvatar,
+		}
+
+		switch {
+		case categoryID != "":
+			category, err := models.FindCategory(categoryID)
+			if err != nil {
+				http.Error(w, "服务器错误", http.StatusInternalServerError)
+				return
+			}
+			if category == nil {
+				http.NotFound(w, r)
+				return
+			}
+			categoryID = category.ID
+			ch.Title += " - 分类：" + category.Name
+			ch.Link = site + "/category/" + category.ID
+		case tagID != "":
+			tag, err := models.FindTag(tagID)
+			if err != nil {
+				http.Error(w, "服务器错误", http.StatusInternalServerError)
+				return
+			}
+			if tag == nil {
+				http.NotFound(w, r)
+				return
+			}
+			tagID = tag.ID
+			ch.Title += " - 标签：" + tag.Name
+			ch.Link = site + "/tag/" + tag.ID
+		}
+
+		articles, err := models.GetPublicArticles(categoryID, tagID, options.ItemCount)
+		if err != nil {
+			log.Printf("生成订阅源失败: %v", err)
+			http.Error(w, "服务器错误", http.StatusInternalServerError)
+			return
+		}
+
+		// ETag 覆盖影响输出的全部因素：格式、范围、网站配置版本、输出方式、订阅源标题，
+		// 以及每篇文章的修改时间、分类名和标签名；分类和标签改名不会更新文章的修改时间
+		h := sha256.New()
+		fmt.Fprintf(h, "%s|%s|%s|%d|%s|%d|%s|%q", format, categoryID, tagID, version, options.Content,
+			options.ExcerptLength, ch.FeedURL, ch.Title)
+		for _, article := range articles {
+			fmt.Fprintf(h, "|%s:%d:%q:%q", article.ID, article.UpdatedAt.Unix(), article.CategoryName, article.TagNames)
+			if article.UpdatedAt.After(ch.Updated) {
+				ch.Updated = article.UpdatedAt
+			}
+		}
+		etag := `W/"` + hex.EncodeToString(h.Sum(nil)[:12]) + `"`
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		if !ch.Updated.IsZero() {
+			w.Header().Set("Last-Modified", ch.Updated.UTC().Format(http.TimeFormat))
+		}
+		if notModified(r, etag, ch.Updated) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if ch.Updated.IsZero() {
+			ch.Updated = time.Now()
+		}
+
+		items := make([]*item, 0, len(articles))
+		for i := range articles {
+			items = append(items, newItem(&articles[i], site))
+		}
+
+		var (
+			body        []byte
+			contentType string
+		)
+		switch format {
+		case FormatAtom:
+			body, err = renderAtom(ch, items)
+			contentType = "application/atom+xml; charset=utf-8"
+		case FormatJSON:
+			body, err = renderJSON(ch, items)
+			contentType = "application/feed+json; charset=utf-8"
+		default:
+			body, err = renderRSS(ch, items)
+			contentType = "application/rss+xml; charset=utf-8"
+		}
+		if err != nil {
+			log.Printf("生成订阅源失败: %v", err)
+			http.Error(w, "服务器错误", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Write(body)
+	})
+}
+
+// newItem 把文章转换为订阅源条目
+func newItem(article *models.Article, site string) *item {
+	link := site + "/article/" + article.ID
+	summary := markdown.Excerpt(article.Content, options.ExcerptLength)
+
+	it := &item{
+		ID:        link,
+		Title:     article.Title,
+		Link:      link,
+		Summary:   summary,
+		Published: article.CreatedAt,
+		Updated:   article.UpdatedAt,
+	}
+
+	if options.Content == ContentFull {
+		rendered, err := markdown.Render(article.Content)
+		if err != nil {
+			log.Printf("渲染文章 %s 失败: %v", article.ID, err)
+			rendered = "<p>" + html.EscapeString(markdown.Strip(article.Content)) + "</p>"
+		}
+		it.Content = rendered
+	} else {
+		it.Content = "<p>" + html.EscapeString(summary) + `</p><p><a href="` + html.EscapeString(link) + `">阅读全文</a></p>`
+	}
+
+	if article.Cover != "" {
+		it.Image = article.Cover
+		it.ImageType = mime.TypeByExtension(strings.ToLower(path.Ext(strings.SplitN(article.Cover, "?", 2)[0])))
+		if !strings.HasPrefix(it.ImageType, "image/") {
+			it.ImageType = "image/jpeg"
+		}
+		// 开头展示封面，方便不支持 enclosure 的阅读器
+		it.Content = `<p><img src="` + html.EscapeString(article.Cover) + `" alt="` + html.EscapeString(article.Title) + `"></p>` + it.Content
+	}
+
+	if article.CategoryName != "" {
+		it.Categories = append(it.Categories, term{Name: article.CategoryName, Scheme: site + "/category"})
+	}
+	for _, name := range article.TagNames {
+		it.Categories = append(it.Categories, term{Name: name, Scheme: site + "/tag"})
+	}
+	return it
+}
+
+// notModified 判断条件请求是否命中：带 If-None-Match 时只比较 ETag，否则比较 If-Modified-Since
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP 日期只精确到秒
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// baseURL 返回前台地址，未配置时使用请求的协议和主机
+func baseURL(r *http.Request) string {
+	if options.SiteURL != "" {
+		return options.SiteURL
+	}
+	return netutil.RequestOrigin(r)
+}
+
+// requestURL 返回订阅源自身的地址
+func requestURL(r *http.Request) string {
+	return netutil.RequestOrigin(r) + r.URL.Path
+}
@@ -0,0 +1,264 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+// ---------- RSS 2.0 ----------
+
+type rssDocument struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Image         *rssImage `xml:"image,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Categories  []rssCategory `xml:"category"`
+	Description string        `xml:"description"`
+	Content     string        `xml:"content:encoded"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssCategory struct {
+	Domain string `xml:"domain,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// renderRSS 生成 RSS 2.0，正文放在 content:encoded 中，description 为纯文本摘要
+func renderRSS(ch channel, items []*item) ([]byte, error) {
+	doc := rssDocument{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         ch.Title,
+			Link:          ch.Link,
+			Description:   ch.Description,
+			SelfLink:      atomLink{Href: ch.FeedURL, Rel: "self", Type: "application/rss+xml"},
+			Language:      language,
+			LastBuildDate: ch.Updated.Format(time.RFC1123Z),
+			Items:         []rssItem{},
+		},
+	}
+	if ch.Icon != "" {
+		doc.Channel.Image = &rssImage{URL: ch.Icon, Title: ch.Title, Link: ch.Link}
+	}
+
+	for _, it := range items {
+		entry := rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: it.ID},
+			PubDate:     it.Published.Format(time.RFC1123Z),
+			Description: it.Summary,
+			Content:     it.Content,
+		}
+		for _, t := range it.Categories {
+			entry.Categories = append(entry.Categories, rssCategory{Domain: t.Scheme, Value: t.Name})
+		}
+		if it.Image != "" {
+			// 封面大小未知，按 RSS 规范的惯例填 0
+			entry.Enclosure = &rssEnclosure{URL: it.Image, Length: 0, Type: it.ImageType}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, entry)
+	}
+	return marshalXML(doc)
+}
+
+// ---------- Atom ----------
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	NS       string      `xml:"xmlns,attr"`
+	Lang     string      `xml:"xml:lang,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   *atomAuthor `xml:"author,omitempty"`
+	Icon     string      `xml:"icon,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomCategory struct {
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr,omitempty"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// renderAtom 生成 Atom 订阅源
+func renderAtom(ch channel, items []*item) ([]byte, error) {
+	feed := atomFeed{
+		NS:       "http://www.w3.org/2005/Atom",
+		Lang:     language,
+		ID:       ch.FeedURL,
+		Title:    ch.Title,
+		Subtitle: ch.Description,
+		Updated:  ch.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: ch.Link, Rel: "alternate", Type: "text/html"},
+			{Href: ch.FeedURL, Rel: "self", Type: "application/atom+xml"},
+		},
+		Icon:    ch.Icon,
+		Entries: []atomEntry{},
+	}
+	if ch.Author != "" {
+		feed.Author = &atomAuthor{Name: ch.Author}
+	}
+
+	for _, it := range items {
+		entry := atomEntry{
+			ID:        it.ID,
+			Title:     it.Title,
+			Links:     []atomLink{{Href: it.Link, Rel: "alternate", Type: "text/html"}},
+			Published: it.Published.Format(time.RFC3339),
+			Updated:   it.Updated.Format(time.RFC3339),
+			Summary:   it.Summary,
+			Content:   atomContent{Type: "html", Value: it.Content},
+		}
+		if it.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: it.Image, Rel: "enclosure", Type: it.ImageType})
+		}
+		for _, t := range it.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: t.Name, Scheme: t.Scheme})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return marshalXML(feed)
+}
+
+// marshalXML 生成带 XML 声明的文档
+func marshalXML(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ---------- JSON Feed 1.1 ----------
+
+type jsonFeed struct {
+	Version     string       `json:"version"`
+	Title       string       `json:"title"`
+	HomePageURL string       `json:"home_page_url"`
+	FeedURL     string       `json:"feed_url"`
+	Description string       `json:"description,omitempty"`
+	Icon        string       `json:"icon,omitempty"`
+	Authors     []jsonAuthor `json:"authors,omitempty"`
+	Language    string       `json:"language"`
+	Items       []jsonItem   `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentHTML   string   `json:"content_html"`
+	Summary       string   `json:"summary,omitempty"`
+	Image         string   `json:"image,omitempty"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// renderJSON 生成 JSON Feed 1.1
+func renderJSON(ch channel, items []*item) ([]byte, error) {
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       ch.Title,
+		HomePageURL: ch.Link,
+		FeedURL:     ch.FeedURL,
+		Description: ch.Description,
+		Icon:        ch.Icon,
+		Language:    language,
+		Items:       []jsonItem{},
+	}
+	if ch.Author != "" {
+		feed.Authors = []jsonAuthor{{Name: ch.Author}}
+	}
+
+	for _, it := range items {
+		entry := jsonItem{
+			ID:            it.ID,
+			URL:           it.Link,
+			Title:         it.Title,
+			ContentHTML:   it.Content,
+			Summary:       it.Summary,
+			Image:         it.Image,
+			DatePublished: it.Published.Format(time.RFC3339),
+			DateModified:  it.Updated.Format(time.RFC3339),
+		}
+		for _, t := range it.Categories {
+			entry.Tags = append(entry.Tags, t.Name)
+		}
+		feed.Items = append(feed.Items, entry)
+	}
+	return json.MarshalIndent(feed, "", "  ")
+}
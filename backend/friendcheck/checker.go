@@ -0,0 +1,239 @@
+// Package friendcheck 友链健康检测：后台定期抓取已通过审核的友链，
+// 记录是否可以访问、对方页面是否有指向本站的链接，连续失败达到阈值后标记为失效
+package friendcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// maxBodySize 读取友链页面的最大字节数，超出部分不参与反链检测
+const maxBodySize = 2 << 20
+
+// maxRedirects 最多跟随的重定向次数
+const maxRedirects = 5
+
+// userAgent 检测请求使用的 User-Agent
+const userAgent = "Mozilla/5.0 (compatible; FriendLinkChecker/1.0)"
+
+// hrefPattern 匹配页面中的 href 属性值
+var hrefPattern = regexp.MustCompile(`(?i)href\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+// Checker 友链检测器
+type Checker struct {
+	client        *http.Client
+	siteHost      string // 本站域名，为空时不检测反链
+	interval      time.Duration
+	concurrency   int
+	deadThreshold int
+
+	// loadFriends 和 saveResult 默认读写数据库，测试时可以替换
+	loadFriends func() ([]*models.Friend, error)
+	saveResult  func(id int64, result models.FriendCheckResult, deadThreshold int) error
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var defaultChecker *Checker
+
+// Init 根据配置创建全局检测器；检测间隔大于0时启动后台定期检测
+func Init(cfg *config.Config) {
+	defaultChecker = NewChecker(cfg.SiteURL, cfg.FriendCheckTimeout, cfg.FriendCheckConcurrency, cfg.FriendDeadThreshold)
+	if cfg.FriendCheckInterval > 0 {
+		defaultChecker.Start(cfg.FriendCheckInterval)
+	}
+}
+
+// Default 返回全局检测器
+func Default() *Checker {
+	return defaultChecker
+}
+
+// Stop 停止全局检测器的后台检测
+func Stop() {
+	if defaultChecker != nil {
+		defaultChecker.Stop()
+	}
+}
+
+// NewChecker 创建检测器，siteURL 为本站地址，timeout 为单个友链的请求超时，
+// concurrency 为同时检测的友链数，deadThreshold 为标记失效前允许的连续失败次数
+func NewChecker(siteURL string, timeout time.Duration, concurrency, deadThreshold int) *Checker {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if deadThreshold <= 0 {
+		deadThreshold = 1
+	}
+	return &Checker{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return errors.New("重定向次数过多")
+				}
+				return nil
+			},
+		},
+		siteHost:      siteHost(siteURL),
+		concurrency:   concurrency,
+		deadThreshold: deadThreshold,
+		loadFriends:   models.GetFriendsToCheck,
+		saveResult:    models.SaveFriendCheck,
+	}
+}
+
+// siteHost 取出本站地址的域名，去掉 www. 前缀并转为小写
+func siteHost(siteURL string) string {
+	u, err := url.Parse(strings.TrimSpace(siteURL))
+	if err != nil {
+		return ""
+	}
+	return normalizeHost(u.Hostname())
+}
+
+// normalizeHost 去掉 www. 前缀并转为小写，便于比较
+func normalizeHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+// Start 启动后台协程，立即检测一次，之后每隔 interval 检测一次
+func (c *Checker) Start(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := c.RunOnce(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("友链检测失败: %v", err)
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台协程，正在进行的请求会被取消
+func (c *Checker) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+}
+
+// RunOnce 检测全部已通过审核的友链并保存结果
+func (c *Checker) RunOnce(ctx context.Context) error {
+	friends, err := c.loadFriends()
+	if err != nil {
+		return err
+	}
+
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for _, friend := range friends {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+		wg.Add(1)
+		go func(friend *models.Friend) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := c.CheckFriend(ctx, friend); err != nil {
+				log.Printf("保存友链 %d 检测结果失败: %v", friend.ID, err)
+			}
+		}(friend)
+	}
+	wg.Wait()
+	return nil
+}
+
+// CheckFriend 检测单个友链并保存结果
+func (c *Checker) CheckFriend(ctx context.Context, friend *models.Friend) error {
+	result := c.Check(ctx, friend.LinkAddress)
+	if ctx.Err() != nil {
+		// 停止时被取消的请求不计入失败次数
+		return nil
+	}
+	return c.saveResult(friend.ID, result, c.deadThreshold)
+}
+
+// Check 请求友链地址，返回是否可以访问以及页面中是否有指向本站的链接
+func (c *Checker) Check(ctx context.Context, address string) models.FriendCheckResult {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return models.FriendCheckResult{Error: truncate(fmt.Sprintf("无效的友链地址: %v", err))}
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return models.FriendCheckResult{Error: truncate(err.Error())}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return models.FriendCheckResult{Error: fmt.Sprintf("HTTP状态码 %d", resp.StatusCode)}
+	}
+
+	result := models.FriendCheckResult{Reachable: true}
+	if c.siteHost == "" {
+		return result
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		result.Error = truncate("读取页面失败: " + err.Error())
+	}
+	result.HasBacklink = c.hasBacklink(resp.Request.URL, body)
+	return result
+}
+
+// hasBacklink 判断页面中是否有指向本站的链接，相对地址按页面地址解析
+func (c *Checker) hasBacklink(base *url.URL, body []byte) bool {
+	for _, match := range hrefPattern.FindAllSubmatch(body, -1) {
+		href := string(match[1]) + string(match[2]) + string(match[3])
+		u, err := base.Parse(strings.TrimSpace(href))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		if normalizeHost(u.Hostname()) == c.siteHost {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate 截断错误信息，避免超出数据库字段长度
+func truncate(msg string) string {
+	runes := []rune(msg)
+	if len(runes) > 200 {
+		return string(runes[:200])
+	}
+	return msg
+}
@@ -0,0 +1,198 @@
+package friendcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// htmlServer 启动返回固定页面的测试服务器
+func htmlServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCheckBacklink(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		backlink bool
+	}{
+		{"双引号绝对地址", `<a href="https://example.com/">博客</a>`, true},
+		{"www 前缀和大小写", `<a HREF='https://WWW.Example.com/post/1'>博客</a>`, true},
+		{"不带引号", `<a href=http://example.com>博客</a>`, true},
+		{"协议相对地址", `<a href="//example.com/about">博客</a>`, true},
+		{"其他站点", `<a href="https://example.org/">别人</a><a href="https://notexample.com/">别人</a>`, false},
+		{"站内相对地址", `<a href="/friends">友链</a>`, false},
+		{"非 http 协议", `<a href="mailto:me@example.com">邮件</a>`, false},
+		{"没有链接", `<p>example.com</p>`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := htmlServer(t, http.StatusOK, tt.body)
+			c := NewChecker("https://example.com", time.Second, 1, 1)
+
+			result := c.Check(context.Background(), srv.URL)
+			if !result.Reachable {
+				t.Fatalf("Reachable = false, error = %q", result.Error)
+			}
+			if result.HasBacklink != tt.backlink {
+				t.Errorf("HasBacklink = %v, want %v", result.HasBacklink, tt.backlink)
+			}
+		})
+	}
+}
+
+func TestCheckFollowsRedirect(t *testing.T) {
+	target := htmlServer(t, http.StatusOK, `<a href="https://example.com">博客</a>`)
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer srv.Close()
+
+	result := NewChecker("https://example.com", time.Second, 1, 1).Check(context.Background(), srv.URL)
+	if !result.Reachable || !result.HasBacklink {
+		t.Errorf("result = %+v, want reachable with backlink", result)
+	}
+}
+
+func TestCheckWithoutSiteURL(t *testing.T) {
+	srv := htmlServer(t, http.StatusOK, `<a href="https://example.com">博客</a>`)
+
+	result := NewChecker("", time.Second, 1, 1).Check(context.Background(), srv.URL)
+	if !result.Reachable || result.HasBacklink {
+		t.Errorf("result = %+v, want reachable without backlink check", result)
+	}
+}
+
+func TestCheckDeadLink(t *testing.T) {
+	c := NewChecker("https://example.com", time.Second, 1, 1)
+
+	t.Run("错误状态码", func(t *testing.T) {
+		srv := htmlServer(t, http.StatusInternalServerError, `<a href="https://example.com">博客</a>`)
+		result := c.Check(context.Background(), srv.URL)
+		if result.Reachable || result.HasBacklink {
+			t.Errorf("result = %+v, want unreachable", result)
+		}
+		if !strings.Contains(result.Error, "500") {
+			t.Errorf("Error = %q, want status code", result.Error)
+		}
+	})
+
+	t.Run("连接失败", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		address := srv.URL
+		srv.Close()
+
+		result := c.Check(context.Background(), address)
+		if result.Reachable || result.Error == "" {
+			t.Errorf("result = %+v, want unreachable with error", result)
+		}
+	})
+
+	t.Run("无效地址", func(t *testing.T) {
+		result := c.Check(context.Background(), "://bad")
+		if result.Reachable || !strings.Contains(result.Error, "无效的友链地址") {
+			t.Errorf("result = %+v, want invalid address error", result)
+		}
+	})
+}
+
+func TestCheckTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	start := time.Now()
+	result := NewChecker("https://example.com", 100*time.Millisecond, 1, 1).Check(context.Background(), srv.URL)
+	if result.Reachable || result.Error == "" {
+		t.Errorf("result = %+v, want timeout error", result)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Check took %v, want it to stop at the timeout", elapsed)
+	}
+}
+
+func TestRunOnceConcurrencyLimit(t *testing.T) {
+	const concurrency = 2
+	var inFlight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	var friends []*models.Friend
+	for i := 1; i <= 8; i++ {
+		friends = append(friends, &models.Friend{ID: int64(i), LinkAddress: srv.URL})
+	}
+
+	c := NewChecker("", time.Second, concurrency, 3)
+	c.loadFriends = func() ([]*models.Friend, error) { return friends, nil }
+	var mu sync.Mutex
+	saved := make(map[int64]models.FriendCheckResult)
+	c.saveResult = func(id int64, result models.FriendCheckResult, deadThreshold int) error {
+		if deadThreshold != 3 {
+			t.Errorf("deadThreshold = %d, want 3", deadThreshold)
+		}
+		mu.Lock()
+		saved[id] = result
+		mu.Unlock()
+		return nil
+	}
+
+	if err := c.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if got := atomic.LoadInt32(&peak); got > concurrency {
+		t.Errorf("peak concurrent requests = %d, want at most %d", got, concurrency)
+	}
+	if len(saved) != len(friends) {
+		t.Fatalf("saved %d results, want %d", len(saved), len(friends))
+	}
+	for id, result := range saved {
+		if !result.Reachable {
+			t.Errorf("friend %d: result = %+v, want reachable", id, result)
+		}
+	}
+}
+
+func TestCheckFriendCancelledNotSaved(t *testing.T) {
+	srv := htmlServer(t, http.StatusOK, "")
+	c := NewChecker("", time.Second, 1, 1)
+	c.saveResult = func(int64, models.FriendCheckResult, int) error {
+		t.Error("saveResult called for a cancelled check")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.CheckFriend(ctx, &models.Friend{ID: 1, LinkAddress: srv.URL}); err != nil {
+		t.Errorf("CheckFriend: %v", err)
+	}
+}
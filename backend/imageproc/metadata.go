@@ -0,0 +1,337 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+)
+
+// errMalformed 图片结构无法解析
+var errMalformed = errors.New("图片格式错误")
+
+// Sanitize 去掉图片中的 EXIF/GPS 等元数据。JPEG 需要旋转时解码、摆正后重新编码，
+// 否则只删除元数据段，不损失画质；PNG、WebP、GIF 只删除元数据块；其余类型(如 BMP)没有元数据，原样返回
+func Sanitize(data []byte, contentType string) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		orientation := jpegOrientation(data)
+		if orientation <= 1 || orientation > 8 {
+			return stripJPEG(data)
+		}
+		// 解码前先检查尺寸，避免声明了超大尺寸的小文件在上传请求中耗尽内存
+		if err := checkPixels(data); err != nil {
+			return nil, err
+		}
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, orient(img, orientation), &jpeg.Options{Quality: 92}); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "image/png":
+		return stripPNG(data)
+	case "image/webp":
+		return stripWebP(data)
+	case "image/gif":
+		return stripGIF(data)
+	default:
+		return data, nil
+	}
+}
+
+// jpegSegments 遍历 JPEG 开头到扫描数据之前的各个段，fn 返回 false 时停止；
+// 返回扫描数据的起始位置
+func jpegSegments(data []byte, fn func(marker byte, segment []byte) bool) (int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, errMalformed
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 0, errMalformed
+		}
+		marker := data[pos+1]
+		// 填充字节
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 0, errMalformed
+		}
+		if !fn(marker, data[pos:pos+2+length]) {
+			return pos, nil
+		}
+		// SOS 之后是压缩数据，不再按段解析
+		if marker == 0xDA {
+			return pos, nil
+		}
+		pos += 2 + length
+	}
+	return 0, errMalformed
+}
+
+// jpegOrientation 从 APP1 Exif 段读取方向标签(0x0112)，读取失败时返回 0
+func jpegOrientation(data []byte) int {
+	orientation := 0
+	jpegSegments(data, func(marker byte, segment []byte) bool {
+		if marker == 0xDA {
+			return false
+		}
+		if marker != 0xE1 || len(segment) < 10 || string(segment[4:10]) != "Exif\x00\x00" {
+			return true
+		}
+		orientation = tiffOrientation(segment[10:])
+		return false
+	})
+	return orientation
+}
+
+// tiffOrientation 在 TIFF 结构的 IFD0 中查找方向标签
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		// 方向标签类型为 SHORT，值直接存放在值字段的前两个字节
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// stripJPEG 删除 APP1(Exif/XMP)、APP13(IPTC) 和注释段，保留 JFIF、ICC 等其余段
+func stripJPEG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	sos, err := jpegSegments(data, func(marker byte, segment []byte) bool {
+		if marker == 0xDA {
+			return false
+		}
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out = append(out, segment...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(out, data[sos:]...), nil
+}
+
+// pngSignature PNG 文件头
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// strippedPNGChunks 需要删除的 PNG 元数据块
+var strippedPNGChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNG 删除 PNG 中的 EXIF、文本和时间块
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	pos := len(pngSignature)
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+		chunkType := string(data[pos+4 : pos+8])
+		if !strippedPNGChunks[chunkType] {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+		if chunkType == "IEND" {
+			return out, nil
+		}
+	}
+	return nil, errMalformed
+}
+
+// VP8X 扩展头中表示含有 EXIF、XMP 块的标志位
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripWebP 删除 WebP 中的 EXIF 和 XMP 块，并清除 VP8X 头中对应的标志位
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errMalformed
+	}
+	riffEnd := 8 + int(binary.LittleEndian.Uint32(data[4:]))
+	if riffEnd > len(data) {
+		return nil, errMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:12]...)
+	pos := 12
+	for pos < riffEnd {
+		if pos+8 > riffEnd {
+			return nil, errMalformed
+		}
+		fourCC := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		// 块数据长度为奇数时后面有一个填充字节
+		end := pos + 8 + size + size&1
+		if size < 0 || end > riffEnd {
+			return nil, errMalformed
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[pos:end]...)
+			if size > 0 {
+				out[start+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// gifLoopExtensions 只记录循环次数的应用扩展，删除后动图只播放一次，需要保留
+var gifLoopExtensions = map[string]bool{
+	"NETSCAPE2.0": true,
+	"ANIMEXTS1.0": true,
+}
+
+// stripGIF 删除 GIF 中的注释扩展和应用扩展(XMP、ICC 等)，保留循环次数扩展
+func stripGIF(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, errMalformed
+	}
+	// 文件头和逻辑屏幕描述符，之后可能跟着全局颜色表
+	pos := 13
+	if packed := data[10]; packed&0x80 != 0 {
+		pos += 3 << (packed&0x07 + 1)
+	}
+	if pos > len(data) {
+		return nil, errMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:pos]...)
+
+	for pos < len(data) {
+		start := pos
+		switch data[pos] {
+		case 0x3B: // 文件结束
+			return append(out, 0x3B), nil
+		case 0x2C: // 图像描述符，之后是局部颜色表和图像数据
+			if pos+10 > len(data) {
+				return nil, errMalformed
+			}
+			packed := data[pos+9]
+			pos += 10
+			if packed&0x80 != 0 {
+				pos += 3 << (packed&0x07 + 1)
+			}
+			// LZW 最小码长
+			pos++
+			end, err := gifSubBlocks(data, pos)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data[start:end]...)
+			pos = end
+		case 0x21: // 扩展块
+			if pos+2 > len(data) {
+				return nil, errMalformed
+			}
+			label := data[pos+1]
+			end, err := gifSubBlocks(data, pos+2)
+			if err != nil {
+				return nil, err
+			}
+			keep := true
+			switch label {
+			case 0xFE: // 注释扩展
+				keep = false
+			case 0xFF: // 应用扩展，第一个子块是11字节的应用标识
+				keep = pos+14 <= len(data) && data[pos+2] == 11 && gifLoopExtensions[string(data[pos+3:pos+14])]
+			}
+			if keep {
+				out = append(out, data[start:end]...)
+			}
+			pos = end
+		default:
+			return nil, errMalformed
+		}
+	}
+	return nil, errMalformed
+}
+
+// gifSubBlocks 跳过从 pos 开始的数据子块序列，返回结束标记之后的位置
+func gifSubBlocks(data []byte, pos int) (int, error) {
+	for {
+		if pos >= len(data) {
+			return 0, errMalformed
+		}
+		size := int(data[pos])
+		pos++
+		if size == 0 {
+			return pos, nil
+		}
+		pos += size
+	}
+}
+
+// decodeConfig 读取图片尺寸
+func decodeConfig(data []byte) (image.Config, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	return cfg, err
+}
+
+// checkPixels 读取图片尺寸，像素数超过 maxPixels 时返回错误，解码整张图片前调用
+func checkPixels(data []byte) error {
+	cfg, err := decodeConfig(data)
+	if err != nil {
+		return fmt.Errorf("读取图片尺寸失败: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return fmt.Errorf("图片尺寸过大: %dx%d", cfg.Width, cfg.Height)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+// riffChunk 按 RIFF 格式编码一个块，奇数长度补一个填充字节
+func riffChunk(fourCC string, payload []byte) []byte {
+	chunk := append([]byte(fourCC), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(payload)))
+	chunk = append(chunk, payload...)
+	if len(payload)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func webpFile(chunks ...[]byte) []byte {
+	body := []byte("WEBP")
+	for _, c := range chunks {
+		body = append(body, c...)
+	}
+	out := append([]byte("RIFF"), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(body)))
+	return append(out, body...)
+}
+
+func TestStripWebP(t *testing.T) {
+	vp8x := make([]byte, 10)
+	vp8x[0] = webpFlagEXIF | webpFlagXMP | 0x10 // 同时带透明通道标志
+	bitstream := riffChunk("VP8L", []byte{0x2F, 1, 2, 3, 4})
+	data := webpFile(
+		riffChunk("VP8X", vp8x),
+		bitstream,
+		riffChunk("EXIF", []byte("Exif\x00\x00GPS 31.2,121.5")),
+		riffChunk("XMP ", []byte("<x:xmpmeta/>")),
+	)
+
+	out, err := stripWebP(data)
+	if err != nil {
+		t.Fatalf("stripWebP: %v", err)
+	}
+	want := webpFile(riffChunk("VP8X", append([]byte{0x10}, vp8x[1:]...)), bitstream)
+	if !bytes.Equal(out, want) {
+		t.Errorf("stripWebP = %q, want %q", out, want)
+	}
+	if got, err := Sanitize(data, "image/webp"); err != nil || !bytes.Equal(got, want) {
+		t.Errorf("Sanitize(image/webp) = %q, %v", got, err)
+	}
+
+	for _, bad := range [][]byte{
+		[]byte("RIFF\x00\x00\x00\x00WEBQ"),
+		data[:len(data)-3],
+	} {
+		if _, err := stripWebP(bad); err == nil {
+			t.Errorf("stripWebP(%q) returned no error", bad)
+		}
+	}
+}
+
+// gifExtension 编码一个只有一个数据子块的扩展块
+func gifExtension(label byte, blocks ...[]byte) []byte {
+	ext := []byte{0x21, label}
+	for _, b := range blocks {
+		ext = append(ext, byte(len(b)))
+		ext = append(ext, b...)
+	}
+	return append(ext, 0)
+}
+
+func TestStripGIF(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	frame := image.NewPaletted(image.Rect(0, 0, 4, 4), palette)
+	frame.SetColorIndex(1, 1, 1)
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}}); err != nil {
+		t.Fatalf("EncodeAll: %v", err)
+	}
+	clean := buf.Bytes()
+
+	// 在第一个扩展块之前插入注释扩展和 XMP 应用扩展
+	at := bytes.IndexByte(clean[13+3*2:], 0x21) + 13 + 3*2
+	comment := gifExtension(0xFE, []byte("GPS 31.2,121.5"))
+	xmp := gifExtension(0xFF, []byte("XMP DataXMP"), []byte("<x:xmpmeta/>"))
+	data := append(append(append(append([]byte{}, clean[:at]...), comment...), xmp...), clean[at:]...)
+
+	out, err := stripGIF(data)
+	if err != nil {
+		t.Fatalf("stripGIF: %v", err)
+	}
+	if !bytes.Equal(out, clean) {
+		t.Errorf("stripGIF did not restore the original file\n got %q\nwant %q", out, clean)
+	}
+	decoded, err := gif.DecodeAll(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("DecodeAll: %v", err)
+	}
+	if len(decoded.Image) != 2 || decoded.LoopCount != 0 {
+		t.Errorf("decoded %d frames with loop count %d, want 2 frames looping forever", len(decoded.Image), decoded.LoopCount)
+	}
+
+	if _, err := stripGIF(data[:len(data)-1]); err == nil {
+		t.Error("stripGIF without a trailer returned no error")
+	}
+}
@@ -0,0 +1,208 @@
+// Package imageproc 图片处理：上传时去掉 EXIF/GPS 元数据并按 EXIF 方向摆正，
+// 由后台工作池异步生成缩略图(thumb)和中图(medium)。全部使用标准库实现，
+// 标准库没有 WebP 编码器，变体按是否透明输出 PNG 或 JPEG；WebP、BMP 原图不生成变体
+package imageproc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // 注册 GIF 解码器
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/storage"
+)
+
+// maxPixels 允许解码的最大像素数，防止小文件解码出超大图片耗尽内存
+const maxPixels = 50_000_000
+
+// maxSourceSize 读取原图的最大字节数
+const maxSourceSize = 64 << 20
+
+// Variant 图片变体
+type Variant struct {
+	Name    string // 变体名，同时是对象键的后缀
+	MaxSize int    // 最长边像素
+}
+
+// Pool 生成图片变体的工作池
+type Pool struct {
+	store    storage.Storage
+	variants []Variant
+	quality  int
+	jobs     chan string
+	wg       sync.WaitGroup
+}
+
+var defaultPool *Pool
+
+// Init 根据配置启动全局工作池
+func Init(cfg *config.Config, store storage.Storage) {
+	defaultPool = NewPool(store, []Variant{
+		{Name: "thumb", MaxSize: cfg.ImageThumbSize},
+		{Name: "medium", MaxSize: cfg.ImageMediumSize},
+	}, cfg.ImageQuality, cfg.ImageWorkers, cfg.ImageQueueSize)
+}
+
+// Stop 停止全局工作池，等待队列中的任务处理完
+func Stop() {
+	if defaultPool != nil {
+		defaultPool.Stop()
+	}
+}
+
+// Enqueue 把图片加入全局工作池的处理队列
+func Enqueue(storageKey, contentType string) {
+	if defaultPool != nil {
+		defaultPool.Enqueue(storageKey, contentType)
+	}
+}
+
+// Supported 判断该类型的图片能否解码生成变体
+func Supported(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// NewPool 创建并启动工作池
+func NewPool(store storage.Storage, variants []Variant, quality, workers, queueSize int) *Pool {
+	p := &Pool{
+		store:    store,
+		variants: variants,
+		quality:  quality,
+		jobs:     make(chan string, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.run()
+	}
+	return p
+}
+
+// Enqueue 加入处理队列，队列已满时放弃，前端会回退到原图
+func (p *Pool) Enqueue(storageKey, contentType string) {
+	if !Supported(contentType) {
+		return
+	}
+	select {
+	case p.jobs <- storageKey:
+	default:
+		log.Printf("图片处理队列已满，跳过生成变体: %s", storageKey)
+	}
+}
+
+// Stop 关闭队列并等待工作协程退出
+func (p *Pool) Stop() {
+	close(p.jobs)
+	p.wg.Wait()
+}
+
+func (p *Pool) run() {
+	defer p.wg.Done()
+	for key := range p.jobs {
+		if err := p.process(key); err != nil {
+			log.Printf("生成图片变体失败 %s: %v", key, err)
+		}
+	}
+}
+
+// VariantKeys 返回全局工作池可能为一张图片生成的全部变体对象键，删除原图时一并删除
+func VariantKeys(storageKey string) []string {
+	if defaultPool == nil {
+		return nil
+	}
+	return defaultPool.VariantKeys(storageKey)
+}
+
+// VariantKeys 返回可能生成的全部变体对象键，变体按原图是否透明输出 JPEG 或 PNG，两种扩展名都列出
+func (p *Pool) VariantKeys(storageKey string) []string {
+	keys := make([]string, 0, len(p.variants)*2)
+	for _, variant := range p.variants {
+		for _, ext := range []string{".jpg", ".png"} {
+			keys = append(keys, VariantKey(storageKey, variant.Name, ext))
+		}
+	}
+	return keys
+}
+
+// VariantKey 返回变体的对象键，如 ab/abcd.png 的缩略图为 ab/abcd_thumb.jpg
+func VariantKey(storageKey, name, ext string) string {
+	base := storageKey
+	if i := strings.LastIndex(base, "."); i > strings.LastIndex(base, "/") {
+		base = base[:i]
+	}
+	return base + "_" + name + ext
+}
+
+// process 生成一张图片的全部变体并更新上传记录
+func (p *Pool) process(storageKey string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	rc, err := p.store.Get(ctx, storageKey)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(io.LimitReader(rc, maxSourceSize))
+	rc.Close()
+	if err != nil {
+		return fmt.Errorf("读取原图失败: %w", err)
+	}
+
+	if err := checkPixels(data); err != nil {
+		return err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("解码图片失败: %w", err)
+	}
+	src := toNRGBA(img)
+
+	// 不透明的图片输出 JPEG，体积更小；有透明通道时输出 PNG
+	ext, contentType := ".jpg", "image/jpeg"
+	if !opaque(src) {
+		ext, contentType = ".png", "image/png"
+	}
+
+	urls := make(map[string]string, len(p.variants))
+	for _, variant := range p.variants {
+		key := VariantKey(storageKey, variant.Name, ext)
+		urls[variant.Name] = p.store.URL(key)
+
+		exists, err := p.store.Exists(ctx, key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		w, h := fit(src.Rect.Dx(), src.Rect.Dy(), variant.MaxSize)
+		var buf bytes.Buffer
+		if contentType == "image/png" {
+			err = png.Encode(&buf, resize(src, w, h))
+		} else {
+			err = jpeg.Encode(&buf, resize(src, w, h), &jpeg.Options{Quality: p.quality})
+		}
+		if err != nil {
+			return fmt.Errorf("编码%s变体失败: %w", variant.Name, err)
+		}
+		if err := p.store.Put(ctx, key, bytes.NewReader(buf.Bytes()), int64(buf.Len()), contentType); err != nil {
+			return err
+		}
+	}
+
+	return models.UpdateUploadFileVariants(storageKey, urls["thumb"], urls["medium"])
+}
@@ -0,0 +1,150 @@
+package imageproc
+
+import (
+	"image"
+	"image/draw"
+)
+
+// toNRGBA 把任意图片转换为 NRGBA，方便逐像素处理
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok && n.Rect.Min == (image.Point{}) {
+		return n
+	}
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Rect, img, b.Min, draw.Src)
+	return dst
+}
+
+// orient 按 EXIF 方向(1-8)把图片摆正
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	src := toNRGBA(img)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+
+	// 5-8 需要交换宽高
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平翻转
+				dx, dy = w-1-x, y
+			case 3: // 旋转180度
+				dx, dy = w-1-x, h-1-y
+			case 4: // 垂直翻转
+				dx, dy = x, h-1-y
+			case 5: // 沿左上-右下对角线翻转
+				dx, dy = y, x
+			case 6: // 顺时针旋转90度
+				dx, dy = h-1-y, x
+			case 7: // 沿右上-左下对角线翻转
+				dx, dy = h-1-y, w-1-x
+			case 8: // 逆时针旋转90度
+				dx, dy = y, w-1-x
+			}
+			si := y*src.Stride + x*4
+			di := dy*dst.Stride + dx*4
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+// fit 计算在 maxSize x maxSize 范围内保持宽高比的尺寸，不放大
+func fit(w, h, maxSize int) (int, int) {
+	if w <= maxSize && h <= maxSize {
+		return w, h
+	}
+	if w >= h {
+		return maxSize, max(1, h*maxSize/w)
+	}
+	return max(1, w*maxSize/h), maxSize
+}
+
+// resize 使用区域平均缩小图片，每个目标像素取对应源区域内像素的加权平均，
+// 缩小时比双线性插值更少出现锯齿。颜色按透明度预乘后再平均，避免透明像素的颜色渗到边缘
+func resize(img image.Image, dw, dh int) *image.NRGBA {
+	src := toNRGBA(img)
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	if sw == dw && sh == dh {
+		copy(dst.Pix, src.Pix)
+		return dst
+	}
+
+	xScale := float64(sw) / float64(dw)
+	yScale := float64(sh) / float64(dh)
+
+	for dy := 0; dy < dh; dy++ {
+		sy0 := float64(dy) * yScale
+		sy1 := sy0 + yScale
+		for dx := 0; dx < dw; dx++ {
+			sx0 := float64(dx) * xScale
+			sx1 := sx0 + xScale
+
+			var r, g, b, a, total float64
+			for sy := int(sy0); sy < sh && float64(sy) < sy1; sy++ {
+				wy := overlap(sy0, sy1, sy)
+				for sx := int(sx0); sx < sw && float64(sx) < sx1; sx++ {
+					weight := wy * overlap(sx0, sx1, sx)
+					i := sy*src.Stride + sx*4
+					alpha := float64(src.Pix[i+3])
+					r += float64(src.Pix[i]) * alpha * weight
+					g += float64(src.Pix[i+1]) * alpha * weight
+					b += float64(src.Pix[i+2]) * alpha * weight
+					a += alpha * weight
+					total += weight
+				}
+			}
+
+			i := dy*dst.Stride + dx*4
+			if a > 0 {
+				dst.Pix[i] = clamp(r / a)
+				dst.Pix[i+1] = clamp(g / a)
+				dst.Pix[i+2] = clamp(b / a)
+			}
+			if total > 0 {
+				dst.Pix[i+3] = clamp(a / total)
+			}
+		}
+	}
+	return dst
+}
+
+// overlap 返回像素 [p, p+1) 与区间 [lo, hi) 重叠的长度
+func overlap(lo, hi float64, p int) float64 {
+	start := max(lo, float64(p))
+	end := min(hi, float64(p+1))
+	if end <= start {
+		return 0
+	}
+	return end - start
+}
+
+func clamp(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 255 {
+		return 255
+	}
+	return uint8(v + 0.5)
+}
+
+// opaque 判断图片是否完全不透明
+func opaque(img *image.NRGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0xFF {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+// Package ipgeo 离线解析IP归属地：从本地 ip2region xdb 文件查询，结果在进程内缓存
+package ipgeo
+
+import (
+	"log"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// LocalSource 内网地址的归属地描述
+const LocalSource = "内网IP"
+
+// Database IP数据库，返回 国家|区域|省份|城市|ISP 格式的区域信息
+type Database interface {
+	Lookup(ip net.IP) (string, error)
+}
+
+// Resolver IP归属地解析，没有配置数据库时只识别内网地址
+type Resolver struct {
+	db        Database
+	cacheSize int
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+var defaultResolver = New(nil, 0)
+
+// Init 根据配置加载IP数据库，未配置数据库文件时只识别内网地址
+func Init(cfg *config.Config) error {
+	if cfg.IPGeoDBPath == "" {
+		defaultResolver = New(nil, 0)
+		return nil
+	}
+	searcher, err := LoadXdb(cfg.IPGeoDBPath)
+	if err != nil {
+		return err
+	}
+	defaultResolver = New(searcher, cfg.IPGeoCacheSize)
+	log.Printf("已加载IP数据库: %s", cfg.IPGeoDBPath)
+	return nil
+}
+
+// Default 返回全局IP归属地解析
+func Default() *Resolver {
+	return defaultResolver
+}
+
+// Lookup 使用全局解析查询IP归属地
+func Lookup(addr string) string {
+	return defaultResolver.Lookup(addr)
+}
+
+// New 创建IP归属地解析，cacheSize 为缓存的最大条数，小于等于0时不缓存
+func New(db Database, cacheSize int) *Resolver {
+	return &Resolver{
+		db:        db,
+		cacheSize: cacheSize,
+		cache:     make(map[string]string),
+	}
+}
+
+// Lookup 返回IP的归属地描述，如 "广东省 深圳市"、"美国"；内网地址返回 "内网IP"，无法解析时返回空字符串
+func (r *Resolver) Lookup(addr string) string {
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return ""
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+		return LocalSource
+	}
+	if r.db == nil {
+		return ""
+	}
+
+	key := ip.String()
+	if r.cacheSize > 0 {
+		r.mu.Lock()
+		source, ok := r.cache[key]
+		r.mu.Unlock()
+		if ok {
+			return source
+		}
+	}
+
+	region, err := r.db.Lookup(ip)
+	if err != nil {
+		log.Printf("查询IP %s 归属地失败: %v", key, err)
+		return ""
+	}
+	source := FormatRegion(region)
+
+	if r.cacheSize > 0 {
+		r.mu.Lock()
+		// 缓存满时随机淘汰一条，map 的遍历顺序本身是随机的
+		if len(r.cache) >= r.cacheSize {
+			for k := range r.cache {
+				delete(r.cache, k)
+				break
+			}
+		}
+		r.cache[key] = source
+		r.mu.Unlock()
+	}
+	return source
+}
+
+// FormatRegion 把 国家|区域|省份|城市|ISP 格式的区域信息转换为展示用的归属地：
+// 国内地址只展示省份和城市，国外地址展示国家和省份城市，省略未知字段和重复字段
+func FormatRegion(region string) string {
+	fields := strings.Split(region, "|")
+	if len(fields) < 4 {
+		return ""
+	}
+	country, province, city := fields[0], fields[2], fields[3]
+
+	var parts []string
+	for _, part := range []string{country, province, city} {
+		if part == "" || part == "0" {
+			continue
+		}
+		if len(parts) > 0 && parts[len(parts)-1] == part {
+			continue
+		}
+		parts = append(parts, part)
+	}
+	// 国内地址去掉国家，只剩国家时保留
+	if len(parts) > 1 && parts[0] == "中国" {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, " ")
+}
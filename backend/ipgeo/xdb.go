@@ -0,0 +1,87 @@
+package ipgeo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+)
+
+// ip2region xdb 文件格式常量
+const (
+	xdbHeaderSize       = 256
+	xdbVectorIndexRows  = 256
+	xdbVectorIndexCols  = 256
+	xdbVectorIndexSize  = 8
+	xdbSegmentIndexSize = 14
+	xdbVersion          = 2
+)
+
+// ErrInvalidXdb 数据文件不是有效的 xdb 文件或已损坏
+var ErrInvalidXdb = errors.New("无效的 ip2region xdb 文件")
+
+// XdbSearcher 基于 ip2region xdb 文件的查询，整个文件加载到内存，可以并发使用
+type XdbSearcher struct {
+	content []byte
+}
+
+// LoadXdb 加载 ip2region xdb 文件，目前只支持 IPv4 的 v2 格式
+func LoadXdb(path string) (*XdbSearcher, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取IP数据库失败: %w", err)
+	}
+	return NewXdbSearcher(content)
+}
+
+// NewXdbSearcher 根据 xdb 文件内容创建查询
+func NewXdbSearcher(content []byte) (*XdbSearcher, error) {
+	if len(content) < xdbHeaderSize+xdbVectorIndexRows*xdbVectorIndexCols*xdbVectorIndexSize {
+		return nil, ErrInvalidXdb
+	}
+	if version := binary.LittleEndian.Uint16(content); version != xdbVersion {
+		return nil, fmt.Errorf("%w: 不支持的版本 %d", ErrInvalidXdb, version)
+	}
+	return &XdbSearcher{content: content}, nil
+}
+
+// Lookup 查询IP的区域信息，格式为 国家|区域|省份|城市|ISP，未知字段为 0；不支持的地址返回空字符串
+func (s *XdbSearcher) Lookup(ip net.IP) (string, error) {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return "", nil
+	}
+	value := binary.BigEndian.Uint32(ip4)
+
+	// 先按前两个字节在向量索引中定位段索引的范围，再在范围内二分
+	idx := xdbHeaderSize + (int(ip4[0])*xdbVectorIndexCols+int(ip4[1]))*xdbVectorIndexSize
+	start := int(binary.LittleEndian.Uint32(s.content[idx:]))
+	end := int(binary.LittleEndian.Uint32(s.content[idx+4:]))
+	if start > end || end+xdbSegmentIndexSize > len(s.content) {
+		return "", ErrInvalidXdb
+	}
+
+	low, high := 0, (end-start)/xdbSegmentIndexSize
+	for low <= high {
+		mid := (low + high) / 2
+		p := start + mid*xdbSegmentIndexSize
+		segment := s.content[p : p+xdbSegmentIndexSize]
+		if value < binary.LittleEndian.Uint32(segment) {
+			high = mid - 1
+			continue
+		}
+		if value > binary.LittleEndian.Uint32(segment[4:]) {
+			low = mid + 1
+			continue
+		}
+
+		length := int(binary.LittleEndian.Uint16(segment[8:]))
+		ptr := int(binary.LittleEndian.Uint32(segment[10:]))
+		if ptr+length > len(s.content) {
+			return "", ErrInvalidXdb
+		}
+		return string(s.content[ptr : ptr+length]), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,181 @@
+// Package loginguard 防止暴力破解登录：按账号和IP统计连续失败次数，超过阈值后按指数退避临时锁定
+package loginguard
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/ipgeo"
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// 审计事件类型
+const (
+	EventAccountLocked = "account_locked"
+	EventIPLocked      = "ip_locked"
+	EventUnlocked      = "unlocked"
+)
+
+// counter 连续失败计数
+type counter struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// Guard 登录保护
+type Guard struct {
+	mu sync.Mutex
+
+	accountThreshold int           // 账号连续失败多少次后开始锁定
+	ipThreshold      int           // IP连续失败多少次后开始锁定
+	window           time.Duration // 距上次失败超过该时长后重新计数
+	baseLock         time.Duration // 首次锁定时长，之后每多失败一次翻倍
+	maxLock          time.Duration // 最长锁定时长
+
+	accounts map[string]*counter
+	ips      map[string]*counter
+}
+
+var defaultGuard = New(5, 20, 15*time.Minute, time.Minute, time.Hour)
+
+// Init 根据配置初始化全局登录保护
+func Init(cfg *config.Config) {
+	defaultGuard = New(cfg.LoginAccountThreshold, cfg.LoginIPThreshold, cfg.LoginFailureWindow, cfg.LoginLockBase, cfg.LoginLockMax)
+}
+
+// Default 返回全局登录保护
+func Default() *Guard {
+	return defaultGuard
+}
+
+// New 创建登录保护
+func New(accountThreshold, ipThreshold int, window, baseLock, maxLock time.Duration) *Guard {
+	return &Guard{
+		accountThreshold: accountThreshold,
+		ipThreshold:      ipThreshold,
+		window:           window,
+		baseLock:         baseLock,
+		maxLock:          maxLock,
+		accounts:         make(map[string]*counter),
+		ips:              make(map[string]*counter),
+	}
+}
+
+// Check 检查账号和IP是否处于锁定中，返回剩余锁定时长，未锁定时返回 0
+func (g *Guard) Check(account, ip string) time.Duration {
+	now := time.Now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var wait time.Duration
+	if c, ok := g.accounts[account]; ok && now.Before(c.lockedUntil) {
+		wait = c.lockedUntil.Sub(now)
+	}
+	if c, ok := g.ips[ip]; ok && now.Before(c.lockedUntil) && c.lockedUntil.Sub(now) > wait {
+		wait = c.lockedUntil.Sub(now)
+	}
+	return wait
+}
+
+// RecordFailure 记录一次登录失败，达到阈值时锁定账号或IP并写入审计事件
+func (g *Guard) RecordFailure(account, ip string) {
+	now := time.Now()
+	g.mu.Lock()
+	accountLock := g.failLocked(g.accounts, account, g.accountThreshold, now)
+	ipLock := g.failLocked(g.ips, ip, g.ipThreshold, now)
+	g.cleanupLocked(now)
+	g.mu.Unlock()
+
+	if accountLock > 0 {
+		audit(EventAccountLocked, account, ip, "锁定 "+accountLock.String())
+	}
+	if ipLock > 0 {
+		audit(EventIPLocked, account, ip, "锁定 "+ipLock.String())
+	}
+}
+
+// RecordSuccess 登录成功后清除账号的失败记录；IP的失败记录保留，避免用一个可用账号为IP洗白
+func (g *Guard) RecordSuccess(account string) {
+	g.mu.Lock()
+	delete(g.accounts, account)
+	g.mu.Unlock()
+}
+
+// Unlock 管理员解除账号和IP的锁定，参数为空时忽略
+func (g *Guard) Unlock(account, ip, operator string) {
+	g.mu.Lock()
+	if account != "" {
+		delete(g.accounts, account)
+	}
+	if ip != "" {
+		delete(g.ips, ip)
+	}
+	g.mu.Unlock()
+
+	audit(EventUnlocked, account, ip, "操作人 "+operator)
+}
+
+// failLocked 累加失败次数，达到阈值时返回本次锁定时长，调用方需持有锁
+func (g *Guard) failLocked(counters map[string]*counter, key string, threshold int, now time.Time) time.Duration {
+	if key == "" {
+		return 0
+	}
+	c, ok := counters[key]
+	if !ok || (now.Sub(c.lastFailure) > g.window && now.After(c.lockedUntil)) {
+		c = &counter{}
+		counters[key] = c
+	}
+	c.failures++
+	c.lastFailure = now
+
+	if c.failures < threshold {
+		return 0
+	}
+	// 达到阈值后每多失败一次，锁定时长翻倍
+	lock := time.Duration(float64(g.baseLock) * math.Pow(2, float64(c.failures-threshold)))
+	if lock > g.maxLock || lock <= 0 {
+		lock = g.maxLock
+	}
+	c.lockedUntil = now.Add(lock)
+	return lock
+}
+
+// cleanupLocked 清理已过期的计数，调用方需持有锁
+func (g *Guard) cleanupLocked(now time.Time) {
+	for _, counters := range []map[string]*counter{g.accounts, g.ips} {
+		for key, c := range counters {
+			if now.Sub(c.lastFailure) > g.window && now.After(c.lockedUntil) {
+				delete(counters, key)
+			}
+		}
+	}
+}
+
+// audit 写入登录审计事件，失败时只记录日志
+func audit(event, account, ip, detail string) {
+	log.Printf("登录审计: %s account=%s ip=%s %s", event, account, ip, detail)
+	if err := models.CreateLoginAudit(&models.LoginAudit{
+		Event:    event,
+		Account:  account,
+		IP:       ip,
+		IpSource: ipgeo.Lookup(ip),
+		Detail:   detail,
+	}); err != nil {
+		log.Printf("写入登录审计事件失败: %v", err)
+	}
+}
+
+// AccountKey 返回账号的计数键：用户存在时按用户ID计数，这样用户名和邮箱登录共用一个计数；
+// 不存在时按输入的标识计数
+func AccountKey(user *models.User, identifier string) string {
+	if user != nil {
+		return "user:" + strconv.Itoa(user.ID)
+	}
+	return "name:" + strings.ToLower(strings.TrimSpace(identifier))
+}
@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogMailer 不真正发送邮件，而是把邮件内容写入本地文件或标准日志，用于本地开发和测试
+type LogMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewLogMailer 创建日志邮件发送器，path 为空时写入标准日志
+func NewLogMailer(path string) *LogMailer {
+	return &LogMailer{path: path}
+}
+
+// Send 记录一封邮件
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	entry := fmt.Sprintf("[%s] To: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), to, subject, body)
+
+	if m.path == "" {
+		log.Printf("邮件(未实际发送):\n%s", entry)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("打开邮件日志文件失败: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(entry); err != nil {
+		return fmt.Errorf("写入邮件日志文件失败: %w", err)
+	}
+	return nil
+}
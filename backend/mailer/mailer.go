@@ -0,0 +1,30 @@
+// Package mailer 提供发送邮件的抽象，以及 SMTP 和本地日志两种实现
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	// Send 发送一封纯文本邮件
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// New 根据配置创建邮件发送器，MAIL_DRIVER 为 smtp 时使用SMTP，为 log 时写入本地日志
+func New(cfg *config.Config) (Mailer, error) {
+	switch cfg.MailDriver {
+	case "smtp":
+		if cfg.SMTPHost == "" || cfg.MailFrom == "" {
+			return nil, fmt.Errorf("使用smtp发送邮件时必须配置 SMTP_HOST 和 MAIL_FROM")
+		}
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	case "log", "":
+		return NewLogMailer(cfg.MailLogFile), nil
+	default:
+		return nil, fmt.Errorf("不支持的邮件驱动: %s", cfg.MailDriver)
+	}
+}
@@ -6,18 +6,46 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/jayden/personal-blog-backend/api"
 	v1 "github.com/jayden/personal-blog-backend/api/v1"
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/captcha"
+	"github.com/jayden/personal-blog-backend/chat"
 	"github.com/jayden/personal-blog-backend/config"
 	"github.com/jayden/personal-blog-backend/db"
 	_ "github.com/jayden/personal-blog-backend/docs" // 这里需要导入 docs 包
+	"github.com/jayden/personal-blog-backend/feed"
+	"github.com/jayden/personal-blog-backend/friendcheck"
+	"github.com/jayden/personal-blog-backend/imageproc"
+	"github.com/jayden/personal-blog-backend/ipgeo"
+	"github.com/jayden/personal-blog-backend/loginguard"
+	"github.com/jayden/personal-blog-backend/mailer"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/oauth"
+	"github.com/jayden/personal-blog-backend/prerender"
+	"github.com/jayden/personal-blog-backend/ratelimit"
+	"github.com/jayden/personal-blog-backend/search"
+	"github.com/jayden/personal-blog-backend/seo"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+	"github.com/jayden/personal-blog-backend/sms"
+	"github.com/jayden/personal-blog-backend/spamfilter"
+	"github.com/jayden/personal-blog-backend/storage"
+	"github.com/jayden/personal-blog-backend/taxonomy"
+	"github.com/jayden/personal-blog-backend/tourist"
+	"github.com/jayden/personal-blog-backend/upload"
+	"github.com/jayden/personal-blog-backend/verifycode"
+	"github.com/jayden/personal-blog-backend/viewstat"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -35,8 +63,9 @@ func enableCORS(next http.Handler) http.Handler {
 
 		// 设置其他必要的CORS头
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Origin, X-Requested-With, Accept")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Token, Uid, Origin, X-Requested-With, Accept, App-Name, Timestamp, X-Terminal-Id, X-Terminal-Token")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Access-Control-Expose-Headers", "RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After")
 		w.Header().Set("Access-Control-Max-Age", "86400")
 		w.Header().Set("Vary", "Origin")
 
@@ -75,21 +104,126 @@ func main() {
 		}
 	}()
 
-	// 创建路由器
+	// 设置受信任的反向代理，用于获取真实客户端IP
+	if err := netutil.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("解析受信任代理失败: %v", err)
+	}
+
+	// 加载IP归属地数据库
+	if err := ipgeo.Init(cfg); err != nil {
+		log.Fatalf("加载IP归属地数据库失败: %v", err)
+	}
+
+	// 初始化JWT签名密钥
+	auth.Init(cfg)
+
+	// 初始化接口限流
+	if err := ratelimit.Init(cfg); err != nil {
+		log.Fatalf("解析限流策略失败: %v", err)
+	}
+
+	// 初始化图形验证码
+	captcha.Init(cfg)
+
+	// 初始化登录失败锁定
+	loginguard.Init(cfg)
+
+	// 注册已配置的第三方登录平台
+	oauth.Init(cfg)
+
+	// 初始化邮件验证码服务
+	m, err := mailer.New(cfg)
+	if err != nil {
+		log.Fatalf("初始化邮件发送失败: %v", err)
+	}
+	verifycode.EmailCodes = verifycode.NewService(verifycode.NewEmailSender(m), verifycode.Options{
+		CodeLength:  6,
+		TTL:         cfg.VerifyCodeTTL,
+		MaxAttempts: 5,
+		Interval:    cfg.VerifyCodeInterval,
+		DailyLimit:  cfg.VerifyCodeDailyLimit,
+	}, verifycode.SceneRegister, verifycode.SceneResetPassword, verifycode.SceneBindEmail)
+
+	// 初始化短信验证码服务
+	smsSender, err := sms.New(cfg)
+	if err != nil {
+		log.Fatalf("初始化短信发送失败: %v", err)
+	}
+	sms.DefaultCountryCode = cfg.SMSDefaultCountryCode
+	verifycode.PhoneCodes = verifycode.NewService(verifycode.NewSMSSender(smsSender), verifycode.Options{
+		CodeLength:  6,
+		TTL:         cfg.VerifyCodeTTL,
+		MaxAttempts: 5,
+		Interval:    cfg.VerifyCodeInterval,
+		DailyLimit:  cfg.VerifyCodeDailyLimit,
+	}, verifycode.SceneLogin, verifycode.SceneBindPhone, verifycode.SceneUnbindPhone)
+
+	// 初始化文件上传和存储后端
+	if err := upload.Init(cfg); err != nil {
+		log.Fatalf("初始化文件上传失败: %v", err)
+	}
+
+	// 启动图片处理工作池，退出时等待队列中的图片处理完
+	imageproc.Init(cfg, upload.Default().Storage())
+	defer imageproc.Stop()
+
+	// 启动浏览量统计，退出时先把内存中的统计写回数据库再关闭连接
+	viewstat.Init(cfg)
+	defer viewstat.Stop()
+
+	// 网站配置缓存
+	siteconfig.Init(cfg)
+
+	// 游客ID签名密钥
+	tourist.Init(cfg)
+
+	// 建立文章检索索引
+	if err := search.Init(cfg); err != nil {
+		log.Fatalf("初始化文章检索失败: %v", err)
+	}
+
+	// 订阅源和 SEO 选项
+	feed.Init(cfg)
+	seo.Init(cfg)
+	prerender.Init(cfg)
+
+	// 启动友链定期检测
+	friendcheck.Init(cfg)
+	defer friendcheck.Stop()
+
+	// 启动分类和标签计数定期校正
+	taxonomy.Init(cfg)
+	defer taxonomy.Stop()
+
+	// 垃圾内容过滤和聊天室
+	spamfilter.Init(cfg)
+	chat.Init(cfg)
+
+	// 创建路由器，所有接口先经过兜底限流
 	r := mux.NewRouter()
+	r.Use(ratelimit.Middleware(ratelimit.PolicyDefault))
 
 	// 设置API路由
 	apiRouter := r.PathPrefix("/blog-api/v1").Subrouter()
-	apiRouter.HandleFunc("/login", api.LoginHandler).Methods("POST")
-	apiRouter.HandleFunc("/register", api.RegisterHandler).Methods("POST")
+	apiRouter.Handle("/login", ratelimit.Wrap(ratelimit.PolicyAuth, api.LoginHandler)).Methods("POST")
+	apiRouter.Handle("/register", ratelimit.Wrap(ratelimit.PolicyAuth, api.RegisterHandler)).Methods("POST")
+	apiRouter.Handle("/email_login", ratelimit.Wrap(ratelimit.PolicyAuth, api.EmailLoginHandler)).Methods("POST")
+	apiRouter.Handle("/reset_password", ratelimit.Wrap(ratelimit.PolicyAuth, api.ResetPasswordHandler)).Methods("POST")
+	apiRouter.Handle("/send_email_verify_code", ratelimit.Wrap(ratelimit.PolicyAuth, api.SendEmailVerifyCodeHandler)).Methods("POST")
+	apiRouter.Handle("/phone_login", ratelimit.Wrap(ratelimit.PolicyAuth, api.PhoneLoginHandler)).Methods("POST")
+	apiRouter.Handle("/send_phone_verify_code", ratelimit.Wrap(ratelimit.PolicyAuth, api.SendPhoneVerifyCodeHandler)).Methods("POST")
+	apiRouter.HandleFunc("/get_oauth_authorize_url", api.GetOauthAuthorizeUrlHandler).Methods("POST")
+	apiRouter.Handle("/third_login", ratelimit.Wrap(ratelimit.PolicyAuth, api.ThirdLoginHandler)).Methods("POST")
 	apiRouter.HandleFunc("/health", api.HealthCheck).Methods("GET")
+	apiRouter.Handle("/get_captcha_code", ratelimit.Wrap(ratelimit.PolicyAuth, api.GetCaptchaCodeHandler)).Methods("POST")
 
 	// 文章相关路由
 	apiRouter.HandleFunc("/articles", api.GetArticlesHandler).Methods("GET")
 	apiRouter.HandleFunc("/article", api.GetArticleDetailHandler).Methods("GET")
-	apiRouter.HandleFunc("/article", api.CreateArticleHandler).Methods("POST")
-	apiRouter.HandleFunc("/article", api.UpdateArticleHandler).Methods("PUT")
-	apiRouter.HandleFunc("/article", api.DeleteArticleHandler).Methods("DELETE")
+	// 写接口需要管理员权限
+	apiRouter.Handle("/article", auth.RequireAdmin(http.HandlerFunc(api.CreateArticleHandler))).Methods("POST")
+	apiRouter.Handle("/article", auth.RequireAdmin(http.HandlerFunc(api.UpdateArticleHandler))).Methods("PUT")
+	apiRouter.Handle("/article", auth.RequireAdmin(http.HandlerFunc(api.DeleteArticleHandler))).Methods("DELETE")
 
 	// 分类相关路由
 	apiRouter.HandleFunc("/categories", api.GetCategoriesHandler).Methods("GET")
@@ -101,8 +235,10 @@ func main() {
 	apiRouter.HandleFunc("/articles/category", api.GetArticlesByCategoryHandler).Methods("GET")
 	apiRouter.HandleFunc("/articles/tag", api.GetArticlesByTagHandler).Methods("GET")
 
-	// v1 API路由
+	// v1 API路由，读接口使用 read 策略，写接口在路由上单独指定更严格的策略
 	v1Router := r.PathPrefix("/blog-api/v1").Subrouter()
+	v1Router.Use(ratelimit.Middleware(ratelimit.PolicyRead))
+	v1Router.Use(tourist.Middleware)
 
 	// 相册相关路由
 	v1Router.HandleFunc("/album/find_album_list", v1.FindAlbumListHandler).Methods("POST")
@@ -116,26 +252,39 @@ func main() {
 	v1Router.HandleFunc("/article/get_article_details", v1.GetArticleDetailsHandler).Methods("POST")
 	v1Router.HandleFunc("/article/get_article_home_list", v1.GetArticleHomeListHandler).Methods("POST")
 	v1Router.HandleFunc("/article/get_article_recommend", v1.GetArticleRecommendHandler).Methods("POST")
-	v1Router.HandleFunc("/article/like_article", v1.LikeArticleHandler).Methods("POST")
+	v1Router.HandleFunc("/article/search_article", v1.SearchArticleHandler).Methods("POST")
+	v1Router.HandleFunc("/article/get_article_seo", v1.GetArticleSeoHandler).Methods("POST")
+	v1Router.Handle("/article/like_article", ratelimit.Wrap(ratelimit.PolicyLike, v1.LikeArticleHandler)).Methods("POST")
 
 	// 评论相关路由
 	v1Router.HandleFunc("/comment/find_comment_list", v1.FindCommentListHandler).Methods("POST")
 	v1Router.HandleFunc("/comment/find_comment_recent_list", v1.FindCommentRecentListHandler).Methods("POST")
 	v1Router.HandleFunc("/comment/find_comment_reply_list", v1.FindCommentReplyListHandler).Methods("POST")
-	v1Router.HandleFunc("/comment/add_comment", v1.AddCommentHandler).Methods("POST")
-	v1Router.HandleFunc("/comment/like_comment", v1.LikeCommentHandler).Methods("POST")
-	v1Router.HandleFunc("/comment/update_comment", v1.UpdateCommentHandler).Methods("POST")
-
-	// 用户相关路由
-	v1Router.HandleFunc("/user/delete_user_bind_third_party", v1.DeleteUserBindThirdPartyHandler).Methods("POST")
-	v1Router.HandleFunc("/user/get_user_info", v1.GetUserInfoHandler).Methods("GET")
-	v1Router.HandleFunc("/user/get_user_like", v1.GetUserLikeHandler).Methods("GET")
-	v1Router.HandleFunc("/user/update_user_avatar", v1.UpdateUserAvatarHandler).Methods("POST")
-	v1Router.HandleFunc("/user/update_user_bind_email", v1.UpdateUserBindEmailHandler).Methods("POST")
-	v1Router.HandleFunc("/user/update_user_bind_phone", v1.UpdateUserBindPhoneHandler).Methods("POST")
-	v1Router.HandleFunc("/user/update_user_bind_third_party", v1.UpdateUserBindThirdPartyHandler).Methods("POST")
-	v1Router.HandleFunc("/user/update_user_info", v1.UpdateUserInfoHandler).Methods("POST")
-	v1Router.HandleFunc("/user/update_user_password", v1.UpdateUserPasswordHandler).Methods("POST")
+	v1Router.Handle("/comment/add_comment", ratelimit.Wrap(ratelimit.PolicyComment, v1.AddCommentHandler)).Methods("POST")
+	v1Router.Handle("/comment/like_comment", ratelimit.Wrap(ratelimit.PolicyLike, v1.LikeCommentHandler)).Methods("POST")
+	v1Router.Handle("/comment/update_comment", ratelimit.Wrap(ratelimit.PolicyComment, v1.UpdateCommentHandler)).Methods("POST")
+
+	// 用户相关路由，需要登录
+	userRouter := v1Router.PathPrefix("/user").Subrouter()
+	userRouter.Use(auth.RequireLogin)
+	userRouter.HandleFunc("/delete_user_bind_third_party", v1.DeleteUserBindThirdPartyHandler).Methods("POST")
+	userRouter.HandleFunc("/get_user_info", v1.GetUserInfoHandler).Methods("GET")
+	userRouter.HandleFunc("/get_user_like", v1.GetUserLikeHandler).Methods("GET")
+	userRouter.HandleFunc("/update_user_avatar", v1.UpdateUserAvatarHandler).Methods("POST")
+	userRouter.HandleFunc("/update_user_bind_email", v1.UpdateUserBindEmailHandler).Methods("POST")
+	userRouter.HandleFunc("/update_user_bind_phone", v1.UpdateUserBindPhoneHandler).Methods("POST")
+	userRouter.HandleFunc("/delete_user_bind_phone", v1.DeleteUserBindPhoneHandler).Methods("POST")
+	userRouter.HandleFunc("/update_user_bind_third_party", v1.UpdateUserBindThirdPartyHandler).Methods("POST")
+	userRouter.HandleFunc("/update_user_info", v1.UpdateUserInfoHandler).Methods("POST")
+	userRouter.HandleFunc("/update_user_password", v1.UpdateUserPasswordHandler).Methods("POST")
+
+	// 文件上传相关路由，需要登录
+	uploadRouter := v1Router.PathPrefix("/upload").Subrouter()
+	uploadRouter.Use(auth.RequireLogin)
+	uploadRouter.HandleFunc("/upload_file", v1.UploadFileHandler).Methods("POST")
+	uploadRouter.HandleFunc("/multi_upload_file", v1.MultiUploadFileHandler).Methods("POST")
+	uploadRouter.HandleFunc("/list_upload_file", v1.ListUploadFileHandler).Methods("POST")
+	uploadRouter.HandleFunc("/deletes_upload_file", v1.DeletesUploadFileHandler).Methods("DELETE")
 
 	// 博客相关路由
 	v1Router.HandleFunc("/blog", v1.GetBlogHomeInfoHandler).Methods("GET")
@@ -146,12 +295,122 @@ func main() {
 
 	// 说说相关路由
 	v1Router.HandleFunc("/talk/find_talk_list", v1.FindTalkListHandler).Methods("POST")
+	v1Router.HandleFunc("/talk/get_talk", v1.GetTalkHandler).Methods("POST")
+	v1Router.Handle("/talk/like_talk", ratelimit.Wrap(ratelimit.PolicyLike, v1.LikeTalkHandler)).Methods("PUT")
+
+	// 留言相关路由，游客也可以留言
+	v1Router.HandleFunc("/remark/find_remark_list", v1.FindRemarkListHandler).Methods("POST")
+	v1Router.Handle("/remark/add_remark", ratelimit.Wrap(ratelimit.PolicyComment, v1.AddRemarkHandler)).Methods("POST")
+
+	// 友链相关路由
+	v1Router.HandleFunc("/friend_link/find_friend_list", v1.FindFriendListHandler).Methods("POST")
+	v1Router.Handle("/friend_link/apply_friend_link", ratelimit.Wrap(ratelimit.PolicyComment, v1.ApplyFriendLinkHandler)).Methods("POST")
+
+	// 分类和标签相关路由
+	v1Router.HandleFunc("/category/find_category_list", v1.FindCategoryListHandler).Methods("POST")
+	v1Router.HandleFunc("/category/find_category_tree", v1.FindCategoryTreeHandler).Methods("POST")
+	v1Router.HandleFunc("/tag/find_tag_list", v1.FindTagListHandler).Methods("POST")
+
+	// 页面相关路由
+	v1Router.HandleFunc("/page/find_page_list", v1.FindPageListHandler).Methods("POST")
+
+	// 聊天室 WebSocket 路由
+	v1Router.Handle("/websocket", chat.Default().Handler()).Methods("GET")
+
+	// 后台管理路由，需要管理员权限
+	adminRouter := v1Router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(auth.RequireAdmin)
+
+	// 统计相关路由
+	adminRouter.HandleFunc("/statistics/find_daily_view_list", v1.FindDailyViewListHandler).Methods("POST")
+	adminRouter.HandleFunc("/security/unlock_login", v1.UnlockLoginHandler).Methods("POST")
+	adminRouter.HandleFunc("/security/find_login_audit_list", v1.FindLoginAuditListHandler).Methods("POST")
+
+	// 文章管理路由
+	adminRouter.HandleFunc("/article/rebuild_search_index", v1.RebuildSearchIndexHandler).Methods("POST")
+
+	// 分类和标签管理路由
+	adminRouter.HandleFunc("/category/add_category", v1.AddCategoryHandler).Methods("POST")
+	adminRouter.HandleFunc("/category/update_category", v1.UpdateCategoryHandler).Methods("POST")
+	adminRouter.HandleFunc("/category/delete_category", v1.DeleteCategoryHandler).Methods("POST")
+	adminRouter.HandleFunc("/category/merge_category", v1.MergeCategoryHandler).Methods("POST")
+	adminRouter.HandleFunc("/category/reconcile_count", v1.ReconcileCountHandler).Methods("POST")
+	adminRouter.HandleFunc("/tag/add_tag", v1.AddTagHandler).Methods("POST")
+	adminRouter.HandleFunc("/tag/update_tag", v1.UpdateTagHandler).Methods("POST")
+	adminRouter.HandleFunc("/tag/delete_tag", v1.DeleteTagHandler).Methods("POST")
+	adminRouter.HandleFunc("/tag/merge_tag", v1.MergeTagHandler).Methods("POST")
+
+	// 相册管理路由
+	adminRouter.HandleFunc("/album/add_album", v1.AddAlbumHandler).Methods("POST")
+	adminRouter.HandleFunc("/album/update_album", v1.UpdateAlbumHandler).Methods("POST")
+	adminRouter.HandleFunc("/album/delete_album", v1.DeleteAlbumHandler).Methods("POST")
+	adminRouter.HandleFunc("/album/add_photos", v1.AddPhotosHandler).Methods("POST")
+	adminRouter.HandleFunc("/album/delete_photos", v1.DeletePhotosHandler).Methods("POST")
+	adminRouter.HandleFunc("/album/sort_photos", v1.SortPhotosHandler).Methods("POST")
+	adminRouter.HandleFunc("/album/set_album_cover", v1.SetAlbumCoverHandler).Methods("POST")
+
+	// 说说管理路由
+	adminRouter.HandleFunc("/talk/add_talk", v1.AddTalkHandler).Methods("POST")
+	adminRouter.HandleFunc("/talk/update_talk", v1.UpdateTalkHandler).Methods("POST")
+	adminRouter.HandleFunc("/talk/delete_talk", v1.DeleteTalkHandler).Methods("POST")
+
+	// 留言管理路由
+	adminRouter.HandleFunc("/remark/find_remark_list", v1.AdminFindRemarkListHandler).Methods("POST")
+	adminRouter.HandleFunc("/remark/update_remark_review", v1.UpdateRemarkReviewHandler).Methods("POST")
+	adminRouter.HandleFunc("/remark/deletes_remark", v1.DeletesRemarkHandler).Methods("POST")
+
+	// 友链管理路由
+	adminRouter.HandleFunc("/friend_link/find_friend_list", v1.AdminFindFriendListHandler).Methods("POST")
+	adminRouter.HandleFunc("/friend_link/add_friend", v1.AddFriendHandler).Methods("POST")
+	adminRouter.HandleFunc("/friend_link/update_friend", v1.UpdateFriendHandler).Methods("POST")
+	adminRouter.HandleFunc("/friend_link/update_friend_status", v1.UpdateFriendStatusHandler).Methods("POST")
+	adminRouter.HandleFunc("/friend_link/deletes_friend", v1.DeletesFriendHandler).Methods("POST")
+	adminRouter.HandleFunc("/friend_link/check_friend", v1.CheckFriendHandler).Methods("POST")
+
+	// 页面管理路由
+	adminRouter.HandleFunc("/page/add_page", v1.AddPageHandler).Methods("POST")
+	adminRouter.HandleFunc("/page/update_page", v1.UpdatePageHandler).Methods("POST")
+	adminRouter.HandleFunc("/page/deletes_page", v1.DeletesPageHandler).Methods("POST")
+	adminRouter.HandleFunc("/page/sort_pages", v1.SortPagesHandler).Methods("POST")
+
+	// 网站配置管理路由
+	adminRouter.HandleFunc("/website/get_website_config", v1.GetWebsiteConfigHandler).Methods("POST")
+	adminRouter.HandleFunc("/website/update_website_config", v1.UpdateWebsiteConfigHandler).Methods("POST")
+	adminRouter.HandleFunc("/website/update_about_me", v1.UpdateAboutMeHandler).Methods("POST")
+	adminRouter.HandleFunc("/website/find_config_version_list", v1.FindConfigVersionListHandler).Methods("POST")
+	adminRouter.HandleFunc("/website/rollback_config", v1.RollbackConfigHandler).Methods("POST")
+
+	// 本地存储的静态文件路由，路径前缀取自 UPLOAD_BASE_URL
+	if local, ok := upload.Default().Storage().(*storage.LocalStorage); ok {
+		prefix := "/uploads"
+		if u, err := url.Parse(cfg.UploadBaseURL); err == nil && strings.TrimRight(u.Path, "/") != "" {
+			prefix = strings.TrimRight(u.Path, "/")
+		}
+		r.PathPrefix(prefix+"/").Handler(http.StripPrefix(prefix, local.Handler())).Methods("GET", "HEAD")
+	}
+
+	// 订阅源路由：全站、单个分类和单个标签
+	for _, scope := range []string{"/", "/category/{category_id}/", "/tag/{tag_id}/"} {
+		r.Handle(scope+"feed.xml", feed.Handler(feed.FormatRSS)).Methods("GET", "HEAD")
+		r.Handle(scope+"atom.xml", feed.Handler(feed.FormatAtom)).Methods("GET", "HEAD")
+		r.Handle(scope+"feed.json", feed.Handler(feed.FormatJSON)).Methods("GET", "HEAD")
+	}
+
+	// 站点地图和 robots.txt
+	r.HandleFunc("/sitemap.xml", seo.SitemapHandler).Methods("GET", "HEAD")
+	r.HandleFunc("/sitemap-{part:[0-9]+}.xml", seo.SitemapPartHandler).Methods("GET", "HEAD")
+	r.HandleFunc("/robots.txt", seo.RobotsHandler).Methods("GET", "HEAD")
+
+	// 分享地址，返回带 Open Graph 信息的预渲染页面
+	r.HandleFunc("/s/{id}", prerender.ArticleHandler).Methods("GET", "HEAD")
+	r.HandleFunc("/s/category/{category_id}", prerender.CategoryHandler).Methods("GET", "HEAD")
+	r.HandleFunc("/s/tag/{tag_id}", prerender.TagHandler).Methods("GET", "HEAD")
 
 	// Swagger 文档路由
 	r.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
-	// 应用自定义CORS中间件
-	handler := enableCORS(r)
+	// 爬虫访问前台文章、分类和标签地址时返回预渲染页面，再应用自定义CORS中间件
+	handler := enableCORS(prerender.Middleware(r))
 
 	// 启动服务器
 	server := &http.Server{
@@ -172,4 +431,12 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("服务器正在关闭...")
+
+	// 先停止接收新请求并等待进行中的请求完成，再断开已升级为 WebSocket 的连接
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("关闭服务器失败: %v", err)
+	}
+	chat.Stop()
 }
@@ -0,0 +1,67 @@
+// Package markdown 把文章的 Markdown 正文转换为 HTML 或纯文本
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// renderer 支持 GFM（表格、删除线、任务列表、自动链接）；文章由管理员撰写，保留其中的原始 HTML
+var renderer = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// Render 把 Markdown 转换为 HTML
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+var (
+	mdCodeFence = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	mdImage     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink      = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdHTMLTag   = regexp.MustCompile(`<[^>]+>`)
+	mdHeading   = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s*`)
+	mdQuote     = regexp.MustCompile(`(?m)^\s*>+\s?`)
+	mdList      = regexp.MustCompile(`(?m)^\s*([-*+]|\d+\.)\s+`)
+	mdRule      = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$`)
+	mdTable     = regexp.MustCompile(`(?m)^\s*\|?(\s*:?-+:?\s*\|)+\s*:?-*:?\s*$`)
+	mdEmphasis  = regexp.MustCompile("[*_~`]+")
+	mdSpaces    = regexp.MustCompile(`\s+`)
+)
+
+// Strip 去掉 Markdown 标记，返回用于索引和摘要的纯文本；图片保留替代文字，链接保留文字
+func Strip(markdown string) string {
+	text := mdCodeFence.ReplaceAllString(markdown, "")
+	text = mdImage.ReplaceAllString(text, "$1")
+	text = mdLink.ReplaceAllString(text, "$1")
+	text = mdHTMLTag.ReplaceAllString(text, " ")
+	text = mdTable.ReplaceAllString(text, "")
+	text = mdRule.ReplaceAllString(text, "")
+	text = mdHeading.ReplaceAllString(text, "")
+	text = mdQuote.ReplaceAllString(text, "")
+	text = mdList.ReplaceAllString(text, "")
+	text = mdEmphasis.ReplaceAllString(text, "")
+	text = strings.ReplaceAll(text, "|", " ")
+	return strings.TrimSpace(mdSpaces.ReplaceAllString(text, " "))
+}
+
+// Excerpt 返回 Markdown 正文的纯文本摘要，超过 length 个字符时截断并以省略号结尾
+func Excerpt(markdown string, length int) string {
+	text := Strip(markdown)
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length]) + "..."
+}
@@ -1,38 +1,477 @@
 package models
 
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 相册错误
+var (
+	ErrAlbumNotFound = errors.New("相册不存在")
+	ErrPhotoNotFound = errors.New("照片不存在")
+)
+
 // Album 相册模型
 type Album struct {
 	ID         int64  `json:"id" db:"id"`
 	AlbumName  string `json:"album_name" db:"album_name"`
 	AlbumDesc  string `json:"album_desc" db:"album_desc"`
 	AlbumCover string `json:"album_cover" db:"album_cover"`
-	CreatedAt  int64  `json:"created_at" db:"created_at"`
-	UpdatedAt  int64  `json:"updated_at" db:"updated_at"`
+	// 封面缩略图，没有变体时与封面相同
+	AlbumCoverThumb string `json:"album_cover_thumb" db:"-"`
+	// 是否私密，私密相册只有管理员可见
+	IsPrivate bool `json:"is_private" db:"is_private"`
+	// 是否需要密码才能查看照片
+	HasPassword bool `json:"has_password" db:"-"`
+	// 访问密码的bcrypt哈希
+	PasswordHash string `json:"-" db:"album_password"`
+	// 照片数量
+	PhotoCount int64 `json:"photo_count" db:"-"`
+	CreatedAt  int64 `json:"created_at" db:"created_at"`
+	UpdatedAt  int64 `json:"updated_at" db:"updated_at"`
 }
 
 // Photo 照片模型
 type Photo struct {
-	ID        int64  `json:"id" db:"id"`
-	AlbumID   int64  `json:"album_id" db:"album_id"`
-	PhotoUrl  string `json:"photo_url" db:"photo_url"`
-	CreatedAt int64  `json:"created_at" db:"created_at"`
-	UpdatedAt int64  `json:"updated_at" db:"updated_at"`
+	ID       int64  `json:"id" db:"id"`
+	AlbumID  int64  `json:"album_id" db:"album_id"`
+	PhotoUrl string `json:"photo_url" db:"photo_url"`
+	// 缩略图地址，没有变体时与原图相同
+	ThumbUrl string `json:"thumb_url" db:"-"`
+	// 中图地址，没有变体时与原图相同
+	MediumUrl string `json:"medium_url" db:"-"`
+	// 照片名称
+	PhotoName string `json:"photo_name" db:"photo_name"`
+	// 排序，越小越靠前
+	Sort      int   `json:"sort" db:"sort"`
+	CreatedAt int64 `json:"created_at" db:"created_at"`
+	UpdatedAt int64 `json:"updated_at" db:"updated_at"`
 }
 
-// GetAlbums 获取相册列表
-func GetAlbums(limit, offset int) ([]Album, error) {
-	// 实现获取相册列表逻辑
-	return []Album{}, nil
+// albumColumns 查询相册的列，照片数量通过子查询统计
+const albumColumns = `a.id, a.album_name, a.album_desc, a.album_cover, a.is_private, a.album_password,
+	(SELECT COUNT(*) FROM photo p WHERE p.album_id = a.id), a.created_time, a.updated_time`
+
+// scanAlbum 扫描一行相册记录
+func scanAlbum(scanner interface{ Scan(...interface{}) error }) (*Album, error) {
+	var (
+		album                    Album
+		createdTime, updatedTime time.Time
+	)
+	err := scanner.Scan(&album.ID, &album.AlbumName, &album.AlbumDesc, &album.AlbumCover, &album.IsPrivate,
+		&album.PasswordHash, &album.PhotoCount, &createdTime, &updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	album.HasPassword = album.PasswordHash != ""
+	album.CreatedAt = createdTime.Unix()
+	album.UpdatedAt = updatedTime.Unix()
+	return &album, nil
 }
 
-// GetPhotosByAlbumID 根据相册ID获取照片列表
-func GetPhotosByAlbumID(albumID int64, limit, offset int) ([]Photo, error) {
-	// 实现根据相册ID获取照片列表逻辑
-	return []Photo{}, nil
+// GetAlbums 获取相册列表，includePrivate 为 false 时不包含私密相册
+func GetAlbums(limit, offset int, includePrivate bool) ([]Album, int64, error) {
+	where := ""
+	if !includePrivate {
+		where = " WHERE a.is_private = 0"
+	}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM album a" + where).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取相册总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT "+albumColumns+" FROM album a"+where+" ORDER BY a.id DESC LIMIT ? OFFSET ?",
+		limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取相册列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	albums := []Album{}
+	for rows.Next() {
+		album, err := scanAlbum(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描相册行失败: %w", err)
+		}
+		albums = append(albums, *album)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历相册行失败: %w", err)
+	}
+
+	if err := AttachAlbumVariants(albums); err != nil {
+		return nil, 0, err
+	}
+	return albums, total, nil
 }
 
 // GetAlbumByID 根据ID获取相册
 func GetAlbumByID(id int64) (*Album, error) {
-	// 实现根据ID获取相册逻辑
-	return &Album{}, nil
+	row := db.DB.QueryRow("SELECT "+albumColumns+" FROM album a WHERE a.id = ?", id)
+	album, err := scanAlbum(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 相册不存在
+		}
+		return nil, fmt.Errorf("获取相册失败: %w", err)
+	}
+
+	albums := []Album{*album}
+	if err := AttachAlbumVariants(albums); err != nil {
+		return nil, err
+	}
+	return &albums[0], nil
+}
+
+// VerifyAlbumPassword 校验相册访问密码，未设置密码的相册总是通过
+func VerifyAlbumPassword(album *Album, password string) bool {
+	if album.PasswordHash == "" {
+		return true
+	}
+	return VerifyPassword(album.PasswordHash, password)
+}
+
+// HashAlbumPassword 生成相册访问密码的哈希
+func HashAlbumPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("生成相册密码哈希失败: %w", err)
+	}
+	return string(hashed), nil
+}
+
+// CreateAlbum 创建相册
+func CreateAlbum(album *Album) error {
+	result, err := db.DB.Exec(
+		`INSERT INTO album (album_name, album_desc, album_cover, is_private, album_password, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, NOW(), NOW())`,
+		album.AlbumName, album.AlbumDesc, album.AlbumCover, album.IsPrivate, album.PasswordHash,
+	)
+	if err != nil {
+		return fmt.Errorf("创建相册失败: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("获取相册ID失败: %w", err)
+	}
+	album.ID = id
+	return nil
+}
+
+// UpdateAlbum 更新相册信息，PasswordHash 会被整体覆盖，调用方需要先填入原有哈希或新哈希
+func UpdateAlbum(album *Album) error {
+	result, err := db.DB.Exec(
+		`UPDATE album SET album_name = ?, album_desc = ?, album_cover = ?, is_private = ?, album_password = ?, updated_time = NOW()
+		WHERE id = ?`,
+		album.AlbumName, album.AlbumDesc, album.AlbumCover, album.IsPrivate, album.PasswordHash, album.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("更新相册失败: %w", err)
+	}
+	return requireExists(result, "album", "id = ?", ErrAlbumNotFound, album.ID)
+}
+
+// DeleteAlbum 删除相册及其中的照片记录，上传的文件本身不删除
+func DeleteAlbum(id int64) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM photo WHERE album_id = ?", id); err != nil {
+		return fmt.Errorf("删除相册照片失败: %w", err)
+	}
+	result, err := tx.Exec("DELETE FROM album WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("删除相册失败: %w", err)
+	}
+	if err := requireAffected(result, ErrAlbumNotFound); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交删除相册事务失败: %w", err)
+	}
+	return nil
+}
+
+// SetAlbumCover 把相册中的一张照片设为封面
+func SetAlbumCover(albumID, photoID int64) error {
+	result, err := db.DB.Exec(
+		`UPDATE album a JOIN photo p ON p.album_id = a.id
+		SET a.album_cover = p.photo_url, a.updated_time = NOW()
+		WHERE a.id = ? AND p.id = ?`,
+		albumID, photoID,
+	)
+	if err != nil {
+		return fmt.Errorf("设置相册封面失败: %w", err)
+	}
+	return requireExists(result, "photo", "id = ? AND album_id = ?", ErrPhotoNotFound, photoID, albumID)
+}
+
+// photoColumns 查询照片的列
+const photoColumns = "id, album_id, photo_url, photo_name, sort, created_time, updated_time"
+
+// GetPhotosByAlbumID 根据相册ID获取照片列表，按排序值升序
+func GetPhotosByAlbumID(albumID int64, limit, offset int) ([]Photo, int64, error) {
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM photo WHERE album_id = ?", albumID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取照片总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT "+photoColumns+" FROM photo WHERE album_id = ? ORDER BY sort ASC, id ASC LIMIT ? OFFSET ?",
+		albumID, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取照片列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	photos := []Photo{}
+	for rows.Next() {
+		var (
+			photo                    Photo
+			createdTime, updatedTime time.Time
+		)
+		if err := rows.Scan(&photo.ID, &photo.AlbumID, &photo.PhotoUrl, &photo.PhotoName, &photo.Sort,
+			&createdTime, &updatedTime); err != nil {
+			return nil, 0, fmt.Errorf("扫描照片行失败: %w", err)
+		}
+		photo.CreatedAt = createdTime.Unix()
+		photo.UpdatedAt = updatedTime.Unix()
+		photos = append(photos, photo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历照片行失败: %w", err)
+	}
+
+	if err := AttachPhotoVariants(photos); err != nil {
+		return nil, 0, err
+	}
+	return photos, total, nil
+}
+
+// AddPhotos 向相册添加照片，新照片排在已有照片之后；相册没有封面时使用第一张照片
+func AddPhotos(albumID int64, photos []Photo) error {
+	if len(photos) == 0 {
+		return nil
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 锁定相册行，避免并发添加时排序值重复
+	var cover string
+	err = tx.QueryRow("SELECT album_cover FROM album WHERE id = ? FOR UPDATE", albumID).Scan(&cover)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrAlbumNotFound
+		}
+		return fmt.Errorf("获取相册失败: %w", err)
+	}
+
+	var maxSort int
+	if err := tx.QueryRow("SELECT COALESCE(MAX(sort), 0) FROM photo WHERE album_id = ?", albumID).Scan(&maxSort); err != nil {
+		return fmt.Errorf("获取照片排序失败: %w", err)
+	}
+
+	stmt, err := tx.Prepare(
+		"INSERT INTO photo (album_id, photo_url, photo_name, sort, created_time, updated_time) VALUES (?, ?, ?, ?, NOW(), NOW())",
+	)
+	if err != nil {
+		return fmt.Errorf("预处理照片语句失败: %w", err)
+	}
+	defer stmt.Close()
+
+	for i := range photos {
+		photos[i].AlbumID = albumID
+		photos[i].Sort = maxSort + i + 1
+		result, err := stmt.Exec(albumID, photos[i].PhotoUrl, photos[i].PhotoName, photos[i].Sort)
+		if err != nil {
+			return fmt.Errorf("添加照片失败: %w", err)
+		}
+		if photos[i].ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("获取照片ID失败: %w", err)
+		}
+	}
+
+	if cover == "" {
+		if _, err := tx.Exec("UPDATE album SET album_cover = ?, updated_time = NOW() WHERE id = ?", photos[0].PhotoUrl, albumID); err != nil {
+			return fmt.Errorf("设置相册封面失败: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交添加照片事务失败: %w", err)
+	}
+	return nil
+}
+
+// DeletePhotos 删除相册中的照片，返回实际删除的数量
+func DeletePhotos(albumID int64, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	args := []interface{}{albumID}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	result, err := db.DB.Exec(
+		"DELETE FROM photo WHERE album_id = ? AND id IN ("+placeholders(len(ids))+")",
+		args...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("删除照片失败: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("获取删除数量失败: %w", err)
+	}
+	return affected, nil
+}
+
+// SortPhotos 按给定顺序重排相册中的照片，ids 必须恰好是相册中的全部照片
+func SortPhotos(albumID int64, ids []int64) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM photo WHERE album_id = ? FOR UPDATE", albumID).Scan(&count); err != nil {
+		return fmt.Errorf("获取照片数量失败: %w", err)
+	}
+	if count != len(ids) {
+		return ErrPhotoNotFound
+	}
+
+	// 排序值没变化时更新行数为 0，不能用来判断照片是否属于相册，先统一确认
+	seen := make(map[int64]bool, len(ids))
+	args := []interface{}{albumID}
+	for _, id := range ids {
+		if seen[id] {
+			return ErrPhotoNotFound
+		}
+		seen[id] = true
+		args = append(args, id)
+	}
+	if len(ids) > 0 {
+		if err := tx.QueryRow(
+			"SELECT COUNT(*) FROM photo WHERE album_id = ? AND id IN ("+placeholders(len(ids))+")", args...,
+		).Scan(&count); err != nil {
+			return fmt.Errorf("获取照片数量失败: %w", err)
+		}
+		if count != len(ids) {
+			return ErrPhotoNotFound
+		}
+	}
+
+	stmt, err := tx.Prepare("UPDATE photo SET sort = ?, updated_time = NOW() WHERE id = ? AND album_id = ?")
+	if err != nil {
+		return fmt.Errorf("预处理排序语句失败: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, id := range ids {
+		if _, err := stmt.Exec(i+1, id, albumID); err != nil {
+			return fmt.Errorf("更新照片排序失败: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交排序事务失败: %w", err)
+	}
+	return nil
+}
+
+// requireAffected 没有更新到任何行时返回 notFound
+func requireAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("获取更新行数失败: %w", err)
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// requireExists 更新后确认记录存在。值未变化时 MySQL 也会返回 0 行，
+// 没有更新到任何行时再按 where 条件查询一次，记录确实不存在才返回 notFound
+func requireExists(result sql.Result, table, where string, notFound error, args ...interface{}) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("获取更新行数失败: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+	var exists int
+	if err := db.DB.QueryRow("SELECT 1 FROM "+table+" WHERE "+where, args...).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return notFound
+		}
+		return fmt.Errorf("确认记录是否存在失败: %w", err)
+	}
+	return nil
+}
+
+// placeholders 生成 n 个以逗号分隔的占位符
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// AttachPhotoVariants 填充照片的缩略图和中图地址
+func AttachPhotoVariants(photos []Photo) error {
+	urls := make([]string, 0, len(photos))
+	for _, photo := range photos {
+		urls = append(urls, photo.PhotoUrl)
+	}
+	variants, err := GetImageVariants(urls)
+	if err != nil {
+		return err
+	}
+	for i := range photos {
+		photos[i].ThumbUrl, photos[i].MediumUrl = photos[i].PhotoUrl, photos[i].PhotoUrl
+		if v, ok := variants[photos[i].PhotoUrl]; ok {
+			photos[i].ThumbUrl, photos[i].MediumUrl = v.ThumbURL, v.MediumURL
+		}
+	}
+	return nil
+}
+
+// AttachAlbumVariants 填充相册封面的缩略图地址
+func AttachAlbumVariants(albums []Album) error {
+	urls := make([]string, 0, len(albums))
+	for _, album := range albums {
+		urls = append(urls, album.AlbumCover)
+	}
+	variants, err := GetImageVariants(urls)
+	if err != nil {
+		return err
+	}
+	for i := range albums {
+		albums[i].AlbumCoverThumb = albums[i].AlbumCover
+		if v, ok := variants[albums[i].AlbumCover]; ok {
+			albums[i].AlbumCoverThumb = v.ThumbURL
+		}
+	}
+	return nil
 }
@@ -1,9 +1,27 @@
 package models
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// 文章状态
+const (
+	ArticleStatusPublic  = 1 // 公开
+	ArticleStatusPrivate = 2 // 私密
+	ArticleStatusDraft   = 3 // 草稿
+	ArticleStatusDeleted = 4 // 已删除
 )
 
+// ErrArticleNotFound 文章不存在
+var ErrArticleNotFound = errors.New("文章不存在")
+
 // Article 文章模型
 type Article struct {
 	ID          string    `json:"id" db:"id"`
@@ -15,8 +33,12 @@ type Article struct {
 	IsTop       int       `json:"is_top" db:"is_top"`
 	Status      int       `json:"status" db:"status"`
 	CategoryID  string    `json:"category_id" db:"category_id"`
+	TagIDs      []string  `json:"tag_ids" db:"-"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	// 分类名和标签名，查询时填充，创建和更新文章时忽略
+	CategoryName string   `json:"category_name" db:"-"`
+	TagNames     []string `json:"tag_names" db:"-"`
 }
 
 // ArticleDetails 文章详情模型
@@ -27,6 +49,8 @@ type ArticleDetails struct {
 	NextArticle          *ArticlePreview  `json:"next_article"`
 	RecommendArticleList []ArticlePreview `json:"recommend_article_list"`
 	NewestArticleList    []ArticlePreview `json:"newest_article_list"`
+	// 从顶级分类到文章所属分类的路径，用于面包屑
+	CategoryPath []CategoryCrumb `json:"category_path"`
 }
 
 // ArticlePreview 文章预览模型
@@ -46,50 +70,357 @@ type ArticleArchive struct {
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
 
-// GetArticles 获取文章列表
+// articleColumns 查询文章的列，需要配合 articleFrom 使用
+const articleColumns = `a.id, a.article_title, a.article_content, a.article_cover, a.article_type, a.original_url, a.is_top,
+	a.status, a.category_id, IFNULL(c.name, ''), a.created_time, a.updated_time`
+
+// articleFrom 文章及其分类
+const articleFrom = " FROM article a LEFT JOIN category c ON c.id = a.category_id"
+
+// articleOrder 列表的默认排序，置顶文章在前、其余按发布时间倒序
+const articleOrder = " ORDER BY a.is_top DESC, a.created_time DESC, a.id DESC"
+
+// queryArticles 查询文章列表，并补充每篇文章的标签
+func queryArticles(query string, args ...interface{}) ([]Article, error) {
+	rows, err := db.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("获取文章列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []Article{}
+	for rows.Next() {
+		var article Article
+		err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.Cover, &article.Type, &article.OriginalUrl,
+			&article.IsTop, &article.Status, &article.CategoryID, &article.CategoryName, &article.CreatedAt, &article.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("扫描文章行失败: %w", err)
+		}
+		articles = append(articles, article)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历文章行失败: %w", err)
+	}
+	if err := fillArticleTags(articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// fillArticleTags 批量查询文章的标签，填充标签ID和标签名
+func fillArticleTags(articles []Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	ids := make([]interface{}, len(articles))
+	for i, article := range articles {
+		ids[i] = article.ID
+	}
+	rows, err := db.DB.Query(
+		`SELECT r.article_id, t.id, t.name FROM relevance r JOIN tag t ON t.id = r.tag_id
+		WHERE r.article_id IN (`+placeholders(len(ids))+`) ORDER BY t.id ASC`,
+		ids...,
+	)
+	if err != nil {
+		return fmt.Errorf("获取文章标签失败: %w", err)
+	}
+	defer rows.Close()
+
+	index := make(map[string]int, len(articles))
+	for i := range articles {
+		articles[i].TagIDs = []string{}
+		articles[i].TagNames = []string{}
+		index[articles[i].ID] = i
+	}
+	for rows.Next() {
+		var articleID, tagID, name string
+		if err := rows.Scan(&articleID, &tagID, &name); err != nil {
+			return fmt.Errorf("扫描文章标签行失败: %w", err)
+		}
+		if i, ok := index[articleID]; ok {
+			articles[i].TagIDs = append(articles[i].TagIDs, tagID)
+			articles[i].TagNames = append(articles[i].TagNames, name)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("遍历文章标签行失败: %w", err)
+	}
+	return nil
+}
+
+// GetArticles 分页获取公开文章，置顶文章在前、其余按发布时间倒序
 func GetArticles(limit, offset int) ([]Article, error) {
-	// 实现获取文章列表逻辑
-	return []Article{}, nil
+	return queryArticles(
+		"SELECT "+articleColumns+articleFrom+" WHERE a.status = ?"+articleOrder+" LIMIT ? OFFSET ?",
+		ArticleStatusPublic, limit, offset,
+	)
 }
 
-// GetArticleByID 根据ID获取文章
+// GetArticleByID 根据ID获取文章，不限状态，文章不存在时返回 nil；
+// 对外展示时应使用 GetPublicArticle 或自行检查状态
 func GetArticleByID(id string) (*Article, error) {
-	// 实现根据ID获取文章逻辑
-	return &Article{}, nil
+	articles, err := queryArticles("SELECT "+articleColumns+articleFrom+" WHERE a.id = ?", id)
+	if err != nil || len(articles) == 0 {
+		return nil, err
+	}
+	return &articles[0], nil
 }
 
-// CreateArticle 创建文章
+// GetPublicArticle 获取一篇公开文章，文章不存在或未公开时返回 nil
+func GetPublicArticle(id string) (*Article, error) {
+	article, err := GetArticleByID(id)
+	if err != nil || article == nil || article.Status != ArticleStatusPublic {
+		return nil, err
+	}
+	return article, nil
+}
+
+// GetPublicArticles 按发布时间倒序获取最新的公开文章，用于订阅源和预渲染页面；
+// categoryID、tagID 不为空时只返回该分类（含子孙分类）或标签下的文章
+func GetPublicArticles(categoryID, tagID string, limit int) ([]Article, error) {
+	where := " WHERE a.status = ?"
+	args := []interface{}{ArticleStatusPublic}
+	if categoryID != "" {
+		where += " AND a.category_id IN (" + categorySubtreeQuery + ")"
+		args = append(args, categoryID)
+	}
+	if tagID != "" {
+		where += " AND a.id IN (SELECT article_id FROM relevance WHERE tag_id = ?)"
+		args = append(args, tagID)
+	}
+	return queryArticles(
+		"SELECT "+articleColumns+articleFrom+where+" ORDER BY a.created_time DESC, a.id DESC LIMIT ?",
+		append(args, limit)...,
+	)
+}
+
+// CreateArticle 创建文章并写入标签关联，同一事务中更新分类和标签的文章数
 func CreateArticle(article *Article) error {
-	// 实现创建文章逻辑
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	article.TagIDs = uniqueNonEmpty(article.TagIDs)
+	if err := checkArticleTaxonomy(tx, article); err != nil {
+		return err
+	}
+	result, err := tx.Exec(
+		`INSERT INTO article (article_title, article_content, article_cover, article_type, original_url, is_top, status,
+			category_id, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		article.Title, article.Content, article.Cover, article.Type, article.OriginalUrl, article.IsTop, article.Status,
+		article.CategoryID, article.CreatedAt, article.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("创建文章失败: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("获取文章ID失败: %w", err)
+	}
+	article.ID = strconv.FormatInt(id, 10)
+
+	if err := saveArticleTags(tx, article.ID, article.TagIDs); err != nil {
+		return err
+	}
+	if err := refreshCategoryCounts(tx, []string{article.CategoryID}); err != nil {
+		return err
+	}
+	if err := refreshTagCounts(tx, article.TagIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交创建文章事务失败: %w", err)
+	}
 	return nil
 }
 
-// UpdateArticle 更新文章
+// UpdateArticle 更新文章并替换标签关联，同一事务中更新新旧分类和标签的文章数
 func UpdateArticle(article *Article) error {
-	// 实现更新文章逻辑
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldCategoryID string
+	err = tx.QueryRow("SELECT category_id FROM article WHERE id = ? FOR UPDATE", article.ID).Scan(&oldCategoryID)
+	if err == sql.ErrNoRows {
+		return ErrArticleNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("获取文章失败: %w", err)
+	}
+	oldTagIDs, err := queryStrings(tx, "SELECT tag_id FROM relevance WHERE article_id = ?", article.ID)
+	if err != nil {
+		return fmt.Errorf("获取文章标签失败: %w", err)
+	}
+
+	article.TagIDs = uniqueNonEmpty(article.TagIDs)
+	if err := checkArticleTaxonomy(tx, article); err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`UPDATE article SET article_title = ?, article_content = ?, article_cover = ?, article_type = ?, original_url = ?,
+			is_top = ?, status = ?, category_id = ?, updated_time = ?
+		WHERE id = ?`,
+		article.Title, article.Content, article.Cover, article.Type, article.OriginalUrl,
+		article.IsTop, article.Status, article.CategoryID, article.UpdatedAt, article.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("更新文章失败: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM relevance WHERE article_id = ?", article.ID); err != nil {
+		return fmt.Errorf("删除文章标签失败: %w", err)
+	}
+	if err := saveArticleTags(tx, article.ID, article.TagIDs); err != nil {
+		return err
+	}
+	// 状态变化也会影响文章数，新旧分类和标签都要重新计算
+	if err := refreshCategoryCounts(tx, []string{oldCategoryID, article.CategoryID}); err != nil {
+		return err
+	}
+	if err := refreshTagCounts(tx, append(oldTagIDs, article.TagIDs...)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交更新文章事务失败: %w", err)
+	}
 	return nil
 }
 
-// DeleteArticle 删除文章
+// DeleteArticle 删除文章及其标签关联，同一事务中更新分类和标签的文章数
 func DeleteArticle(id string) error {
-	// 实现删除文章逻辑
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	var categoryID string
+	err = tx.QueryRow("SELECT category_id FROM article WHERE id = ? FOR UPDATE", id).Scan(&categoryID)
+	if err == sql.ErrNoRows {
+		return ErrArticleNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("获取文章失败: %w", err)
+	}
+	tagIDs, err := queryStrings(tx, "SELECT tag_id FROM relevance WHERE article_id = ?", id)
+	if err != nil {
+		return fmt.Errorf("获取文章标签失败: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM relevance WHERE article_id = ?", id); err != nil {
+		return fmt.Errorf("删除文章标签失败: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM article WHERE id = ?", id); err != nil {
+		return fmt.Errorf("删除文章失败: %w", err)
+	}
+	if err := refreshCategoryCounts(tx, []string{categoryID}); err != nil {
+		return err
+	}
+	if err := refreshTagCounts(tx, tagIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交删除文章事务失败: %w", err)
+	}
 	return nil
 }
 
-// GetArticlesByCategoryID 根据分类ID获取文章
-func GetArticlesByCategoryID(categoryID string, limit, offset int) ([]Article, error) {
-	// 实现根据分类ID获取文章逻辑
-	return []Article{}, nil
+// checkArticleTaxonomy 在事务中确认文章的分类和标签都存在
+func checkArticleTaxonomy(tx *sql.Tx, article *Article) error {
+	var exists int
+	err := tx.QueryRow("SELECT 1 FROM category WHERE id = ?", article.CategoryID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return ErrCategoryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("获取分类失败: %w", err)
+	}
+	if len(article.TagIDs) == 0 {
+		return nil
+	}
+
+	var count int
+	err = tx.QueryRow(
+		"SELECT COUNT(*) FROM tag WHERE id IN ("+placeholders(len(article.TagIDs))+")",
+		stringArgs(article.TagIDs)...,
+	).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("获取标签失败: %w", err)
+	}
+	if count != len(article.TagIDs) {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// saveArticleTags 写入文章的标签关联
+func saveArticleTags(tx *sql.Tx, articleID string, tagIDs []string) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	values := make([]string, len(tagIDs))
+	args := make([]interface{}, 0, len(tagIDs)*2)
+	for i, tagID := range tagIDs {
+		values[i] = "(?, ?)"
+		args = append(args, articleID, tagID)
+	}
+	if _, err := tx.Exec("INSERT INTO relevance (article_id, tag_id) VALUES "+strings.Join(values, ", "), args...); err != nil {
+		return fmt.Errorf("保存文章标签失败: %w", err)
+	}
+	return nil
+}
+
+// GetArticlesByCategoryID 根据分类ID分页获取公开文章，置顶文章在前、其余按发布时间倒序；
+// includeDescendants 为 true 时包含全部子孙分类的文章
+func GetArticlesByCategoryID(categoryID string, includeDescendants bool, limit, offset int) ([]Article, int64, error) {
+	where := " WHERE a.status = ? AND a.category_id = ?"
+	if includeDescendants {
+		where = " WHERE a.status = ? AND a.category_id IN (" + categorySubtreeQuery + ")"
+	}
+	args := []interface{}{ArticleStatusPublic, categoryID}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM article a"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取分类文章总数失败: %w", err)
+	}
+
+	articles, err := queryArticles(
+		"SELECT "+articleColumns+articleFrom+where+articleOrder+" LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取分类文章失败: %w", err)
+	}
+	return articles, total, nil
 }
 
-// GetArticlesByTagID 根据标签ID获取文章
+// GetArticlesByTagID 根据标签ID分页获取公开文章，置顶文章在前、其余按发布时间倒序
 func GetArticlesByTagID(tagID string, limit, offset int) ([]Article, error) {
-	// 实现根据标签ID获取文章逻辑
-	return []Article{}, nil
+	articles, err := queryArticles(
+		"SELECT "+articleColumns+articleFrom+
+			" WHERE a.status = ? AND a.id IN (SELECT article_id FROM relevance WHERE tag_id = ?)"+articleOrder+" LIMIT ? OFFSET ?",
+		ArticleStatusPublic, tagID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取标签文章失败: %w", err)
+	}
+	return articles, nil
 }
 
-// GetArticleCount 获取文章总数
+// GetArticleCount 获取公开文章总数
 func GetArticleCount() (int64, error) {
-	// 实现获取文章总数逻辑
-	return 0, nil
+	var count int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM article WHERE status = ?", ArticleStatusPublic).Scan(&count); err != nil {
+		return 0, fmt.Errorf("获取文章总数失败: %w", err)
+	}
+	return count, nil
 }
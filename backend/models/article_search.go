@@ -0,0 +1,170 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// ArticleSearchDoc 文章检索文档
+type ArticleSearchDoc struct {
+	ID           string
+	Title        string
+	Content      string
+	CategoryName string
+	TagNames     []string
+}
+
+// ArticleSearchMatch 检索命中的文档和相关度
+type ArticleSearchMatch struct {
+	Doc   *ArticleSearchDoc
+	Score float64
+}
+
+// GetArticleSearchDocs 获取公开文章的检索文档，id 为空时返回全部公开文章
+func GetArticleSearchDocs(id string) ([]*ArticleSearchDoc, error) {
+	query := `SELECT a.id, a.article_title, a.article_content, IFNULL(c.name, '')
+		FROM article a LEFT JOIN category c ON c.id = a.category_id
+		WHERE a.status = ?`
+	args := []interface{}{ArticleStatusPublic}
+	if id != "" {
+		query += " AND a.id = ?"
+		args = append(args, id)
+	}
+
+	rows, err := db.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("获取文章检索文档失败: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		docs []*ArticleSearchDoc
+		ids  []interface{}
+	)
+	for rows.Next() {
+		doc := &ArticleSearchDoc{}
+		if err := rows.Scan(&doc.ID, &doc.Title, &doc.Content, &doc.CategoryName); err != nil {
+			return nil, fmt.Errorf("扫描文章检索文档行失败: %w", err)
+		}
+		docs = append(docs, doc)
+		ids = append(ids, doc.ID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历文章检索文档行失败: %w", err)
+	}
+	if len(docs) == 0 {
+		return docs, nil
+	}
+
+	tagNames, err := getArticleTagNames(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		doc.TagNames = tagNames[doc.ID]
+	}
+
+	return docs, nil
+}
+
+// getArticleTagNames 批量获取文章的标签名，按文章ID分组
+func getArticleTagNames(ids []interface{}) (map[string][]string, error) {
+	rows, err := db.DB.Query(
+		"SELECT r.article_id, t.name FROM relevance r JOIN tag t ON t.id = r.tag_id WHERE r.article_id IN ("+placeholders(len(ids))+")",
+		ids...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取文章标签失败: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string][]string)
+	for rows.Next() {
+		var articleID, name string
+		if err := rows.Scan(&articleID, &name); err != nil {
+			return nil, fmt.Errorf("扫描文章标签行失败: %w", err)
+		}
+		names[articleID] = append(names[articleID], name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历文章标签行失败: %w", err)
+	}
+	return names, nil
+}
+
+// SaveArticleSearchIndex 写入或更新文章的全文索引，content 应为去掉 Markdown 标记后的纯文本
+func SaveArticleSearchIndex(doc *ArticleSearchDoc) error {
+	_, err := db.DB.Exec(
+		`INSERT INTO article_search (article_id, title, content, category_name, tag_names, updated_time)
+		VALUES (?, ?, ?, ?, ?, NOW())
+		ON DUPLICATE KEY UPDATE title = VALUES(title), content = VALUES(content),
+			category_name = VALUES(category_name), tag_names = VALUES(tag_names), updated_time = VALUES(updated_time)`,
+		doc.ID, doc.Title, doc.Content, doc.CategoryName, strings.Join(doc.TagNames, "\n"),
+	)
+	if err != nil {
+		return fmt.Errorf("保存文章全文索引失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteArticleSearchIndex 删除文章的全文索引
+func DeleteArticleSearchIndex(id string) error {
+	if _, err := db.DB.Exec("DELETE FROM article_search WHERE article_id = ?", id); err != nil {
+		return fmt.Errorf("删除文章全文索引失败: %w", err)
+	}
+	return nil
+}
+
+// ClearArticleSearchIndex 清空文章全文索引
+func ClearArticleSearchIndex() error {
+	if _, err := db.DB.Exec("DELETE FROM article_search"); err != nil {
+		return fmt.Errorf("清空文章全文索引失败: %w", err)
+	}
+	return nil
+}
+
+// SearchArticleIndex 使用 MySQL 全文索引（ngram 分词）检索文章，标题命中的权重更高，返回当前页的命中和总数
+func SearchArticleIndex(query string, limit, offset int) ([]*ArticleSearchMatch, int64, error) {
+	const match = "MATCH(title, content, category_name, tag_names) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM article_search WHERE "+match, query).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取检索结果总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		`SELECT article_id, title, content, category_name, tag_names,
+			MATCH(title) AGAINST(? IN NATURAL LANGUAGE MODE) * 3 + `+match+` AS score
+		FROM article_search WHERE `+match+`
+		ORDER BY score DESC, article_id DESC LIMIT ? OFFSET ?`,
+		query, query, query, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("检索文章失败: %w", err)
+	}
+	defer rows.Close()
+
+	matches := []*ArticleSearchMatch{}
+	for rows.Next() {
+		var (
+			doc      ArticleSearchDoc
+			tagNames string
+			score    sql.NullFloat64
+		)
+		if err := rows.Scan(&doc.ID, &doc.Title, &doc.Content, &doc.CategoryName, &tagNames, &score); err != nil {
+			return nil, 0, fmt.Errorf("扫描检索结果行失败: %w", err)
+		}
+		if tagNames != "" {
+			doc.TagNames = strings.Split(tagNames, "\n")
+		}
+		matches = append(matches, &ArticleSearchMatch{Doc: &doc, Score: score.Float64})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历检索结果行失败: %w", err)
+	}
+
+	return matches, total, nil
+}
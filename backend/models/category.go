@@ -2,25 +2,85 @@ package models
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/jayden/personal-blog-backend/db"
 )
 
+var (
+	// ErrCategoryNotFound 分类不存在
+	ErrCategoryNotFound = errors.New("分类不存在")
+	// ErrCategoryNameExists 分类名已存在
+	ErrCategoryNameExists = errors.New("分类名已存在")
+	// ErrCategorySlugExists 分类别名已存在
+	ErrCategorySlugExists = errors.New("分类别名已存在")
+	// ErrCategoryInUse 分类下还有文章
+	ErrCategoryInUse = errors.New("分类下还有文章，请先移动文章或合并到其他分类")
+)
+
 // Category 分类模型
 // @Description 文章分类信息
 type Category struct {
 	// 分类ID
 	ID string `json:"id" example:"1"`
+	// 上级分类ID，顶级分类为0
+	ParentID string `json:"parent_id" example:"0"`
 	// 分类名称
 	Name string `json:"name" example:"技术"`
-	// 文章数量
+	// 别名，用于地址
+	Slug string `json:"slug" example:"ji-shu"`
+	// 公开文章数量，包含全部子孙分类的文章
 	Count int `json:"count" example:"10"`
+	// 创建时间
+	CreatedAt int64 `json:"created_at" example:"1700000000"`
+	// 更新时间
+	UpdatedAt int64 `json:"updated_at" example:"1700000000"`
+}
+
+// CategoryVO 分类列表中返回的分类
+// @Description 分类及其公开文章数
+type CategoryVO struct {
+	ID           string `json:"id" example:"1"`
+	ParentID     string `json:"parent_id" example:"0"`
+	CategoryName string `json:"category_name" example:"技术"`
+	Slug         string `json:"slug" example:"ji-shu"`
+	ArticleCount int    `json:"article_count" example:"10"`
+	CreatedAt    int64  `json:"created_at" example:"1700000000"`
+	UpdatedAt    int64  `json:"updated_at" example:"1700000000"`
+}
+
+// categoryColumns 查询分类的列
+const categoryColumns = "id, parent_id, name, IFNULL(slug, ''), count, created_time, updated_time"
+
+// scanCategory 扫描一行分类记录
+func scanCategory(scanner interface{ Scan(...interface{}) error }) (*Category, error) {
+	var (
+		category                 Category
+		createdTime, updatedTime time.Time
+	)
+	if err := scanner.Scan(&category.ID, &category.ParentID, &category.Name, &category.Slug, &category.Count, &createdTime, &updatedTime); err != nil {
+		return nil, err
+	}
+	category.CreatedAt = createdTime.Unix()
+	category.UpdatedAt = updatedTime.Unix()
+	return &category, nil
+}
+
+// CountCategories 获取分类总数
+func CountCategories() (int64, error) {
+	var count int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM category").Scan(&count); err != nil {
+		return 0, fmt.Errorf("获取分类总数失败: %w", err)
+	}
+	return count, nil
 }
 
 // GetCategories 获取所有分类
 func GetCategories() ([]*Category, error) {
-	rows, err := db.DB.Query("SELECT id, name, count FROM category ORDER BY count DESC")
+	rows, err := db.DB.Query("SELECT " + categoryColumns + " FROM category ORDER BY count DESC")
 	if err != nil {
 		return nil, fmt.Errorf("获取分类列表失败: %w", err)
 	}
@@ -28,8 +88,7 @@ func GetCategories() ([]*Category, error) {
 
 	var categories []*Category
 	for rows.Next() {
-		category := &Category{}
-		scanErr := rows.Scan(&category.ID, &category.Name, &category.Count)
+		category, scanErr := scanCategory(rows)
 		if scanErr != nil {
 			return nil, fmt.Errorf("扫描分类行失败: %w", scanErr)
 		}
@@ -43,10 +102,55 @@ func GetCategories() ([]*Category, error) {
 	return categories, nil
 }
 
-// GetCategoryByID 根据ID获取分类
-func GetCategoryByID(id string) (*Category, error) {
-	category := &Category{}
-	err := db.DB.QueryRow("SELECT id, name, count FROM category WHERE id = ?", id).Scan(&category.ID, &category.Name, &category.Count)
+// GetCategoryList 分页获取分类列表，按文章数倒序；name 不为空时按分类名模糊匹配
+func GetCategoryList(name string, limit, offset int) ([]*CategoryVO, int64, error) {
+	where := ""
+	var args []interface{}
+	if name != "" {
+		where = " WHERE name LIKE ?"
+		args = append(args, "%"+escapeLike(name)+"%")
+	}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM category"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取分类总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT "+categoryColumns+" FROM category"+where+" ORDER BY count DESC, id ASC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取分类列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*CategoryVO{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描分类行失败: %w", err)
+		}
+		list = append(list, &CategoryVO{
+			ID:           category.ID,
+			ParentID:     category.ParentID,
+			CategoryName: category.Name,
+			Slug:         category.Slug,
+			ArticleCount: category.Count,
+			CreatedAt:    category.CreatedAt,
+			UpdatedAt:    category.UpdatedAt,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历分类行失败: %w", err)
+	}
+	return list, total, nil
+}
+
+// getCategory 按条件获取一个分类，不存在时返回 nil
+func getCategory(where string, arg interface{}) (*Category, error) {
+	category, err := scanCategory(db.DB.QueryRow("SELECT "+categoryColumns+" FROM category WHERE "+where, arg))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -56,42 +160,215 @@ func GetCategoryByID(id string) (*Category, error) {
 	return category, nil
 }
 
+// GetCategoryByID 根据ID获取分类
+func GetCategoryByID(id string) (*Category, error) {
+	return getCategory("id = ?", id)
+}
+
 // GetCategoryByName 根据名称获取分类
 func GetCategoryByName(name string) (*Category, error) {
-	category := &Category{}
-	err := db.DB.QueryRow("SELECT id, name, count FROM category WHERE name = ?", name).Scan(&category.ID, &category.Name, &category.Count)
+	return getCategory("name = ?", name)
+}
+
+// GetCategoryBySlug 根据别名获取分类
+func GetCategoryBySlug(slug string) (*Category, error) {
+	return getCategory("slug = ?", slug)
+}
+
+// FindCategory 按ID或别名获取分类，先按ID查找
+func FindCategory(key string) (*Category, error) {
+	category, err := GetCategoryByID(key)
+	if err != nil || category != nil {
+		return category, err
+	}
+	return GetCategoryBySlug(key)
+}
+
+// checkCategory 检查分类名是否被其他分类使用，并在未指定别名时按名称生成不重复的别名
+func checkCategory(category *Category) error {
+	exists, err := taxonomyCategory.nameExists(category.Name, category.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+		return err
+	}
+	if exists {
+		return ErrCategoryNameExists
+	}
+	if category.Slug != "" {
+		exists, err := taxonomyCategory.slugExists(category.Slug, category.ID)
+		if err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("获取分类失败: %w", err)
+		if exists {
+			return ErrCategorySlugExists
+		}
+		return nil
 	}
-	return category, nil
+	category.Slug, err = taxonomyCategory.uniqueSlug(category.Name, category.ID)
+	return err
 }
 
-// CreateCategory 创建新分类
+// CreateCategory 创建新分类，未指定别名时按名称生成；名称或别名重复、上级分类不存在时返回对应错误
 func CreateCategory(category *Category) error {
-	_, err := db.DB.Exec("INSERT INTO category (id, name, count) VALUES (?, ?, ?)", category.ID, category.Name, category.Count)
+	if category.ParentID == "" {
+		category.ParentID = RootCategoryID
+	}
+	if err := checkCategory(category); err != nil {
+		return err
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkCategoryParent(tx, "", category.ParentID); err != nil {
+		return err
+	}
+	now := time.Now()
+	result, err := tx.Exec(
+		"INSERT INTO category (parent_id, name, slug, count, created_time, updated_time) VALUES (?, ?, ?, 0, ?, ?)",
+		category.ParentID, category.Name, category.Slug, now, now,
+	)
 	if err != nil {
 		return fmt.Errorf("创建分类失败: %w", err)
 	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("获取分类ID失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交创建分类事务失败: %w", err)
+	}
+	category.ID = strconv.FormatInt(id, 10)
+	category.Count = 0
+	category.CreatedAt = now.Unix()
+	category.UpdatedAt = now.Unix()
 	return nil
 }
 
-// UpdateCategory 更新分类
+// UpdateCategory 修改分类名、别名和上级分类，别名为空时按新名称重新生成；
+// 上级分类变化时同一事务中重新计算新旧祖先分类的文章数
 func UpdateCategory(category *Category) error {
-	_, err := db.DB.Exec("UPDATE category SET name = ?, count = ? WHERE id = ?", category.Name, category.Count, category.ID)
+	if category.ParentID == "" {
+		category.ParentID = RootCategoryID
+	}
+	if err := checkCategory(category); err != nil {
+		return err
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := scanCategory(tx.QueryRow("SELECT "+categoryColumns+" FROM category WHERE id = ? FOR UPDATE", category.ID))
+	if err == sql.ErrNoRows {
+		return ErrCategoryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("获取分类失败: %w", err)
+	}
+	if err := checkCategoryParent(tx, category.ID, category.ParentID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"UPDATE category SET parent_id = ?, name = ?, slug = ?, updated_time = NOW() WHERE id = ?",
+		category.ParentID, category.Name, category.Slug, category.ID,
+	)
 	if err != nil {
 		return fmt.Errorf("更新分类失败: %w", err)
 	}
+	if existing.ParentID != category.ParentID {
+		if err := refreshCategoryCounts(tx, []string{existing.ParentID, category.ParentID}); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交更新分类事务失败: %w", err)
+	}
+	category.Count = existing.Count
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now().Unix()
 	return nil
 }
 
-// DeleteCategory 删除分类
+// DeleteCategory 删除分类，分类下还有子分类或文章（含草稿和私密文章）时返回对应错误
 func DeleteCategory(id string) error {
-	_, err := db.DB.Exec("DELETE FROM category WHERE id = ?", id)
+	var children int
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM category WHERE parent_id = ?", id).Scan(&children); err != nil {
+		return fmt.Errorf("获取子分类数失败: %w", err)
+	}
+	if children > 0 {
+		return ErrCategoryHasChildren
+	}
+	var count int
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM article WHERE category_id = ?", id).Scan(&count); err != nil {
+		return fmt.Errorf("获取分类文章数失败: %w", err)
+	}
+	if count > 0 {
+		return ErrCategoryInUse
+	}
+
+	result, err := db.DB.Exec("DELETE FROM category WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("删除分类失败: %w", err)
 	}
-	return nil
+	return requireAffected(result, ErrCategoryNotFound)
+}
+
+// MergeCategory 把 sourceID 分类下的文章和子分类移到 targetID 分类后删除 sourceID，返回被移动的文章ID；
+// targetID 是 sourceID 的子孙分类时返回 ErrCategoryCycle
+func MergeCategory(sourceID, targetID string) ([]string, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := taxonomyCategory.lock(tx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	if err := checkCategoryParent(tx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	// 来源分类删除后就查不到它的祖先了，先记下来
+	sourceAncestors, err := categoryAncestorIDs(tx, []string{sourceID})
+	if err != nil {
+		return nil, err
+	}
+	articleIDs, err := queryStrings(tx, "SELECT id FROM article WHERE category_id = ?", sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类文章失败: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE article SET category_id = ? WHERE category_id = ?", targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("移动分类文章失败: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE category SET parent_id = ? WHERE parent_id = ?", targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("移动子分类失败: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM category WHERE id = ?", sourceID); err != nil {
+		return nil, fmt.Errorf("删除分类失败: %w", err)
+	}
+	if err := refreshCategoryCounts(tx, append(sourceAncestors, targetID)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("提交合并分类事务失败: %w", err)
+	}
+	return articleIDs, nil
+}
+
+// GetArticleIDsByCategoryID 获取分类下全部文章的ID，用于分类改名后同步检索索引
+func GetArticleIDsByCategoryID(id string) ([]string, error) {
+	ids, err := queryStrings(db.DB, "SELECT id FROM article WHERE category_id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类文章失败: %w", err)
+	}
+	return ids, nil
 }
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+var (
+	// ErrCategoryParentNotFound 上级分类不存在
+	ErrCategoryParentNotFound = errors.New("上级分类不存在")
+	// ErrCategoryCycle 上级分类不能是自身或自身的子孙分类
+	ErrCategoryCycle = errors.New("上级分类不能是自身或自身的子孙分类")
+	// ErrCategoryHasChildren 分类下还有子分类
+	ErrCategoryHasChildren = errors.New("分类下还有子分类，请先移动子分类或合并到其他分类")
+)
+
+// RootCategoryID 顶级分类的上级分类ID
+const RootCategoryID = "0"
+
+// categorySubtreeQuery 查询一个分类及其全部子孙分类的ID，可以作为 IN 子查询使用
+const categorySubtreeQuery = `WITH RECURSIVE subtree AS (
+		SELECT id FROM category WHERE id = ?
+		UNION ALL
+		SELECT c.id FROM category c JOIN subtree s ON c.parent_id = s.id
+	) SELECT id FROM subtree`
+
+// CategoryNode 分类树中的节点
+// @Description 分类树节点，文章数包含全部子孙分类的公开文章
+type CategoryNode struct {
+	ID           string          `json:"id" example:"1"`
+	CategoryName string          `json:"category_name" example:"后端"`
+	Slug         string          `json:"slug" example:"hou-duan"`
+	ParentID     string          `json:"parent_id" example:"0"`
+	ArticleCount int             `json:"article_count" example:"10"`
+	Children     []*CategoryNode `json:"children"`
+}
+
+// CategoryCrumb 面包屑中的一级分类
+// @Description 从顶级分类到当前分类路径上的一个分类
+type CategoryCrumb struct {
+	ID           string `json:"id" example:"1"`
+	CategoryName string `json:"category_name" example:"后端"`
+	Slug         string `json:"slug" example:"hou-duan"`
+}
+
+// GetCategoryTree 一次查询全部分类并组装成树，同级分类按ID正序
+func GetCategoryTree() ([]*CategoryNode, error) {
+	rows, err := db.DB.Query("SELECT id, parent_id, name, IFNULL(slug, ''), count FROM category ORDER BY id ASC")
+	if err != nil {
+		return nil, fmt.Errorf("获取分类树失败: %w", err)
+	}
+	defer rows.Close()
+
+	var nodes []*CategoryNode
+	byID := make(map[string]*CategoryNode)
+	for rows.Next() {
+		node := &CategoryNode{Children: []*CategoryNode{}}
+		if err := rows.Scan(&node.ID, &node.ParentID, &node.CategoryName, &node.Slug, &node.ArticleCount); err != nil {
+			return nil, fmt.Errorf("扫描分类行失败: %w", err)
+		}
+		nodes = append(nodes, node)
+		byID[node.ID] = node
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历分类行失败: %w", err)
+	}
+
+	// 上级分类已被删除的分类按顶级分类处理
+	roots := []*CategoryNode{}
+	for _, node := range nodes {
+		if parent, ok := byID[node.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+// GetCategoryPath 获取从顶级分类到该分类的路径，用于面包屑；分类不存在时返回空切片
+func GetCategoryPath(id string) ([]CategoryCrumb, error) {
+	rows, err := db.DB.Query(
+		`WITH RECURSIVE path AS (
+			SELECT id, parent_id, 0 AS depth FROM category WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.parent_id, p.depth + 1 FROM category c JOIN path p ON c.id = p.parent_id
+		)
+		SELECT c.id, c.name, IFNULL(c.slug, '') FROM path p JOIN category c ON c.id = p.id ORDER BY p.depth DESC`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取分类路径失败: %w", err)
+	}
+	defer rows.Close()
+
+	path := []CategoryCrumb{}
+	for rows.Next() {
+		var crumb CategoryCrumb
+		if err := rows.Scan(&crumb.ID, &crumb.CategoryName, &crumb.Slug); err != nil {
+			return nil, fmt.Errorf("扫描分类路径失败: %w", err)
+		}
+		path = append(path, crumb)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历分类路径失败: %w", err)
+	}
+	return path, nil
+}
+
+// categoryAncestorIDs 在事务中获取分类及其全部祖先分类的ID
+func categoryAncestorIDs(tx *sql.Tx, ids []string) ([]string, error) {
+	ids = uniqueNonEmpty(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	ancestors, err := queryStrings(tx,
+		`WITH RECURSIVE path AS (
+			SELECT id, parent_id FROM category WHERE id IN (`+placeholders(len(ids))+`)
+			UNION ALL
+			SELECT c.id, c.parent_id FROM category c JOIN path p ON c.id = p.parent_id
+		) SELECT DISTINCT id FROM path`,
+		stringArgs(ids)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取上级分类失败: %w", err)
+	}
+	return ancestors, nil
+}
+
+// checkCategoryParent 在事务中确认上级分类存在，且不是 id 自身或其子孙分类；parentID 为顶级时直接通过
+func checkCategoryParent(tx *sql.Tx, id, parentID string) error {
+	if parentID == RootCategoryID {
+		return nil
+	}
+	if parentID == id {
+		return ErrCategoryCycle
+	}
+	path, err := categoryAncestorIDs(tx, []string{parentID})
+	if err != nil {
+		return err
+	}
+	if len(path) == 0 {
+		return ErrCategoryParentNotFound
+	}
+	for _, ancestorID := range path {
+		if ancestorID == id {
+			return ErrCategoryCycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// 聊天消息状态
+const (
+	ChatStatusNormal   = 0 // 正常
+	ChatStatusEdited   = 1 // 已编辑
+	ChatStatusRecalled = 2 // 已撤回
+	ChatStatusDeleted  = 3 // 已删除
+)
+
+// ChatMessage 聊天室消息模型
+type ChatMessage struct {
+	ID int64 `json:"id" db:"id"`
+	// 用户ID，游客发送时为空
+	UserID string `json:"user_id" db:"user_id"`
+	// 终端ID，标识游客；是游客身份的凭证，不返回给前端
+	TerminalID string `json:"-" db:"terminal_id"`
+	// 由终端ID派生的公开标识，用于区分不同游客
+	TouristID string `json:"tourist_id" db:"-"`
+	// 发送时的昵称
+	Nickname string `json:"nickname" db:"nickname"`
+	// 发送时的头像
+	Avatar string `json:"avatar" db:"avatar"`
+	// 发送人IP
+	IpAddress string `json:"ip_address" db:"ip_address"`
+	// 发送人地址
+	IpSource string `json:"ip_source" db:"ip_source"`
+	// 消息类型，目前只有 text
+	Type string `json:"type" db:"type"`
+	// 消息内容
+	Content string `json:"content" db:"content"`
+	// 状态 0正常 1已编辑 2已撤回 3已删除
+	Status    int   `json:"status" db:"status"`
+	CreatedAt int64 `json:"created_at" db:"created_at"`
+	UpdatedAt int64 `json:"updated_at" db:"updated_at"`
+}
+
+// chatMessageColumns 查询聊天消息的列
+const chatMessageColumns = `id, user_id, terminal_id, nickname, avatar, ip_address, ip_source, type, content, status,
+	created_time, updated_time`
+
+// scanChatMessage 扫描一行聊天消息记录
+func scanChatMessage(scanner interface{ Scan(...interface{}) error }) (*ChatMessage, error) {
+	var (
+		msg                      ChatMessage
+		userID                   int
+		createdTime, updatedTime time.Time
+	)
+	err := scanner.Scan(&msg.ID, &userID, &msg.TerminalID, &msg.Nickname, &msg.Avatar, &msg.IpAddress, &msg.IpSource,
+		&msg.Type, &msg.Content, &msg.Status, &createdTime, &updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	if userID > 0 {
+		msg.UserID = FormatUserID(userID)
+	}
+	msg.TouristID = TouristPublicID(msg.TerminalID)
+	msg.CreatedAt = createdTime.Unix()
+	msg.UpdatedAt = updatedTime.Unix()
+	return &msg, nil
+}
+
+// CreateChatMessage 保存聊天消息，userID 为 0 表示游客
+func CreateChatMessage(msg *ChatMessage, userID int) error {
+	now := time.Now()
+	result, err := db.DB.Exec(
+		`INSERT INTO chat_message (user_id, terminal_id, nickname, avatar, ip_address, ip_source, type, content, status,
+			created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		userID, msg.TerminalID, msg.Nickname, msg.Avatar, msg.IpAddress, msg.IpSource, msg.Type, msg.Content,
+		ChatStatusNormal, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("保存聊天消息失败: %w", err)
+	}
+	if msg.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("获取聊天消息ID失败: %w", err)
+	}
+	if userID > 0 {
+		msg.UserID = FormatUserID(userID)
+	}
+	msg.TouristID = TouristPublicID(msg.TerminalID)
+	msg.Status = ChatStatusNormal
+	msg.CreatedAt = now.Unix()
+	msg.UpdatedAt = now.Unix()
+	return nil
+}
+
+// GetRecentChatMessages 获取最近的 limit 条未撤回消息，按发送时间正序
+func GetRecentChatMessages(limit int) ([]*ChatMessage, error) {
+	rows, err := db.DB.Query(
+		"SELECT "+chatMessageColumns+" FROM chat_message WHERE status IN (?, ?) ORDER BY id DESC LIMIT ?",
+		ChatStatusNormal, ChatStatusEdited, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取聊天记录失败: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*ChatMessage{}
+	for rows.Next() {
+		msg, err := scanChatMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("扫描聊天消息行失败: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历聊天消息行失败: %w", err)
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+// GetChatMessageByID 根据ID获取聊天消息
+func GetChatMessageByID(id int64) (*ChatMessage, error) {
+	msg, err := scanChatMessage(db.DB.QueryRow("SELECT "+chatMessageColumns+" FROM chat_message WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 消息不存在
+		}
+		return nil, fmt.Errorf("获取聊天消息失败: %w", err)
+	}
+	return msg, nil
+}
+
+// RecallChatMessage 撤回聊天消息，消息不存在或已撤回时返回 false
+func RecallChatMessage(id int64) (bool, error) {
+	result, err := db.DB.Exec(
+		"UPDATE chat_message SET status = ?, updated_time = NOW() WHERE id = ? AND status IN (?, ?)",
+		ChatStatusRecalled, id, ChatStatusNormal, ChatStatusEdited,
+	)
+	if err != nil {
+		return false, fmt.Errorf("撤回聊天消息失败: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("撤回聊天消息失败: %w", err)
+	}
+	return affected > 0, nil
+}
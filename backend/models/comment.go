@@ -1,5 +1,27 @@
 package models
 
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// 评论类型
+const (
+	CommentTypeArticle = 1 // 文章
+	CommentTypeFriend  = 2 // 友链
+	CommentTypeTalk    = 3 // 说说
+)
+
+// 评论状态
+const (
+	CommentStatusNormal  = 0 // 正常
+	CommentStatusEdited  = 1 // 已编辑
+	CommentStatusDeleted = 2 // 已删除
+)
+
 // Comment 评论模型
 type Comment struct {
 	ID             int64  `json:"id" db:"id"`
@@ -9,16 +31,95 @@ type Comment struct {
 	UserID         string `json:"user_id" db:"user_id"`
 	ReplyUserID    string `json:"reply_user_id" db:"reply_user_id"`
 	CommentContent string `json:"comment_content" db:"comment_content"`
+	IpAddress      string `json:"ip_address" db:"ip_address"`
+	IpSource       string `json:"ip_source" db:"ip_source"`
 	Type           int    `json:"type" db:"type"`
 	Status         int    `json:"status" db:"status"`
 	CreatedAt      int64  `json:"created_at" db:"created_at"`
 	UpdatedAt      int64  `json:"updated_at" db:"updated_at"`
+	// 评论人信息
+	User *UserInfoVO `json:"user,omitempty" db:"-"`
+	// 被回复用户信息
+	ReplyUser *UserInfoVO `json:"reply_user,omitempty" db:"-"`
+	// 回复数，只有顶层评论有
+	ReplyCount int64 `json:"reply_count" db:"-"`
+	// 最新的几条回复，只有评论列表中的顶层评论有
+	CommentReplyList []*Comment `json:"comment_reply_list,omitempty" db:"-"`
 }
 
-// GetComments 获取评论列表
-func GetComments(topicID, parentID int64, limit, offset int) ([]Comment, error) {
-	// 实现获取评论列表逻辑
-	return []Comment{}, nil
+// commentPreviewReplies 评论列表中每条评论附带的回复数，更多回复通过回复列表分页获取
+const commentPreviewReplies = 3
+
+// commentColumns 查询评论的列
+const commentColumns = "id, topic_id, parent_id, reply_msg_id, user_id, reply_user_id, comment_content, ip_address, ip_source, type, status, created_time, updated_time"
+
+// scanComment 扫描一行评论记录
+func scanComment(scanner interface{ Scan(...interface{}) error }) (*Comment, error) {
+	var (
+		comment                  Comment
+		createdTime, updatedTime time.Time
+	)
+	err := scanner.Scan(&comment.ID, &comment.TopicID, &comment.ParentID, &comment.ReplyMsgID, &comment.UserID,
+		&comment.ReplyUserID, &comment.CommentContent, &comment.IpAddress, &comment.IpSource, &comment.Type,
+		&comment.Status, &createdTime, &updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	comment.CreatedAt = createdTime.Unix()
+	comment.UpdatedAt = updatedTime.Unix()
+	return &comment, nil
+}
+
+// queryComments 执行评论查询并扫描全部行
+func queryComments(query string, args ...interface{}) ([]*Comment, error) {
+	rows, err := db.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("获取评论列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []*Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("扫描评论行失败: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历评论行失败: %w", err)
+	}
+
+	return comments, nil
+}
+
+// GetComments 获取主题下未删除的顶层评论，按发布时间倒序，每条评论附带回复数和最新的几条回复
+func GetComments(commentType int, topicID int64, limit, offset int) ([]*Comment, int64, error) {
+	where := " WHERE type = ? AND topic_id = ? AND parent_id = 0 AND status <> ?"
+	args := []interface{}{commentType, topicID, CommentStatusDeleted}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM comment"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取评论总数失败: %w", err)
+	}
+
+	comments, err := queryComments(
+		"SELECT "+commentColumns+" FROM comment"+where+" ORDER BY id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	replies, err := attachCommentReplies(comments)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := attachCommentUsers(append(replies, comments...)); err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
 }
 
 // GetRecentComments 获取最新评论列表
@@ -27,18 +128,142 @@ func GetRecentComments(limit int) ([]Comment, error) {
 	return []Comment{}, nil
 }
 
-// GetCommentReplies 获取评论回复列表
-func GetCommentReplies(replyMsgID int64, limit, offset int) ([]Comment, error) {
-	// 实现获取评论回复列表逻辑
-	return []Comment{}, nil
+// GetCommentReplies 获取顶层评论下未删除的回复，按发布时间倒序
+func GetCommentReplies(commentType int, topicID, parentID int64, limit, offset int) ([]*Comment, int64, error) {
+	where := " WHERE type = ? AND topic_id = ? AND parent_id = ? AND status <> ?"
+	args := []interface{}{commentType, topicID, parentID, CommentStatusDeleted}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM comment"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取评论回复总数失败: %w", err)
+	}
+
+	replies, err := queryComments(
+		"SELECT "+commentColumns+" FROM comment"+where+" ORDER BY id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := attachCommentUsers(replies); err != nil {
+		return nil, 0, err
+	}
+	return replies, total, nil
+}
+
+// attachCommentReplies 填充顶层评论的回复数和最新的几条回复，返回附带的全部回复
+func attachCommentReplies(comments []*Comment) ([]*Comment, error) {
+	if len(comments) == 0 {
+		return nil, nil
+	}
+
+	byID := make(map[int64]*Comment, len(comments))
+	args := []interface{}{CommentStatusDeleted}
+	for _, comment := range comments {
+		comment.CommentReplyList = []*Comment{}
+		byID[comment.ID] = comment
+		args = append(args, comment.ID)
+	}
+	in := placeholders(len(comments))
+
+	rows, err := db.DB.Query(
+		"SELECT parent_id, COUNT(*) FROM comment WHERE status <> ? AND parent_id IN ("+in+") GROUP BY parent_id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("统计评论回复数失败: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var parentID, count int64
+		if err := rows.Scan(&parentID, &count); err != nil {
+			return nil, fmt.Errorf("扫描评论回复数行失败: %w", err)
+		}
+		byID[parentID].ReplyCount = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历评论回复数行失败: %w", err)
+	}
+
+	// 每条评论只取最新的几条回复
+	replies, err := queryComments(
+		"SELECT "+commentColumns+" FROM (SELECT "+commentColumns+", ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY id DESC) AS rn"+
+			" FROM comment WHERE status <> ? AND parent_id IN ("+in+")) r WHERE rn <= ? ORDER BY parent_id, id DESC",
+		append(args, commentPreviewReplies)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, reply := range replies {
+		parent := byID[reply.ParentID]
+		parent.CommentReplyList = append(parent.CommentReplyList, reply)
+	}
+	return replies, nil
+}
+
+// attachCommentUsers 填充评论人和被回复用户的信息
+func attachCommentUsers(comments []*Comment) error {
+	userIDs := make([]int, 0, len(comments)*2)
+	for _, comment := range comments {
+		for _, id := range []string{comment.UserID, comment.ReplyUserID} {
+			if userID, err := ParseUserID(id); err == nil {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	users, err := GetUserInfoVOs(userIDs)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if userID, err := ParseUserID(comment.UserID); err == nil {
+			comment.User = users[userID]
+		}
+		if userID, err := ParseUserID(comment.ReplyUserID); err == nil {
+			comment.ReplyUser = users[userID]
+		}
+	}
+	return nil
 }
 
-// CreateComment 创建评论
+// CreateComment 保存评论，调用方需要填好评论人、IP和归属地
 func CreateComment(comment *Comment) error {
-	// 实现创建评论逻辑
+	now := time.Now()
+	result, err := db.DB.Exec(
+		`INSERT INTO comment (topic_id, parent_id, reply_msg_id, user_id, reply_user_id, comment_content, ip_address, ip_source,
+			type, status, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		comment.TopicID, comment.ParentID, comment.ReplyMsgID, comment.UserID, comment.ReplyUserID, comment.CommentContent,
+		comment.IpAddress, comment.IpSource, comment.Type, CommentStatusNormal, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("创建评论失败: %w", err)
+	}
+	if comment.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("获取评论ID失败: %w", err)
+	}
+	comment.Status = CommentStatusNormal
+	comment.CreatedAt = now.Unix()
+	comment.UpdatedAt = now.Unix()
 	return nil
 }
 
+// CommentExists 检查同一主题下未删除的评论是否存在，用于回复前校验父评论
+func CommentExists(id int64, commentType int, topicID int64) (bool, error) {
+	var exists int
+	err := db.DB.QueryRow(
+		"SELECT 1 FROM comment WHERE id = ? AND type = ? AND topic_id = ? AND status <> ?",
+		id, commentType, topicID, CommentStatusDeleted,
+	).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("获取评论失败: %w", err)
+	}
+	return true, nil
+}
+
 // UpdateComment 更新评论
 func UpdateComment(comment *Comment) error {
 	// 实现更新评论逻辑
@@ -50,3 +275,38 @@ func LikeComment(id int64) error {
 	// 实现点赞评论逻辑
 	return nil
 }
+
+// CountComments 批量统计主题下未删除的评论数，返回 主题ID -> 评论数
+func CountComments(commentType int, topicIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64)
+	if len(topicIDs) == 0 {
+		return counts, nil
+	}
+
+	args := []interface{}{commentType, CommentStatusDeleted}
+	for _, id := range topicIDs {
+		args = append(args, id)
+	}
+	rows, err := db.DB.Query(
+		"SELECT topic_id, COUNT(*) FROM comment WHERE type = ? AND status <> ? AND topic_id IN ("+placeholders(len(topicIDs))+") GROUP BY topic_id",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("统计评论数失败: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var topicID, count int64
+		if err := rows.Scan(&topicID, &count); err != nil {
+			return nil, fmt.Errorf("扫描评论数行失败: %w", err)
+		}
+		counts[topicID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历评论数行失败: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,328 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+var (
+	// ErrFriendNotFound 友链不存在
+	ErrFriendNotFound = errors.New("友链不存在")
+	// ErrFriendExists 友链地址已存在
+	ErrFriendExists = errors.New("该友链地址已存在")
+)
+
+// 友链审核状态
+const (
+	FriendStatusPending  = 0 // 待审核
+	FriendStatusApproved = 1 // 已通过
+	FriendStatusRejected = 2 // 已拒绝
+)
+
+// Friend 友链模型
+type Friend struct {
+	ID int64 `json:"id" db:"id"`
+	// 链接名
+	LinkName string `json:"link_name" db:"link_name"`
+	// 链接头像
+	LinkAvatar string `json:"link_avatar" db:"link_avatar"`
+	// 链接地址
+	LinkAddress string `json:"link_address" db:"link_address"`
+	// 链接介绍
+	LinkIntro string `json:"link_intro" db:"link_intro"`
+	// 申请人联系邮箱，只有管理员可见
+	ContactEmail string `json:"contact_email,omitempty" db:"contact_email"`
+	// 审核状态 0待审核 1已通过 2已拒绝
+	Status int `json:"status" db:"status"`
+	// 最近一次检测是否可以访问 0否 1是
+	IsReachable int `json:"is_reachable" db:"is_reachable"`
+	// 最近一次检测对方页面是否有指向本站的链接 0否 1是
+	HasBacklink int `json:"has_backlink" db:"has_backlink"`
+	// 连续检测失败次数
+	FailCount int `json:"fail_count" db:"fail_count"`
+	// 是否已失效，连续失败达到阈值后标记
+	IsDead int `json:"is_dead" db:"is_dead"`
+	// 最近一次检测的错误信息
+	CheckError string `json:"check_error" db:"check_error"`
+	// 最近一次检测时间，未检测过为0
+	CheckedAt int64 `json:"checked_at" db:"checked_at"`
+	CreatedAt int64 `json:"created_at" db:"created_at"`
+	UpdatedAt int64 `json:"updated_at" db:"updated_at"`
+}
+
+// FriendFilter 友链查询条件，字段为 nil 时不过滤
+type FriendFilter struct {
+	Status *int
+	IsDead *int
+}
+
+// FriendCheckResult 一次友链检测的结果
+type FriendCheckResult struct {
+	Reachable   bool
+	HasBacklink bool
+	Error       string
+}
+
+// friendColumns 查询友链的列
+const friendColumns = `id, link_name, link_avatar, link_address, link_intro, contact_email, status,
+	is_reachable, has_backlink, fail_count, is_dead, check_error, checked_time, created_time, updated_time`
+
+// scanFriend 扫描一行友链记录
+func scanFriend(scanner interface{ Scan(...interface{}) error }) (*Friend, error) {
+	var (
+		friend                   Friend
+		checkedTime              sql.NullTime
+		createdTime, updatedTime time.Time
+	)
+	err := scanner.Scan(&friend.ID, &friend.LinkName, &friend.LinkAvatar, &friend.LinkAddress, &friend.LinkIntro,
+		&friend.ContactEmail, &friend.Status, &friend.IsReachable, &friend.HasBacklink, &friend.FailCount,
+		&friend.IsDead, &friend.CheckError, &checkedTime, &createdTime, &updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	if checkedTime.Valid {
+		friend.CheckedAt = checkedTime.Time.Unix()
+	}
+	friend.CreatedAt = createdTime.Unix()
+	friend.UpdatedAt = updatedTime.Unix()
+	return &friend, nil
+}
+
+// GetFriends 获取友链列表，按创建时间正序
+func GetFriends(limit, offset int, filter FriendFilter) ([]*Friend, int64, error) {
+	where := " WHERE 1 = 1"
+	args := []interface{}{}
+	if filter.Status != nil {
+		where += " AND status = ?"
+		args = append(args, *filter.Status)
+	}
+	if filter.IsDead != nil {
+		where += " AND is_dead = ?"
+		args = append(args, *filter.IsDead)
+	}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM friend"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取友链总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT "+friendColumns+" FROM friend"+where+" ORDER BY id ASC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取友链列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	friends := []*Friend{}
+	for rows.Next() {
+		friend, err := scanFriend(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描友链行失败: %w", err)
+		}
+		friends = append(friends, friend)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历友链行失败: %w", err)
+	}
+	return friends, total, nil
+}
+
+// GetFriendByID 根据ID获取友链
+func GetFriendByID(id int64) (*Friend, error) {
+	friend, err := scanFriend(db.DB.QueryRow("SELECT "+friendColumns+" FROM friend WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 友链不存在
+		}
+		return nil, fmt.Errorf("获取友链失败: %w", err)
+	}
+	return friend, nil
+}
+
+// friendAddressExists 检查友链地址是否已被其他友链使用
+func friendAddressExists(address string, excludeID int64) (bool, error) {
+	var id int64
+	err := db.DB.QueryRow("SELECT id FROM friend WHERE link_address = ? AND id <> ? LIMIT 1", address, excludeID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("检查友链地址失败: %w", err)
+	}
+	return true, nil
+}
+
+// CreateFriend 创建友链，地址重复时返回 ErrFriendExists
+func CreateFriend(friend *Friend) error {
+	exists, err := friendAddressExists(friend.LinkAddress, 0)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrFriendExists
+	}
+
+	now := time.Now()
+	result, err := db.DB.Exec(
+		`INSERT INTO friend (link_name, link_avatar, link_address, link_intro, contact_email, status, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		friend.LinkName, friend.LinkAvatar, friend.LinkAddress, friend.LinkIntro, friend.ContactEmail, friend.Status, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("创建友链失败: %w", err)
+	}
+	if friend.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("获取友链ID失败: %w", err)
+	}
+	friend.CreatedAt = now.Unix()
+	friend.UpdatedAt = now.Unix()
+	return nil
+}
+
+// UpdateFriend 修改友链信息和审核状态；地址变化时清空检测结果
+func UpdateFriend(friend *Friend) error {
+	exists, err := friendAddressExists(friend.LinkAddress, friend.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrFriendExists
+	}
+
+	result, err := db.DB.Exec(
+		`UPDATE friend SET
+			is_reachable = IF(link_address = ?, is_reachable, 0),
+			has_backlink = IF(link_address = ?, has_backlink, 0),
+			fail_count = IF(link_address = ?, fail_count, 0),
+			is_dead = IF(link_address = ?, is_dead, 0),
+			check_error = IF(link_address = ?, check_error, ''),
+			checked_time = IF(link_address = ?, checked_time, NULL),
+			link_name = ?, link_avatar = ?, link_address = ?, link_intro = ?, contact_email = ?, status = ?,
+			updated_time = NOW()
+		WHERE id = ?`,
+		friend.LinkAddress, friend.LinkAddress, friend.LinkAddress, friend.LinkAddress, friend.LinkAddress, friend.LinkAddress,
+		friend.LinkName, friend.LinkAvatar, friend.LinkAddress, friend.LinkIntro, friend.ContactEmail, friend.Status,
+		friend.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("修改友链失败: %w", err)
+	}
+	return requireExists(result, "friend", "id = ?", ErrFriendNotFound, friend.ID)
+}
+
+// UpdateFriendsStatus 批量修改友链审核状态，返回修改的数量
+func UpdateFriendsStatus(ids []int64, status int) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	args := []interface{}{status}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	result, err := db.DB.Exec(
+		"UPDATE friend SET status = ?, updated_time = NOW() WHERE id IN ("+placeholders(len(ids))+")",
+		args...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("修改友链审核状态失败: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("获取修改数量失败: %w", err)
+	}
+	return affected, nil
+}
+
+// DeleteFriends 批量删除友链及其评论，返回删除的数量
+func DeleteFriends(ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM friend WHERE id IN ("+placeholders(len(ids))+")", args...)
+	if err != nil {
+		return 0, fmt.Errorf("删除友链失败: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("获取删除数量失败: %w", err)
+	}
+	_, err = tx.Exec(
+		"DELETE FROM comment WHERE type = ? AND topic_id IN ("+placeholders(len(ids))+")",
+		append([]interface{}{CommentTypeFriend}, args...)...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("删除友链评论失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("提交删除友链事务失败: %w", err)
+	}
+	return affected, nil
+}
+
+// GetFriendsToCheck 获取需要检测的友链，即全部已通过审核的友链
+func GetFriendsToCheck() ([]*Friend, error) {
+	rows, err := db.DB.Query("SELECT "+friendColumns+" FROM friend WHERE status = ? ORDER BY id ASC", FriendStatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("获取待检测友链失败: %w", err)
+	}
+	defer rows.Close()
+
+	friends := []*Friend{}
+	for rows.Next() {
+		friend, err := scanFriend(rows)
+		if err != nil {
+			return nil, fmt.Errorf("扫描友链行失败: %w", err)
+		}
+		friends = append(friends, friend)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历友链行失败: %w", err)
+	}
+	return friends, nil
+}
+
+// SaveFriendCheck 保存友链检测结果；连续失败次数达到 deadThreshold 时标记为失效，检测成功后恢复
+func SaveFriendCheck(id int64, result FriendCheckResult, deadThreshold int) error {
+	reachable, backlink := 0, 0
+	if result.Reachable {
+		reachable = 1
+	}
+	if result.HasBacklink {
+		backlink = 1
+	}
+	_, err := db.DB.Exec(
+		`UPDATE friend SET
+			is_reachable = ?,
+			has_backlink = ?,
+			fail_count = IF(? = 1, 0, fail_count + 1),
+			is_dead = IF(? = 1, 0, IF(fail_count >= ?, 1, 0)),
+			check_error = ?,
+			checked_time = NOW()
+		WHERE id = ?`,
+		reachable, backlink, reachable, reachable, deadThreshold, result.Error, id,
+	)
+	if err != nil {
+		return fmt.Errorf("保存友链检测结果失败: %w", err)
+	}
+	return nil
+}
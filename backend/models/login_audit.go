@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// LoginAudit 登录审计事件
+type LoginAudit struct {
+	ID int64 `json:"id" db:"id"`
+	// 事件类型 account_locked,ip_locked,unlocked
+	Event string `json:"event" db:"event" example:"account_locked"`
+	// 账号标识
+	Account string `json:"account" db:"account" example:"user:1"`
+	// 客户端IP
+	IP string `json:"ip" db:"ip" example:"127.0.0.1"`
+	// IP归属地
+	IpSource string `json:"ip_source" db:"ip_source" example:"内网IP"`
+	// 详情
+	Detail    string `json:"detail" db:"detail"`
+	CreatedAt int64  `json:"created_at" db:"created_at"`
+}
+
+// CreateLoginAudit 写入登录审计事件
+func CreateLoginAudit(audit *LoginAudit) error {
+	_, err := db.DB.Exec(
+		"INSERT INTO login_audit (event, account, ip, ip_source, detail, created_time) VALUES (?, ?, ?, ?, ?, NOW())",
+		audit.Event, audit.Account, audit.IP, audit.IpSource, audit.Detail,
+	)
+	if err != nil {
+		return fmt.Errorf("写入登录审计事件失败: %w", err)
+	}
+	return nil
+}
+
+// GetLoginAudits 分页获取登录审计事件，按时间倒序
+func GetLoginAudits(limit, offset int) ([]*LoginAudit, int64, error) {
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM login_audit").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取登录审计事件总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT id, event, account, ip, ip_source, detail, created_time FROM login_audit ORDER BY id DESC LIMIT ? OFFSET ?",
+		limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取登录审计事件失败: %w", err)
+	}
+	defer rows.Close()
+
+	audits := []*LoginAudit{}
+	for rows.Next() {
+		var (
+			audit       LoginAudit
+			createdTime time.Time
+		)
+		if err := rows.Scan(&audit.ID, &audit.Event, &audit.Account, &audit.IP, &audit.IpSource, &audit.Detail,
+			&createdTime); err != nil {
+			return nil, 0, fmt.Errorf("扫描登录审计事件行失败: %w", err)
+		}
+		audit.CreatedAt = createdTime.Unix()
+		audits = append(audits, &audit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历登录审计事件行失败: %w", err)
+	}
+
+	return audits, total, nil
+}
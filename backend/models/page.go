@@ -0,0 +1,249 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+var (
+	// ErrPageNotFound 页面不存在
+	ErrPageNotFound = errors.New("页面不存在")
+	// ErrPageLabelExists 页面标签已存在
+	ErrPageLabelExists = errors.New("页面标签已存在")
+)
+
+// Page 页面模型，前台各页面顶部的封面按页面标签取用
+type Page struct {
+	ID int64 `json:"id" db:"id"`
+	// 页面名
+	PageName string `json:"page_name" db:"page_name"`
+	// 页面标签，与前台页面对应，如 article、talk
+	PageLabel string `json:"page_label" db:"page_label"`
+	// 页面封面
+	PageCover string `json:"page_cover" db:"page_cover"`
+	// 是否在首页轮播 0否 1是
+	IsCarousel int `json:"is_carousel" db:"is_carousel"`
+	// 排序，越小越靠前
+	Sort      int   `json:"sort" db:"sort"`
+	CreatedAt int64 `json:"created_at" db:"created_at"`
+	UpdatedAt int64 `json:"updated_at" db:"updated_at"`
+}
+
+// PageVO 首页信息中返回的页面
+type PageVO struct {
+	ID         int64  `json:"id"`
+	PageName   string `json:"page_name"`
+	PageLabel  string `json:"page_label"`
+	PageCover  string `json:"page_cover"`
+	IsCarousel int    `json:"is_carousel"`
+}
+
+// pageColumns 查询页面的列
+const pageColumns = "id, page_name, page_label, page_cover, is_carousel, sort, created_time, updated_time"
+
+// scanPage 扫描一行页面记录
+func scanPage(scanner interface{ Scan(...interface{}) error }) (*Page, error) {
+	var (
+		page                     Page
+		createdTime, updatedTime time.Time
+	)
+	err := scanner.Scan(&page.ID, &page.PageName, &page.PageLabel, &page.PageCover, &page.IsCarousel, &page.Sort,
+		&createdTime, &updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	page.CreatedAt = createdTime.Unix()
+	page.UpdatedAt = updatedTime.Unix()
+	return &page, nil
+}
+
+// GetPages 获取页面列表，按排序号和ID正序
+func GetPages(limit, offset int) ([]*Page, int64, error) {
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM page").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取页面总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query("SELECT "+pageColumns+" FROM page ORDER BY sort ASC, id ASC LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取页面列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	pages := []*Page{}
+	for rows.Next() {
+		page, err := scanPage(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描页面行失败: %w", err)
+		}
+		pages = append(pages, page)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历页面行失败: %w", err)
+	}
+	return pages, total, nil
+}
+
+// GetPageVOs 获取全部页面，用于首页信息
+func GetPageVOs() ([]PageVO, error) {
+	rows, err := db.DB.Query("SELECT id, page_name, page_label, page_cover, is_carousel FROM page ORDER BY sort ASC, id ASC")
+	if err != nil {
+		return nil, fmt.Errorf("获取页面列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	pages := []PageVO{}
+	for rows.Next() {
+		var page PageVO
+		if err := rows.Scan(&page.ID, &page.PageName, &page.PageLabel, &page.PageCover, &page.IsCarousel); err != nil {
+			return nil, fmt.Errorf("扫描页面行失败: %w", err)
+		}
+		pages = append(pages, page)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历页面行失败: %w", err)
+	}
+	return pages, nil
+}
+
+// pageLabelExists 检查页面标签是否已被其他页面使用
+func pageLabelExists(label string, excludeID int64) (bool, error) {
+	var id int64
+	err := db.DB.QueryRow("SELECT id FROM page WHERE page_label = ? AND id <> ? LIMIT 1", label, excludeID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("检查页面标签失败: %w", err)
+	}
+	return true, nil
+}
+
+// CreatePage 创建页面，排在已有页面之后；标签重复时返回 ErrPageLabelExists
+func CreatePage(page *Page) error {
+	exists, err := pageLabelExists(page.PageLabel, 0)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrPageLabelExists
+	}
+
+	now := time.Now()
+	result, err := db.DB.Exec(
+		`INSERT INTO page (page_name, page_label, page_cover, is_carousel, sort, created_time, updated_time)
+		SELECT ?, ?, ?, ?, COALESCE(MAX(sort), 0) + 1, ?, ? FROM page`,
+		page.PageName, page.PageLabel, page.PageCover, page.IsCarousel, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("创建页面失败: %w", err)
+	}
+	if page.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("获取页面ID失败: %w", err)
+	}
+	if err := db.DB.QueryRow("SELECT sort FROM page WHERE id = ?", page.ID).Scan(&page.Sort); err != nil {
+		return fmt.Errorf("获取页面排序失败: %w", err)
+	}
+	page.CreatedAt = now.Unix()
+	page.UpdatedAt = now.Unix()
+	return nil
+}
+
+// UpdatePage 修改页面名、标签、封面和轮播标记
+func UpdatePage(page *Page) error {
+	exists, err := pageLabelExists(page.PageLabel, page.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrPageLabelExists
+	}
+
+	result, err := db.DB.Exec(
+		"UPDATE page SET page_name = ?, page_label = ?, page_cover = ?, is_carousel = ?, updated_time = NOW() WHERE id = ?",
+		page.PageName, page.PageLabel, page.PageCover, page.IsCarousel, page.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("修改页面失败: %w", err)
+	}
+	return requireExists(result, "page", "id = ?", ErrPageNotFound, page.ID)
+}
+
+// DeletePages 批量删除页面，返回删除的数量
+func DeletePages(ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	result, err := db.DB.Exec("DELETE FROM page WHERE id IN ("+placeholders(len(ids))+")", args...)
+	if err != nil {
+		return 0, fmt.Errorf("删除页面失败: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("获取删除数量失败: %w", err)
+	}
+	return affected, nil
+}
+
+// SortPages 按给定顺序重排页面，ids 必须恰好是全部页面
+func SortPages(ids []int64) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM page FOR UPDATE").Scan(&count); err != nil {
+		return fmt.Errorf("获取页面数量失败: %w", err)
+	}
+	if count != len(ids) {
+		return ErrPageNotFound
+	}
+
+	// 排序值没变化时更新行数为 0，不能用来判断页面是否存在，先统一确认
+	seen := make(map[int64]bool, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return ErrPageNotFound
+		}
+		seen[id] = true
+		args = append(args, id)
+	}
+	if len(ids) > 0 {
+		if err := tx.QueryRow("SELECT COUNT(*) FROM page WHERE id IN ("+placeholders(len(ids))+")", args...).Scan(&count); err != nil {
+			return fmt.Errorf("获取页面数量失败: %w", err)
+		}
+		if count != len(ids) {
+			return ErrPageNotFound
+		}
+	}
+
+	stmt, err := tx.Prepare("UPDATE page SET sort = ?, updated_time = NOW() WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("预处理排序语句失败: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, id := range ids {
+		if _, err := stmt.Exec(i+1, id); err != nil {
+			return fmt.Errorf("更新页面排序失败: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交排序事务失败: %w", err)
+	}
+	return nil
+}
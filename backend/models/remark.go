@@ -0,0 +1,180 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// 留言审核状态
+const (
+	RemarkReviewPending = 0 // 待审核
+	RemarkReviewPassed  = 1 // 已通过
+)
+
+// Remark 留言模型
+type Remark struct {
+	ID int64 `json:"id" db:"id"`
+	// 用户ID，游客留言时为空
+	UserID string `json:"user_id" db:"user_id"`
+	// 终端ID，游客留言时标识游客；是游客身份的凭证，不返回给前端
+	TerminalID string `json:"-" db:"terminal_id"`
+	// 由终端ID派生的公开标识，用于区分不同游客
+	TouristID string `json:"tourist_id" db:"-"`
+	// 留言内容
+	MessageContent string `json:"message_content" db:"message_content"`
+	// 用户IP
+	IpAddress string `json:"ip_address" db:"ip_address"`
+	// 用户地址
+	IpSource string `json:"ip_source" db:"ip_source"`
+	// 是否审核通过 0待审核 1已通过
+	IsReview  int   `json:"is_review" db:"is_review"`
+	CreatedAt int64 `json:"created_at" db:"created_at"`
+	UpdatedAt int64 `json:"updated_at" db:"updated_at"`
+	// 留言用户信息
+	User *UserInfoVO `json:"user,omitempty" db:"-"`
+}
+
+// remarkColumns 查询留言的列
+const remarkColumns = "id, user_id, terminal_id, message_content, ip_address, ip_source, is_review, created_time, updated_time"
+
+// GetRemarks 获取留言列表，按发布时间倒序；isReview 为 nil 时不按审核状态过滤
+func GetRemarks(limit, offset int, isReview *int) ([]*Remark, int64, error) {
+	where := ""
+	args := []interface{}{}
+	if isReview != nil {
+		where = " WHERE is_review = ?"
+		args = append(args, *isReview)
+	}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM remark"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取留言总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT "+remarkColumns+" FROM remark"+where+" ORDER BY id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取留言列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	remarks := []*Remark{}
+	for rows.Next() {
+		var (
+			remark                   Remark
+			userID                   int
+			createdTime, updatedTime time.Time
+		)
+		err := rows.Scan(&remark.ID, &userID, &remark.TerminalID, &remark.MessageContent, &remark.IpAddress,
+			&remark.IpSource, &remark.IsReview, &createdTime, &updatedTime)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描留言行失败: %w", err)
+		}
+		if userID > 0 {
+			remark.UserID = FormatUserID(userID)
+		}
+		remark.TouristID = TouristPublicID(remark.TerminalID)
+		remark.CreatedAt = createdTime.Unix()
+		remark.UpdatedAt = updatedTime.Unix()
+		remarks = append(remarks, &remark)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历留言行失败: %w", err)
+	}
+
+	if err := attachRemarkUsers(remarks); err != nil {
+		return nil, 0, err
+	}
+	return remarks, total, nil
+}
+
+// attachRemarkUsers 填充登录用户留言的用户信息
+func attachRemarkUsers(remarks []*Remark) error {
+	userIDs := make([]int, 0, len(remarks))
+	for _, remark := range remarks {
+		if userID, err := ParseUserID(remark.UserID); err == nil {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	users, err := GetUserInfoVOs(userIDs)
+	if err != nil {
+		return err
+	}
+	for _, remark := range remarks {
+		if userID, err := ParseUserID(remark.UserID); err == nil {
+			remark.User = users[userID]
+		}
+	}
+	return nil
+}
+
+// CreateRemark 发布留言，userID 为 0 表示游客留言
+func CreateRemark(remark *Remark, userID int) error {
+	now := time.Now()
+	result, err := db.DB.Exec(
+		`INSERT INTO remark (user_id, terminal_id, message_content, ip_address, ip_source, is_review, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		userID, remark.TerminalID, remark.MessageContent, remark.IpAddress, remark.IpSource, remark.IsReview, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("发布留言失败: %w", err)
+	}
+	if remark.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("获取留言ID失败: %w", err)
+	}
+	if userID > 0 {
+		remark.UserID = FormatUserID(userID)
+	}
+	remark.TouristID = TouristPublicID(remark.TerminalID)
+	remark.CreatedAt = now.Unix()
+	remark.UpdatedAt = now.Unix()
+	return nil
+}
+
+// UpdateRemarksReview 批量修改留言审核状态，返回修改的数量
+func UpdateRemarksReview(ids []int64, isReview int) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	args := []interface{}{isReview}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	result, err := db.DB.Exec(
+		"UPDATE remark SET is_review = ?, updated_time = NOW() WHERE id IN ("+placeholders(len(ids))+")",
+		args...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("修改留言审核状态失败: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("获取修改数量失败: %w", err)
+	}
+	return affected, nil
+}
+
+// DeleteRemarks 批量删除留言，返回删除的数量
+func DeleteRemarks(ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	result, err := db.DB.Exec("DELETE FROM remark WHERE id IN ("+placeholders(len(ids))+")", args...)
+	if err != nil {
+		return 0, fmt.Errorf("删除留言失败: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("获取删除数量失败: %w", err)
+	}
+	return affected, nil
+}
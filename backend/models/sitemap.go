@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// SitemapItem 站点地图中的一条记录，Key 为文章、分类、标签、相册的ID或页面标签
+type SitemapItem struct {
+	Key       string
+	UpdatedAt time.Time
+}
+
+// GetSitemapArticles 获取全部公开文章，按修改时间倒序
+func GetSitemapArticles() ([]*SitemapItem, error) {
+	return querySitemapItems("文章", `SELECT id, updated_time FROM article WHERE status = ?
+		ORDER BY updated_time DESC, id DESC`, ArticleStatusPublic)
+}
+
+// GetSitemapCategories 获取有公开文章的分类，修改时间取分类下文章的最新修改时间
+func GetSitemapCategories() ([]*SitemapItem, error) {
+	return querySitemapItems("分类", `SELECT category_id, MAX(updated_time) FROM article
+		WHERE status = ? AND category_id <> '' GROUP BY category_id ORDER BY category_id`, ArticleStatusPublic)
+}
+
+// GetSitemapTags 获取有公开文章的标签，修改时间取标签下文章的最新修改时间
+func GetSitemapTags() ([]*SitemapItem, error) {
+	return querySitemapItems("标签", `SELECT r.tag_id, MAX(a.updated_time) FROM relevance r
+		JOIN article a ON a.id = r.article_id
+		WHERE a.status = ? GROUP BY r.tag_id ORDER BY r.tag_id`, ArticleStatusPublic)
+}
+
+// GetSitemapAlbums 获取公开且不需要密码的相册
+func GetSitemapAlbums() ([]*SitemapItem, error) {
+	return querySitemapItems("相册", `SELECT id, updated_time FROM album
+		WHERE is_private = 0 AND album_password = '' ORDER BY id DESC`)
+}
+
+// GetSitemapPages 获取全部页面，Key 为页面标签
+func GetSitemapPages() ([]*SitemapItem, error) {
+	return querySitemapItems("页面", "SELECT page_label, updated_time FROM page ORDER BY sort ASC, id ASC")
+}
+
+// querySitemapItems 执行返回 (key, 修改时间) 两列的查询，name 用于错误信息
+func querySitemapItems(name, query string, args ...interface{}) ([]*SitemapItem, error) {
+	rows, err := db.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("获取站点地图%s失败: %w", name, err)
+	}
+	defer rows.Close()
+
+	items := []*SitemapItem{}
+	for rows.Next() {
+		item := &SitemapItem{}
+		if err := rows.Scan(&item.Key, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("扫描站点地图%s行失败: %w", name, err)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历站点地图%s行失败: %w", name, err)
+	}
+	return items, nil
+}
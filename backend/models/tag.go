@@ -2,11 +2,23 @@ package models
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/jayden/personal-blog-backend/db"
 )
 
+var (
+	// ErrTagNotFound 标签不存在
+	ErrTagNotFound = errors.New("标签不存在")
+	// ErrTagNameExists 标签名已存在
+	ErrTagNameExists = errors.New("标签名已存在")
+	// ErrTagSlugExists 标签别名已存在
+	ErrTagSlugExists = errors.New("标签别名已存在")
+)
+
 // Tag 标签模型
 // @Description 文章标签信息
 type Tag struct {
@@ -14,13 +26,56 @@ type Tag struct {
 	ID string `json:"id" example:"1"`
 	// 标签名称
 	Name string `json:"name" example:"Go"`
-	// 文章数量
+	// 别名，用于地址
+	Slug string `json:"slug" example:"go"`
+	// 公开文章数量
 	Count int `json:"count" example:"5"`
+	// 创建时间
+	CreatedAt int64 `json:"created_at" example:"1700000000"`
+	// 更新时间
+	UpdatedAt int64 `json:"updated_at" example:"1700000000"`
 }
 
-// GetTags 获取所有标签
-func GetTags() ([]*Tag, error) {
-	rows, err := db.DB.Query("SELECT id, name, count FROM tag ORDER BY count DESC")
+// TagVO 标签列表中返回的标签
+// @Description 标签及其公开文章数
+type TagVO struct {
+	ID           string `json:"id" example:"1"`
+	TagName      string `json:"tag_name" example:"Go"`
+	Slug         string `json:"slug" example:"go"`
+	ArticleCount int    `json:"article_count" example:"5"`
+	CreatedAt    int64  `json:"created_at" example:"1700000000"`
+	UpdatedAt    int64  `json:"updated_at" example:"1700000000"`
+}
+
+// tagColumns 查询标签的列
+const tagColumns = "t.id, t.name, IFNULL(t.slug, ''), t.count, t.created_time, t.updated_time"
+
+// scanTag 扫描一行标签记录
+func scanTag(scanner interface{ Scan(...interface{}) error }) (*Tag, error) {
+	var (
+		tag                      Tag
+		createdTime, updatedTime time.Time
+	)
+	if err := scanner.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.Count, &createdTime, &updatedTime); err != nil {
+		return nil, err
+	}
+	tag.CreatedAt = createdTime.Unix()
+	tag.UpdatedAt = updatedTime.Unix()
+	return &tag, nil
+}
+
+// CountTags 获取标签总数
+func CountTags() (int64, error) {
+	var count int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM tag").Scan(&count); err != nil {
+		return 0, fmt.Errorf("获取标签总数失败: %w", err)
+	}
+	return count, nil
+}
+
+// queryTags 查询标签列表
+func queryTags(query string, args ...interface{}) ([]*Tag, error) {
+	rows, err := db.DB.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("获取标签列表失败: %w", err)
 	}
@@ -28,8 +83,7 @@ func GetTags() ([]*Tag, error) {
 
 	var tags []*Tag
 	for rows.Next() {
-		tag := &Tag{}
-		err := rows.Scan(&tag.ID, &tag.Name, &tag.Count)
+		tag, err := scanTag(rows)
 		if err != nil {
 			return nil, fmt.Errorf("扫描标签行失败: %w", err)
 		}
@@ -43,10 +97,50 @@ func GetTags() ([]*Tag, error) {
 	return tags, nil
 }
 
-// GetTagByID 根据ID获取标签
-func GetTagByID(id string) (*Tag, error) {
-	tag := &Tag{}
-	err := db.DB.QueryRow("SELECT id, name, count FROM tag WHERE id = ?", id).Scan(&tag.ID, &tag.Name, &tag.Count)
+// GetTags 获取所有标签
+func GetTags() ([]*Tag, error) {
+	return queryTags("SELECT " + tagColumns + " FROM tag t ORDER BY t.count DESC")
+}
+
+// GetTagList 分页获取标签列表，按文章数倒序；name 不为空时按标签名模糊匹配
+func GetTagList(name string, limit, offset int) ([]*TagVO, int64, error) {
+	where := ""
+	var args []interface{}
+	if name != "" {
+		where = " WHERE t.name LIKE ?"
+		args = append(args, "%"+escapeLike(name)+"%")
+	}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM tag t"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取标签总数失败: %w", err)
+	}
+
+	tags, err := queryTags(
+		"SELECT "+tagColumns+" FROM tag t"+where+" ORDER BY t.count DESC, t.id ASC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]*TagVO, 0, len(tags))
+	for _, tag := range tags {
+		list = append(list, &TagVO{
+			ID:           tag.ID,
+			TagName:      tag.Name,
+			Slug:         tag.Slug,
+			ArticleCount: tag.Count,
+			CreatedAt:    tag.CreatedAt,
+			UpdatedAt:    tag.UpdatedAt,
+		})
+	}
+	return list, total, nil
+}
+
+// getTag 按条件获取一个标签，不存在时返回 nil
+func getTag(where string, arg interface{}) (*Tag, error) {
+	tag, err := scanTag(db.DB.QueryRow("SELECT "+tagColumns+" FROM tag t WHERE "+where, arg))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -56,70 +150,187 @@ func GetTagByID(id string) (*Tag, error) {
 	return tag, nil
 }
 
+// GetTagByID 根据ID获取标签
+func GetTagByID(id string) (*Tag, error) {
+	return getTag("t.id = ?", id)
+}
+
 // GetTagByName 根据名称获取标签
 func GetTagByName(name string) (*Tag, error) {
-	tag := &Tag{}
-	err := db.DB.QueryRow("SELECT id, name, count FROM tag WHERE name = ?", name).Scan(&tag.ID, &tag.Name, &tag.Count)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("获取标签失败: %w", err)
+	return getTag("t.name = ?", name)
+}
+
+// GetTagBySlug 根据别名获取标签
+func GetTagBySlug(slug string) (*Tag, error) {
+	return getTag("t.slug = ?", slug)
+}
+
+// FindTag 按ID或别名获取标签，先按ID查找
+func FindTag(key string) (*Tag, error) {
+	tag, err := GetTagByID(key)
+	if err != nil || tag != nil {
+		return tag, err
 	}
-	return tag, nil
+	return GetTagBySlug(key)
 }
 
 // GetTagsByArticleID 根据文章ID获取所有标签
 func GetTagsByArticleID(articleID string) ([]*Tag, error) {
-	rows, err := db.DB.Query(
-		"SELECT t.id, t.name, t.count FROM tag t JOIN relevance at ON t.id = at.tag_id WHERE at.article_id = ?",
+	tags, err := queryTags(
+		"SELECT "+tagColumns+" FROM tag t JOIN relevance at ON t.id = at.tag_id WHERE at.article_id = ?",
 		articleID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("获取文章标签失败: %w", err)
 	}
-	defer rows.Close()
+	return tags, nil
+}
 
-	var tags []*Tag
-	for rows.Next() {
-		tag := &Tag{}
-		err := rows.Scan(&tag.ID, &tag.Name, &tag.Count)
+// checkTag 检查标签名是否被其他标签使用，并在未指定别名时按名称生成不重复的别名
+func checkTag(tag *Tag) error {
+	exists, err := taxonomyTag.nameExists(tag.Name, tag.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrTagNameExists
+	}
+	if tag.Slug != "" {
+		exists, err := taxonomyTag.slugExists(tag.Slug, tag.ID)
 		if err != nil {
-			return nil, fmt.Errorf("扫描文章标签行失败: %w", err)
+			return err
 		}
-		tags = append(tags, tag)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("遍历文章标签行失败: %w", err)
+		if exists {
+			return ErrTagSlugExists
+		}
+		return nil
 	}
-
-	return tags, nil
+	tag.Slug, err = taxonomyTag.uniqueSlug(tag.Name, tag.ID)
+	return err
 }
 
-// CreateTag 创建新标签
+// CreateTag 创建新标签，未指定别名时按名称生成；名称或别名重复时返回对应错误
 func CreateTag(tag *Tag) error {
-	_, err := db.DB.Exec("INSERT INTO tag (id, name, count) VALUES (?, ?, ?)", tag.ID, tag.Name, tag.Count)
+	if err := checkTag(tag); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	result, err := db.DB.Exec(
+		"INSERT INTO tag (name, slug, count, created_time, updated_time) VALUES (?, ?, 0, ?, ?)",
+		tag.Name, tag.Slug, now, now,
+	)
 	if err != nil {
 		return fmt.Errorf("创建标签失败: %w", err)
 	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("获取标签ID失败: %w", err)
+	}
+	tag.ID = strconv.FormatInt(id, 10)
+	tag.Count = 0
+	tag.CreatedAt = now.Unix()
+	tag.UpdatedAt = now.Unix()
 	return nil
 }
 
-// UpdateTag 更新标签
+// UpdateTag 修改标签名和别名，别名为空时按新名称重新生成；文章数不在这里修改
 func UpdateTag(tag *Tag) error {
-	_, err := db.DB.Exec("UPDATE tag SET name = ?, count = ? WHERE id = ?", tag.Name, tag.Count, tag.ID)
+	existing, err := GetTagByID(tag.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrTagNotFound
+	}
+	if err := checkTag(tag); err != nil {
+		return err
+	}
+
+	_, err = db.DB.Exec("UPDATE tag SET name = ?, slug = ?, updated_time = NOW() WHERE id = ?", tag.Name, tag.Slug, tag.ID)
 	if err != nil {
 		return fmt.Errorf("更新标签失败: %w", err)
 	}
+	tag.Count = existing.Count
+	tag.CreatedAt = existing.CreatedAt
+	tag.UpdatedAt = time.Now().Unix()
 	return nil
 }
 
-// DeleteTag 删除标签
-func DeleteTag(id string) error {
-	_, err := db.DB.Exec("DELETE FROM tag WHERE id = ?", id)
+// DeleteTag 删除标签及其与文章的关联，返回原先带有该标签的文章ID
+func DeleteTag(id string) ([]string, error) {
+	tx, err := db.DB.Begin()
 	if err != nil {
-		return fmt.Errorf("删除标签失败: %w", err)
+		return nil, fmt.Errorf("开启事务失败: %w", err)
 	}
-	return nil
+	defer tx.Rollback()
+
+	articleIDs, err := queryStrings(tx, "SELECT article_id FROM relevance WHERE tag_id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("获取标签文章失败: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM relevance WHERE tag_id = ?", id); err != nil {
+		return nil, fmt.Errorf("删除标签关联失败: %w", err)
+	}
+	result, err := tx.Exec("DELETE FROM tag WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("删除标签失败: %w", err)
+	}
+	if err := requireAffected(result, ErrTagNotFound); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("提交删除标签事务失败: %w", err)
+	}
+	return articleIDs, nil
+}
+
+// MergeTag 把 sourceID 标签的文章关联改为 targetID 后删除 sourceID，返回受影响的文章ID；
+// 同时带有两个标签的文章只保留一条关联
+func MergeTag(sourceID, targetID string) ([]string, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := taxonomyTag.lock(tx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	articleIDs, err := queryStrings(tx, "SELECT article_id FROM relevance WHERE tag_id = ?", sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("获取标签文章失败: %w", err)
+	}
+	_, err = tx.Exec(
+		`DELETE s FROM relevance s JOIN relevance t ON t.article_id = s.article_id AND t.tag_id = ?
+		WHERE s.tag_id = ?`,
+		targetID, sourceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("删除重复的标签关联失败: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE relevance SET tag_id = ? WHERE tag_id = ?", targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("移动标签关联失败: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM tag WHERE id = ?", sourceID); err != nil {
+		return nil, fmt.Errorf("删除标签失败: %w", err)
+	}
+	if err := refreshTagCounts(tx, []string{targetID}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("提交合并标签事务失败: %w", err)
+	}
+	return articleIDs, nil
+}
+
+// GetArticleIDsByTagID 获取带有该标签的全部文章ID，用于标签改名后同步检索索引
+func GetArticleIDsByTagID(id string) ([]string, error) {
+	ids, err := queryStrings(db.DB, "SELECT article_id FROM relevance WHERE tag_id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("获取标签文章失败: %w", err)
+	}
+	return ids, nil
 }
@@ -0,0 +1,226 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// ErrTalkNotFound 说说不存在
+var ErrTalkNotFound = errors.New("说说不存在")
+
+// 说说状态
+const (
+	TalkStatusPublic  = 1 // 公开
+	TalkStatusPrivate = 2 // 私密，只有管理员可见
+)
+
+// Talk 说说模型
+type Talk struct {
+	ID int64 `json:"id" db:"id"`
+	// 发布人ID
+	UserID string `json:"user_id" db:"user_id"`
+	// 内容
+	Content string `json:"content" db:"content"`
+	// 图片地址列表
+	ImgList []string `json:"img_list" db:"img_list"`
+	// 是否置顶 0否 1是
+	IsTop int `json:"is_top" db:"is_top"`
+	// 状态 1公开 2私密
+	Status int `json:"status" db:"status"`
+	// 点赞数
+	LikeCount int64 `json:"like_count" db:"like_count"`
+	// 评论数
+	CommentCount int64 `json:"comment_count" db:"-"`
+	CreatedAt    int64 `json:"created_at" db:"created_at"`
+	UpdatedAt    int64 `json:"updated_at" db:"updated_at"`
+	// 发布人信息
+	User *UserInfoVO `json:"user,omitempty" db:"-"`
+}
+
+// talkColumns 查询说说的列
+const talkColumns = "id, user_id, content, img_list, is_top, status, like_count, created_time, updated_time"
+
+// scanTalk 扫描一行说说记录
+func scanTalk(scanner interface{ Scan(...interface{}) error }) (*Talk, error) {
+	var (
+		talk                     Talk
+		userID                   int
+		imgList                  string
+		createdTime, updatedTime time.Time
+	)
+	err := scanner.Scan(&talk.ID, &userID, &talk.Content, &imgList, &talk.IsTop, &talk.Status, &talk.LikeCount,
+		&createdTime, &updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	talk.UserID = FormatUserID(userID)
+	talk.ImgList = []string{}
+	if imgList != "" {
+		if err := json.Unmarshal([]byte(imgList), &talk.ImgList); err != nil {
+			return nil, fmt.Errorf("解析说说图片列表失败: %w", err)
+		}
+	}
+	talk.CreatedAt = createdTime.Unix()
+	talk.UpdatedAt = updatedTime.Unix()
+	return &talk, nil
+}
+
+// GetTalks 获取说说列表，置顶的排在前面；includePrivate 为 false 时只返回公开的说说
+func GetTalks(limit, offset int, includePrivate bool) ([]*Talk, int64, error) {
+	where := ""
+	args := []interface{}{}
+	if !includePrivate {
+		where = " WHERE status = ?"
+		args = append(args, TalkStatusPublic)
+	}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM talk"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取说说总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT "+talkColumns+" FROM talk"+where+" ORDER BY is_top DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取说说列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	talks := []*Talk{}
+	for rows.Next() {
+		talk, err := scanTalk(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描说说行失败: %w", err)
+		}
+		talks = append(talks, talk)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历说说行失败: %w", err)
+	}
+
+	if err := attachTalkExtras(talks); err != nil {
+		return nil, 0, err
+	}
+	return talks, total, nil
+}
+
+// GetTalkByID 根据ID获取说说
+func GetTalkByID(id int64) (*Talk, error) {
+	talk, err := scanTalk(db.DB.QueryRow("SELECT "+talkColumns+" FROM talk WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 说说不存在
+		}
+		return nil, fmt.Errorf("获取说说失败: %w", err)
+	}
+	if err := attachTalkExtras([]*Talk{talk}); err != nil {
+		return nil, err
+	}
+	return talk, nil
+}
+
+// attachTalkExtras 填充评论数和发布人信息
+func attachTalkExtras(talks []*Talk) error {
+	if len(talks) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(talks))
+	userIDs := make([]int, 0, len(talks))
+	for _, talk := range talks {
+		ids = append(ids, talk.ID)
+		if userID, err := ParseUserID(talk.UserID); err == nil {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	counts, err := CountComments(CommentTypeTalk, ids)
+	if err != nil {
+		return err
+	}
+	users, err := GetUserInfoVOs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, talk := range talks {
+		talk.CommentCount = counts[talk.ID]
+		if userID, err := ParseUserID(talk.UserID); err == nil {
+			talk.User = users[userID]
+		}
+	}
+	return nil
+}
+
+// CreateTalk 发布说说
+func CreateTalk(talk *Talk, userID int) error {
+	imgList, err := json.Marshal(talk.ImgList)
+	if err != nil {
+		return fmt.Errorf("序列化说说图片列表失败: %w", err)
+	}
+	result, err := db.DB.Exec(
+		`INSERT INTO talk (user_id, content, img_list, is_top, status, like_count, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, 0, NOW(), NOW())`,
+		userID, talk.Content, string(imgList), talk.IsTop, talk.Status,
+	)
+	if err != nil {
+		return fmt.Errorf("发布说说失败: %w", err)
+	}
+	if talk.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("获取说说ID失败: %w", err)
+	}
+	talk.UserID = FormatUserID(userID)
+	return nil
+}
+
+// UpdateTalk 修改说说内容、图片、置顶和状态
+func UpdateTalk(talk *Talk) error {
+	imgList, err := json.Marshal(talk.ImgList)
+	if err != nil {
+		return fmt.Errorf("序列化说说图片列表失败: %w", err)
+	}
+	result, err := db.DB.Exec(
+		"UPDATE talk SET content = ?, img_list = ?, is_top = ?, status = ?, updated_time = NOW() WHERE id = ?",
+		talk.Content, string(imgList), talk.IsTop, talk.Status, talk.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("修改说说失败: %w", err)
+	}
+	return requireExists(result, "talk", "id = ?", ErrTalkNotFound, talk.ID)
+}
+
+// DeleteTalk 删除说说及其评论和点赞记录
+func DeleteTalk(id int64) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM talk WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("删除说说失败: %w", err)
+	}
+	if err := requireAffected(result, ErrTalkNotFound); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM comment WHERE type = ? AND topic_id = ?", CommentTypeTalk, id); err != nil {
+		return fmt.Errorf("删除说说评论失败: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM user_like WHERE object_type = ? AND object_id = ?", LikeTypeTalk, id); err != nil {
+		return fmt.Errorf("删除说说点赞失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交删除说说事务失败: %w", err)
+	}
+	return nil
+}
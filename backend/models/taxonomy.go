@@ -0,0 +1,258 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/jayden/personal-blog-backend/db"
+	"github.com/jayden/personal-blog-backend/slug"
+)
+
+// taxonomy 分类和标签共用的别名和合并逻辑，按表名区分
+type taxonomy struct {
+	table    string
+	label    string // 错误信息中的名称
+	fallback string // 名称生成不了别名时使用的前缀
+	notFound error
+}
+
+var (
+	taxonomyCategory = taxonomy{table: "category", label: "分类", fallback: "category", notFound: ErrCategoryNotFound}
+	taxonomyTag      = taxonomy{table: "tag", label: "标签", fallback: "tag", notFound: ErrTagNotFound}
+)
+
+// exists 检查 column 等于 value 的记录是否存在，excludeID 为自身ID，新建时为空
+func (t taxonomy) exists(column, value, excludeID string) (bool, error) {
+	var id string
+	err := db.DB.QueryRow(
+		"SELECT id FROM "+t.table+" WHERE "+column+" = ? AND id <> ? LIMIT 1",
+		value, excludeID,
+	).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("检查%s失败: %w", t.label, err)
+	}
+	return true, nil
+}
+
+// nameExists 检查名称是否被其他记录使用
+func (t taxonomy) nameExists(name, excludeID string) (bool, error) {
+	return t.exists("name", name, excludeID)
+}
+
+// slugExists 检查别名是否被其他记录使用
+func (t taxonomy) slugExists(s, excludeID string) (bool, error) {
+	return t.exists("slug", s, excludeID)
+}
+
+// uniqueSlug 按名称生成别名，与其他记录重复时依次追加 -2、-3……
+func (t taxonomy) uniqueSlug(name, excludeID string) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = t.fallback
+	}
+	candidate := base
+	for i := 2; ; i++ {
+		exists, err := t.slugExists(candidate, excludeID)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		suffix := "-" + strconv.Itoa(i)
+		if len(base)+len(suffix) > slug.MaxLength {
+			base = base[:slug.MaxLength-len(suffix)]
+		}
+		candidate = base + suffix
+	}
+}
+
+// lock 合并前在事务中锁定来源和目标记录，两者相同或任一不存在时返回错误
+func (t taxonomy) lock(tx *sql.Tx, sourceID, targetID string) error {
+	if sourceID == targetID {
+		return fmt.Errorf("不能把%s合并到自身", t.label)
+	}
+	var count int
+	err := tx.QueryRow(
+		"SELECT COUNT(*) FROM "+t.table+" WHERE id IN (?, ?) FOR UPDATE",
+		sourceID, targetID,
+	).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("锁定%s失败: %w", t.label, err)
+	}
+	if count != 2 {
+		return t.notFound
+	}
+	return nil
+}
+
+// fillMissingSlugs 为没有别名的记录按名称生成别名，返回补齐的数量
+func (t taxonomy) fillMissingSlugs() (int, error) {
+	rows, err := db.DB.Query("SELECT id, name FROM " + t.table + " WHERE slug IS NULL OR slug = ''")
+	if err != nil {
+		return 0, fmt.Errorf("获取缺少别名的%s失败: %w", t.label, err)
+	}
+	type item struct{ id, name string }
+	var items []item
+	for rows.Next() {
+		var it item
+		if err := rows.Scan(&it.id, &it.name); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("扫描%s行失败: %w", t.label, err)
+		}
+		items = append(items, it)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("遍历%s行失败: %w", t.label, err)
+	}
+
+	for _, it := range items {
+		s, err := t.uniqueSlug(it.name, it.id)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := db.DB.Exec("UPDATE "+t.table+" SET slug = ? WHERE id = ?", s, it.id); err != nil {
+			return 0, fmt.Errorf("补齐%s别名失败: %w", t.label, err)
+		}
+	}
+	return len(items), nil
+}
+
+// categoryCountQuery 按公开文章重新计算分类文章数，包含全部子孙分类的文章；
+// where 限定要计算的分类，其参数排在文章状态之前
+func categoryCountQuery(where string) string {
+	return `UPDATE category c JOIN (
+		WITH RECURSIVE tree AS (
+			SELECT id AS root_id, id FROM category ` + where + `
+			UNION ALL
+			SELECT t.root_id, ch.id FROM tree t JOIN category ch ON ch.parent_id = t.id
+		)
+		SELECT t.root_id, COUNT(a.id) AS total
+		FROM tree t LEFT JOIN article a ON a.category_id = t.id AND a.status = ?
+		GROUP BY t.root_id
+	) s ON s.root_id = c.id
+	SET c.count = s.total`
+}
+
+// tagCountQuery 按公开文章重新计算标签文章数
+const tagCountQuery = `UPDATE tag t SET t.count = (
+		SELECT COUNT(*) FROM relevance r JOIN article a ON a.id = r.article_id
+		WHERE r.tag_id = t.id AND a.status = ?
+	)`
+
+// refreshCategoryCounts 在事务中重新计算指定分类及其全部祖先分类的文章数
+func refreshCategoryCounts(tx *sql.Tx, ids []string) error {
+	ids, err := categoryAncestorIDs(tx, ids)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	args := append(stringArgs(ids), ArticleStatusPublic)
+	if _, err := tx.Exec(categoryCountQuery("WHERE id IN ("+placeholders(len(ids))+")"), args...); err != nil {
+		return fmt.Errorf("更新分类文章数失败: %w", err)
+	}
+	return nil
+}
+
+// refreshTagCounts 在事务中重新计算指定标签的文章数
+func refreshTagCounts(tx *sql.Tx, ids []string) error {
+	ids = uniqueNonEmpty(ids)
+	if len(ids) == 0 {
+		return nil
+	}
+	args := append([]interface{}{ArticleStatusPublic}, stringArgs(ids)...)
+	if _, err := tx.Exec(tagCountQuery+" WHERE t.id IN ("+placeholders(len(ids))+")", args...); err != nil {
+		return fmt.Errorf("更新标签文章数失败: %w", err)
+	}
+	return nil
+}
+
+// TaxonomyReconcileResult 计数校正结果
+// @Description 分类和标签计数校正结果
+type TaxonomyReconcileResult struct {
+	// 文章数被修正的分类数
+	CategoryFixed int64 `json:"category_fixed" example:"1"`
+	// 文章数被修正的标签数
+	TagFixed int64 `json:"tag_fixed" example:"2"`
+	// 补齐别名的分类数
+	CategorySlugFilled int `json:"category_slug_filled" example:"0"`
+	// 补齐别名的标签数
+	TagSlugFilled int `json:"tag_slug_filled" example:"0"`
+}
+
+// ReconcileTaxonomy 按公开文章重新计算全部分类和标签的文章数，并为没有别名的分类和标签补齐别名
+func ReconcileTaxonomy() (*TaxonomyReconcileResult, error) {
+	result := &TaxonomyReconcileResult{}
+
+	res, err := db.DB.Exec(categoryCountQuery(""), ArticleStatusPublic)
+	if err != nil {
+		return nil, fmt.Errorf("校正分类文章数失败: %w", err)
+	}
+	// MySQL 返回的是值实际发生变化的行数，即被修正的数量
+	if result.CategoryFixed, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("获取校正数量失败: %w", err)
+	}
+
+	res, err = db.DB.Exec(tagCountQuery, ArticleStatusPublic)
+	if err != nil {
+		return nil, fmt.Errorf("校正标签文章数失败: %w", err)
+	}
+	if result.TagFixed, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("获取校正数量失败: %w", err)
+	}
+
+	if result.CategorySlugFilled, err = taxonomyCategory.fillMissingSlugs(); err != nil {
+		return nil, err
+	}
+	if result.TagSlugFilled, err = taxonomyTag.fillMissingSlugs(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// queryStrings 查询单列字符串结果
+func queryStrings(q interface {
+	Query(string, ...interface{}) (*sql.Rows, error)
+}, query string, args ...interface{}) ([]string, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// uniqueNonEmpty 去掉空字符串和重复项，保持原顺序
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// stringArgs 把字符串切片转为查询参数
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
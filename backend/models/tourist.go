@@ -0,0 +1,182 @@
+package models
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// maxUserAgentLength 保存的 User-Agent 最大长度，与表字段一致
+const maxUserAgentLength = 512
+
+// TouristPublicID 由游客ID派生可以公开的标识，用于前端区分不同游客。
+// 游客ID本身是游客身份的凭证，不能返回给其他人
+func TouristPublicID(id string) string {
+	if id == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Tourist 游客模型
+type Tourist struct {
+	// 游客ID
+	TouristID string `json:"tourist_id" db:"tourist_id"`
+	// 最近一次访问的IP
+	IpAddress string `json:"ip_address" db:"ip_address"`
+	// 最近一次访问的User-Agent
+	UserAgent string `json:"user_agent" db:"user_agent"`
+	// 合并到的用户ID，未合并时为0
+	MergedUserID int `json:"merged_user_id" db:"merged_user_id"`
+	// 首次访问时间
+	FirstSeenAt int64 `json:"first_seen_at" db:"first_seen_at"`
+	// 最后访问时间
+	LastSeenAt int64 `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// TouristMergeResult 合并游客数据的结果
+type TouristMergeResult struct {
+	Likes    int64 // 转移的点赞数
+	Remarks  int64 // 归属到用户的留言数
+	Messages int64 // 归属到用户的聊天消息数
+}
+
+// GetTourist 根据游客ID获取游客，不存在时返回 nil
+func GetTourist(touristID string) (*Tourist, error) {
+	var (
+		tourist             Tourist
+		firstSeen, lastSeen time.Time
+	)
+	err := db.DB.QueryRow(
+		`SELECT tourist_id, ip_address, user_agent, merged_user_id, first_seen_time, last_seen_time
+		FROM tourist WHERE tourist_id = ?`,
+		touristID,
+	).Scan(&tourist.TouristID, &tourist.IpAddress, &tourist.UserAgent, &tourist.MergedUserID, &firstSeen, &lastSeen)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("获取游客失败: %w", err)
+	}
+	tourist.FirstSeenAt = firstSeen.Unix()
+	tourist.LastSeenAt = lastSeen.Unix()
+	return &tourist, nil
+}
+
+// TouchTourist 记录游客的一次访问，首次访问时创建游客，之后刷新最后访问时间、IP和User-Agent
+func TouchTourist(touristID, ip, userAgent string) error {
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	now := time.Now()
+	_, err := db.DB.Exec(
+		`INSERT INTO tourist (tourist_id, ip_address, user_agent, first_seen_time, last_seen_time)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE ip_address = VALUES(ip_address), user_agent = VALUES(user_agent),
+			last_seen_time = VALUES(last_seen_time)`,
+		touristID, ip, userAgent, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("记录游客访问失败: %w", err)
+	}
+	return nil
+}
+
+// MergeTourist 把游客的点赞、留言和聊天消息合并到用户名下。
+// 游客和用户都点过赞的对象只保留用户的点赞，并把重复计入的点赞数减掉
+func MergeTourist(touristID string, userID int) (*TouristMergeResult, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 找出用户已经点过赞的对象，这些对象的点赞数被游客和用户各算了一次
+	rows, err := tx.Query(
+		`SELECT t.object_type, t.object_id FROM tourist_like t
+		JOIN user_like u ON u.user_id = ? AND u.object_type = t.object_type AND u.object_id = t.object_id
+		WHERE t.tourist_id = ?`,
+		userID, touristID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("查询重复点赞失败: %w", err)
+	}
+	type likeKey struct {
+		objectType int
+		objectID   int64
+	}
+	var duplicates []likeKey
+	for rows.Next() {
+		var key likeKey
+		if err := rows.Scan(&key.objectType, &key.objectID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("扫描重复点赞行失败: %w", err)
+		}
+		duplicates = append(duplicates, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历重复点赞行失败: %w", err)
+	}
+
+	for _, key := range duplicates {
+		if counter, ok := likeCounterTables[key.objectType]; ok {
+			if _, err := tx.Exec(
+				"UPDATE "+counter+" SET like_count = GREATEST(like_count - 1, 0) WHERE id = ?",
+				key.objectID,
+			); err != nil {
+				return nil, fmt.Errorf("更新点赞数失败: %w", err)
+			}
+		}
+	}
+
+	var merged TouristMergeResult
+	result, err := tx.Exec(
+		`INSERT IGNORE INTO user_like (user_id, object_type, object_id, created_time)
+		SELECT ?, object_type, object_id, created_time FROM tourist_like WHERE tourist_id = ?`,
+		userID, touristID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("转移游客点赞失败: %w", err)
+	}
+	if merged.Likes, err = result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("转移游客点赞失败: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM tourist_like WHERE tourist_id = ?", touristID); err != nil {
+		return nil, fmt.Errorf("删除游客点赞失败: %w", err)
+	}
+
+	// 只认领游客身份下产生的内容，已经属于其他用户的不动
+	result, err = tx.Exec("UPDATE remark SET user_id = ? WHERE terminal_id = ? AND user_id = 0", userID, touristID)
+	if err != nil {
+		return nil, fmt.Errorf("合并游客留言失败: %w", err)
+	}
+	if merged.Remarks, err = result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("合并游客留言失败: %w", err)
+	}
+
+	result, err = tx.Exec("UPDATE chat_message SET user_id = ? WHERE terminal_id = ? AND user_id = 0", userID, touristID)
+	if err != nil {
+		return nil, fmt.Errorf("合并游客聊天消息失败: %w", err)
+	}
+	if merged.Messages, err = result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("合并游客聊天消息失败: %w", err)
+	}
+
+	if _, err := tx.Exec(
+		"UPDATE tourist SET merged_user_id = ?, merged_time = NOW() WHERE tourist_id = ?",
+		userID, touristID,
+	); err != nil {
+		return nil, fmt.Errorf("更新游客合并状态失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("提交合并游客事务失败: %w", err)
+	}
+	return &merged, nil
+}
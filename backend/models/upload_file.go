@@ -0,0 +1,215 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// UploadFile 上传文件记录，JSON 格式与前端 FileInfoVO 一致
+// @Description 上传文件信息
+type UploadFile struct {
+	ID     int64 `json:"-" db:"id"`
+	UserID int   `json:"-" db:"user_id"`
+	// 文件路径（目录/内容摘要.扩展名）
+	FilePath string `json:"file_path" db:"file_path" example:"avatar/3f2a9c.png"`
+	// 原始文件名
+	FileName string `json:"file_name" db:"file_name" example:"me.png"`
+	// 文件类型（按内容识别的MIME类型）
+	FileType string `json:"file_type" db:"file_type" example:"image/png"`
+	// 文件大小（字节）
+	FileSize int64 `json:"file_size" db:"file_size" example:"10240"`
+	// 访问地址
+	FileURL string `json:"file_url" db:"file_url" example:"http://localhost:8083/uploads/3f/3f2a9c.png"`
+	// 缩略图地址，图片变体生成前为空
+	ThumbURL string `json:"thumb_url,omitempty" db:"thumb_url" example:"http://localhost:8083/uploads/3f/3f2a9c_thumb.jpg"`
+	// 中图地址，图片变体生成前为空
+	MediumURL string `json:"medium_url,omitempty" db:"medium_url" example:"http://localhost:8083/uploads/3f/3f2a9c_medium.jpg"`
+	// 内容SHA256，相同内容只存储一份
+	FileHash string `json:"-" db:"file_hash"`
+	// 存储后端中的对象键
+	StorageKey string `json:"-" db:"storage_key"`
+	// 更新时间
+	UpdatedAt int64 `json:"updated_at" db:"updated_at" example:"1704067200"`
+}
+
+// uploadFileColumns 查询上传文件记录的列
+const uploadFileColumns = "id, user_id, file_path, file_name, file_type, file_size, file_url, thumb_url, medium_url, file_hash, storage_key, updated_time"
+
+// scanUploadFile 扫描一行上传文件记录
+func scanUploadFile(scanner interface{ Scan(...interface{}) error }) (*UploadFile, error) {
+	var (
+		file        UploadFile
+		updatedTime time.Time
+	)
+	err := scanner.Scan(&file.ID, &file.UserID, &file.FilePath, &file.FileName, &file.FileType,
+		&file.FileSize, &file.FileURL, &file.ThumbURL, &file.MediumURL, &file.FileHash, &file.StorageKey, &updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	file.UpdatedAt = updatedTime.Unix()
+	return &file, nil
+}
+
+// SaveUploadFile 保存上传文件记录。记录按上传人和路径唯一，同一用户重复上传时只更新文件名和更新时间，
+// 其他用户上传相同内容时新建自己的记录，不影响别人的记录
+func SaveUploadFile(file *UploadFile) error {
+	_, err := db.DB.Exec(
+		`INSERT INTO upload_file (user_id, file_path, file_name, file_type, file_size, file_url, file_hash, storage_key, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE file_name = VALUES(file_name), file_url = VALUES(file_url), updated_time = NOW()`,
+		file.UserID, file.FilePath, file.FileName, file.FileType, file.FileSize, file.FileURL, file.FileHash, file.StorageKey,
+	)
+	if err != nil {
+		return fmt.Errorf("保存上传文件记录失败: %w", err)
+	}
+	file.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// GetUploadFilesByPath 根据文件路径获取上传文件记录；userID 为 0 时返回所有用户在该路径下的记录
+func GetUploadFilesByPath(filePath string, userID int) ([]*UploadFile, error) {
+	query := "SELECT " + uploadFileColumns + " FROM upload_file WHERE file_path = ?"
+	args := []interface{}{filePath}
+	if userID != 0 {
+		query += " AND user_id = ?"
+		args = append(args, userID)
+	}
+	rows, err := db.DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("获取上传文件记录失败: %w", err)
+	}
+	defer rows.Close()
+
+	files := []*UploadFile{}
+	for rows.Next() {
+		file, err := scanUploadFile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("扫描上传文件行失败: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历上传文件行失败: %w", err)
+	}
+
+	return files, nil
+}
+
+// GetUploadFileList 获取目录下的上传文件记录，按更新时间倒序；userID 为 0 时不限上传人
+func GetUploadFileList(dir string, userID int, limit int) ([]*UploadFile, int64, error) {
+	where := "WHERE file_path LIKE ?"
+	args := []interface{}{escapeLike(dir) + "/%"}
+	if dir == "" {
+		where = "WHERE 1 = 1"
+		args = nil
+	}
+	if userID != 0 {
+		where += " AND user_id = ?"
+		args = append(args, userID)
+	}
+
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM upload_file "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取上传文件总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		"SELECT "+uploadFileColumns+" FROM upload_file "+where+" ORDER BY updated_time DESC, id DESC LIMIT ?",
+		append(args, limit)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取上传文件列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	files := []*UploadFile{}
+	for rows.Next() {
+		file, err := scanUploadFile(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描上传文件行失败: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历上传文件行失败: %w", err)
+	}
+
+	return files, total, nil
+}
+
+// UpdateUploadFileVariants 更新引用同一存储对象的所有记录的图片变体地址
+func UpdateUploadFileVariants(storageKey, thumbURL, mediumURL string) error {
+	_, err := db.DB.Exec(
+		"UPDATE upload_file SET thumb_url = ?, medium_url = ? WHERE storage_key = ?",
+		thumbURL, mediumURL, storageKey,
+	)
+	if err != nil {
+		return fmt.Errorf("更新图片变体地址失败: %w", err)
+	}
+	return nil
+}
+
+// ImageVariants 图片变体地址
+type ImageVariants struct {
+	ThumbURL  string
+	MediumURL string
+}
+
+// GetImageVariants 根据原图地址批量查询图片变体地址，返回 原图地址 -> 变体地址；
+// 不是通过上传接口上传或变体尚未生成的地址不在结果中
+func GetImageVariants(urls []string) (map[string]ImageVariants, error) {
+	variants := make(map[string]ImageVariants)
+	if len(urls) == 0 {
+		return variants, nil
+	}
+
+	args := make([]interface{}, len(urls))
+	for i, url := range urls {
+		args[i] = url
+	}
+	rows, err := db.DB.Query(
+		"SELECT file_url, thumb_url, medium_url FROM upload_file WHERE thumb_url <> '' AND file_url IN ("+placeholders(len(urls))+")",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取图片变体失败: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var url string
+		var v ImageVariants
+		if err := rows.Scan(&url, &v.ThumbURL, &v.MediumURL); err != nil {
+			return nil, fmt.Errorf("扫描图片变体行失败: %w", err)
+		}
+		variants[url] = v
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历图片变体行失败: %w", err)
+	}
+
+	return variants, nil
+}
+
+// DeleteUploadFile 删除上传文件记录，返回仍引用同一存储对象的记录数，为 0 时调用方可以删除存储对象
+func DeleteUploadFile(id int64, storageKey string) (int64, error) {
+	if _, err := db.DB.Exec("DELETE FROM upload_file WHERE id = ?", id); err != nil {
+		return 0, fmt.Errorf("删除上传文件记录失败: %w", err)
+	}
+	var refs int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM upload_file WHERE storage_key = ?", storageKey).Scan(&refs); err != nil {
+		return 0, fmt.Errorf("统计存储对象引用失败: %w", err)
+	}
+	return refs, nil
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
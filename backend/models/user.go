@@ -1,8 +1,15 @@
 package models
 
 import (
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jayden/personal-blog-backend/db"
 	"golang.org/x/crypto/bcrypt"
@@ -19,11 +26,14 @@ type User struct {
 	Password string `json:"password,omitempty"` // omitempty 表示在序列化时，如果值为空则忽略该字段
 	// 邮箱
 	Email string `json:"email" example:"admin@example.com"`
+	// 角色，管理员为 admin
+	Role string `json:"role" example:"admin"`
 	// 创建时间
 	CreatedTime time.Time `json:"created_time" example:"2023-01-01T00:00:00Z"`
 }
 
 // UserInfo 用户信息模型
+// UserID 是 User.ID 的字符串形式，与前端及评论等模型中的 user_id 保持一致
 type UserInfo struct {
 	UserID       string `json:"user_id" db:"user_id"`
 	Username     string `json:"username" db:"username"`
@@ -37,6 +47,36 @@ type UserInfo struct {
 	Gender  int    `json:"gender" db:"gender"`
 	Intro   string `json:"intro" db:"intro"`
 	Website string `json:"website" db:"website"`
+	// 绑定的第三方平台账号，只在获取当前用户信息时返回
+	ThirdParty []*UserOauth `json:"third_party,omitempty"`
+}
+
+// UserInfoVO 对外展示的用户摘要，用于说说、评论等内容的作者信息
+type UserInfoVO struct {
+	UserID   string `json:"user_id" example:"1"`
+	Username string `json:"username" example:"admin"`
+	Avatar   string `json:"avatar" example:"https://example.com/avatar.png"`
+	Nickname string `json:"nickname" example:"管理员"`
+	Gender   int    `json:"gender" example:"0"`
+	Intro    string `json:"intro" example:""`
+	Website  string `json:"website" example:""`
+}
+
+// ErrUserNotFound 用户不存在
+var ErrUserNotFound = errors.New("用户不存在")
+
+// FormatUserID 把用户ID转换为字符串形式的 user_id
+func FormatUserID(id int) string {
+	return strconv.Itoa(id)
+}
+
+// ParseUserID 把字符串形式的 user_id 转换为用户ID
+func ParseUserID(userID string) (int, error) {
+	id, err := strconv.Atoi(userID)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("无效的用户ID: %q", userID)
+	}
+	return id, nil
 }
 
 // UserLike 用户点赞模型
@@ -81,8 +121,8 @@ func CreateUser(username, password, email string) (*User, error) {
 // GetUserByUsername 根据用户名查找用户
 func GetUserByUsername(username string) (*User, error) {
 	var user User
-	row := db.DB.QueryRow("SELECT id, username, password, email, created_time FROM user WHERE username = ?", username)
-	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.CreatedTime)
+	row := db.DB.QueryRow("SELECT id, username, password, email, role, created_time FROM user WHERE username = ?", username)
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Role, &user.CreatedTime)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // 用户不存在
@@ -95,8 +135,22 @@ func GetUserByUsername(username string) (*User, error) {
 // GetUserByEmail 根据邮箱查找用户
 func GetUserByEmail(email string) (*User, error) {
 	var user User
-	row := db.DB.QueryRow("SELECT id, username, password, email, created_time FROM user WHERE email = ?", email)
-	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.CreatedTime)
+	row := db.DB.QueryRow("SELECT id, username, password, email, role, created_time FROM user WHERE email = ?", email)
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Role, &user.CreatedTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 用户不存在
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserByPhone 根据绑定的手机号查找用户
+func GetUserByPhone(phone string) (*User, error) {
+	var user User
+	row := db.DB.QueryRow("SELECT id, username, password, email, role, created_time FROM user WHERE phone = ?", phone)
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Role, &user.CreatedTime)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // 用户不存在
@@ -106,62 +160,229 @@ func GetUserByEmail(email string) (*User, error) {
 	return &user, nil
 }
 
+// CreateUserByPhone 为首次使用手机号登录的访客创建用户
+// 用户名随机生成，密码为不可用的随机值，之后可以通过绑定邮箱并重置密码来启用密码登录
+func CreateUserByPhone(phone string) (*User, error) {
+	username, hashedPassword, err := randomCredentials("user")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := db.DB.Exec(
+		"INSERT INTO user (username, password, email, phone, register_type, created_time) VALUES (?, ?, '', ?, 'phone', NOW())",
+		username, hashedPassword, phone,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("创建手机号用户失败: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &User{
+		ID:          int(id),
+		Username:    username,
+		CreatedTime: time.Now(),
+	}, nil
+}
+
+// randomCredentials 为验证码登录、第三方登录等自动创建的用户生成随机用户名和不可用的随机密码哈希
+func randomCredentials(prefix string) (username, hashedPassword string, err error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", "", fmt.Errorf("生成用户名失败: %w", err)
+	}
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return "", "", fmt.Errorf("生成密码失败: %w", err)
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(password)), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", err
+	}
+	return prefix + "_" + hex.EncodeToString(suffix), string(hashed), nil
+}
+
+// 密码长度限制，bcrypt 只使用前72个字节
+const (
+	minPasswordLength = 6
+	maxPasswordLength = 72
+)
+
+var (
+	// ErrPasswordTooShort 密码太短
+	ErrPasswordTooShort = errors.New("密码长度不能少于6位")
+	// ErrPasswordTooLong 密码超过 bcrypt 能使用的长度
+	ErrPasswordTooLong = errors.New("密码长度不能超过72个字节")
+)
+
+// ValidatePassword 校验密码长度，注册、重置和修改密码时共用
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return ErrPasswordTooShort
+	}
+	if len(password) > maxPasswordLength {
+		return ErrPasswordTooLong
+	}
+	return nil
+}
+
 // VerifyPassword 验证密码是否正确
 func VerifyPassword(hashedPassword, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
 
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// DummyVerifyPassword 用户不存在时做一次同等开销的密码比对，避免通过响应时间判断用户名是否存在
+func DummyVerifyPassword(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+	})
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
+// GetUserAuthByID 根据ID获取包含密码哈希的用户记录，用于需要校验密码的场景
+func GetUserAuthByID(id int) (*User, error) {
+	var user User
+	row := db.DB.QueryRow("SELECT id, username, password, email, role, created_time FROM user WHERE id = ?", id)
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Role, &user.CreatedTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 用户不存在
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserRole 获取用户当前的角色，用户不存在时返回空字符串
+func GetUserRole(id int) (string, error) {
+	var role string
+	err := db.DB.QueryRow("SELECT role FROM user WHERE id = ?", id).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("获取用户角色失败: %w", err)
+	}
+	return role, nil
+}
+
 // GetUserByID 根据ID获取用户信息
-func GetUserByID(userID string) (*UserInfo, error) {
-	// 实现根据ID获取用户信息逻辑
-	return &UserInfo{}, nil
+func GetUserByID(id int) (*UserInfo, error) {
+	var (
+		info        UserInfo
+		createdTime time.Time
+	)
+	err := db.DB.QueryRow(
+		`SELECT id, username, nickname, avatar, email, COALESCE(phone, ''), register_type, gender, intro, website, created_time
+		FROM user WHERE id = ?`,
+		id,
+	).Scan(&id, &info.Username, &info.Nickname, &info.Avatar, &info.Email, &info.Phone,
+		&info.RegisterType, &info.Gender, &info.Intro, &info.Website, &createdTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 用户不存在
+		}
+		return nil, fmt.Errorf("获取用户信息失败: %w", err)
+	}
+
+	info.UserID = FormatUserID(id)
+	info.CreatedAt = createdTime.Unix()
+	if info.Nickname == "" {
+		info.Nickname = info.Username
+	}
+	return &info, nil
 }
 
 // UpdateUserAvatar 更新用户头像
-func UpdateUserAvatar(userID, avatar string) error {
-	// 实现更新用户头像逻辑
-	return nil
+func UpdateUserAvatar(id int, avatar string) error {
+	return execUserUpdate("UPDATE user SET avatar = ? WHERE id = ?", avatar, id)
 }
 
 // UpdateUserBindEmail 更新用户绑定邮箱
-func UpdateUserBindEmail(userID, email string) error {
-	// 实现更新用户绑定邮箱逻辑
-	return nil
+func UpdateUserBindEmail(id int, email string) error {
+	return execUserUpdate("UPDATE user SET email = ? WHERE id = ?", email, id)
 }
 
-// UpdateUserBindPhone 更新用户绑定手机号
-func UpdateUserBindPhone(userID, phone string) error {
-	// 实现更新用户绑定手机号逻辑
-	return nil
+// UpdateUserBindPhone 更新用户绑定手机号，phone 为空表示解绑
+// 手机号有唯一索引，未绑定时存为 NULL
+func UpdateUserBindPhone(id int, phone string) error {
+	return execUserUpdate("UPDATE user SET phone = NULLIF(?, '') WHERE id = ?", phone, id)
 }
 
-// UpdateUserBindThirdParty 更新用户绑定第三方平台账号
-func UpdateUserBindThirdParty(userID, platform, openID, nickname, avatar string) error {
-	// 实现更新用户绑定第三方平台账号逻辑
-	return nil
+// UpdateUserInfo 更新用户信息
+func UpdateUserInfo(id int, nickname, intro, website string, gender int) error {
+	return execUserUpdate(
+		"UPDATE user SET nickname = ?, intro = ?, website = ?, gender = ? WHERE id = ?",
+		nickname, intro, website, gender, id,
+	)
 }
 
-// DeleteUserBindThirdParty 删除用户绑定第三方平台账号
-func DeleteUserBindThirdParty(userID, platform string) error {
-	// 实现删除用户绑定第三方平台账号逻辑
-	return nil
+// UpdateUserPassword 更新用户密码，newPassword 为明文，保存前使用 bcrypt 哈希
+func UpdateUserPassword(id int, newPassword string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("密码哈希失败: %w", err)
+	}
+	return execUserUpdate("UPDATE user SET password = ? WHERE id = ?", string(hashedPassword), id)
 }
 
-// UpdateUserInfo 更新用户信息
-func UpdateUserInfo(userID, nickname, intro, website string, gender int) error {
-	// 实现更新用户信息逻辑
-	return nil
-}
+// GetUserInfoVOs 批量获取用户摘要，返回 用户ID -> 用户摘要，不存在的用户不在结果中
+func GetUserInfoVOs(ids []int) (map[int]*UserInfoVO, error) {
+	users := make(map[int]*UserInfoVO)
+	if len(ids) == 0 {
+		return users, nil
+	}
 
-// UpdateUserPassword 更新用户密码
-func UpdateUserPassword(userID, newPassword string) error {
-	// 实现更新用户密码逻辑
-	return nil
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := db.DB.Query(
+		"SELECT id, username, avatar, nickname, gender, intro, website FROM user WHERE id IN ("+placeholders(len(ids))+")",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取用户摘要失败: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   int
+			user UserInfoVO
+		)
+		if err := rows.Scan(&id, &user.Username, &user.Avatar, &user.Nickname, &user.Gender, &user.Intro, &user.Website); err != nil {
+			return nil, fmt.Errorf("扫描用户摘要行失败: %w", err)
+		}
+		user.UserID = FormatUserID(id)
+		if user.Nickname == "" {
+			user.Nickname = user.Username
+		}
+		users[id] = &user
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历用户摘要行失败: %w", err)
+	}
+
+	return users, nil
 }
 
-// GetUserLike 获取用户点赞列表
-func GetUserLike(userID string) (*UserLike, error) {
-	// 实现获取用户点赞列表逻辑
-	return &UserLike{}, nil
+// execUserUpdate 执行针对单个用户的更新语句，用户不存在时返回 ErrUserNotFound
+func execUserUpdate(query string, args ...interface{}) error {
+	result, err := db.DB.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("更新用户失败: %w", err)
+	}
+	// 更新语句的最后一个参数约定为用户ID
+	return requireExists(result, "user", "id = ?", ErrUserNotFound, args[len(args)-1])
 }
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// 点赞对象类型
+const (
+	LikeTypeArticle = 1
+	LikeTypeComment = 2
+	LikeTypeTalk    = 3
+)
+
+// likeCounterTables 点赞时需要同步累加 like_count 的表
+var likeCounterTables = map[int]string{
+	LikeTypeTalk: "talk",
+}
+
+// ToggleLike 切换用户对某个对象的点赞状态，返回切换后是否为已点赞
+func ToggleLike(userID, objectType int, objectID int64) (bool, error) {
+	return toggleLike("user_like", "user_id", userID, objectType, objectID)
+}
+
+// ToggleTouristLike 切换游客对某个对象的点赞状态，返回切换后是否为已点赞
+func ToggleTouristLike(touristID string, objectType int, objectID int64) (bool, error) {
+	return toggleLike("tourist_like", "tourist_id", touristID, objectType, objectID)
+}
+
+// toggleLike 在 table 中切换 owner 对某个对象的点赞状态，并同步点赞数
+func toggleLike(table, ownerColumn string, owner interface{}, objectType int, objectID int64) (bool, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return false, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"INSERT IGNORE INTO "+table+" ("+ownerColumn+", object_type, object_id, created_time) VALUES (?, ?, ?, NOW())",
+		owner, objectType, objectID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("点赞失败: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("点赞失败: %w", err)
+	}
+
+	delta := 1
+	if inserted == 0 {
+		// 已经点过赞，本次为取消点赞
+		if _, err := tx.Exec(
+			"DELETE FROM "+table+" WHERE "+ownerColumn+" = ? AND object_type = ? AND object_id = ?",
+			owner, objectType, objectID,
+		); err != nil {
+			return false, fmt.Errorf("取消点赞失败: %w", err)
+		}
+		delta = -1
+	}
+
+	if counter, ok := likeCounterTables[objectType]; ok {
+		if _, err := tx.Exec(
+			"UPDATE "+counter+" SET like_count = GREATEST(like_count + ?, 0) WHERE id = ?",
+			delta, objectID,
+		); err != nil {
+			return false, fmt.Errorf("更新点赞数失败: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("提交点赞事务失败: %w", err)
+	}
+	return delta > 0, nil
+}
+
+// GetUserLike 获取用户点赞列表
+func GetUserLike(id int) (*UserLike, error) {
+	like := &UserLike{
+		ArticleLikeSet: []int64{},
+		CommentLikeSet: []int64{},
+		TalkLikeSet:    []int64{},
+	}
+
+	rows, err := db.DB.Query("SELECT object_type, object_id FROM user_like WHERE user_id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("获取用户点赞列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			objectType int
+			objectID   int64
+		)
+		if err := rows.Scan(&objectType, &objectID); err != nil {
+			return nil, fmt.Errorf("扫描用户点赞行失败: %w", err)
+		}
+		switch objectType {
+		case LikeTypeArticle:
+			like.ArticleLikeSet = append(like.ArticleLikeSet, objectID)
+		case LikeTypeComment:
+			like.CommentLikeSet = append(like.CommentLikeSet, objectID)
+		case LikeTypeTalk:
+			like.TalkLikeSet = append(like.TalkLikeSet, objectID)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历用户点赞行失败: %w", err)
+	}
+
+	return like, nil
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// ErrThirdPartyBound 第三方账号已绑定其他用户
+var ErrThirdPartyBound = errors.New("该第三方账号已绑定其他用户")
+
+// UserOauth 用户绑定的第三方平台账号
+type UserOauth struct {
+	UserID    int    `json:"-" db:"user_id"`
+	Platform  string `json:"platform" db:"platform" example:"github"`
+	OpenID    string `json:"open_id" db:"open_id" example:"123456"`
+	Nickname  string `json:"nickname" db:"nickname" example:"octocat"`
+	Avatar    string `json:"avatar" db:"avatar"`
+	CreatedAt int64  `json:"created_at" db:"created_at"`
+}
+
+// GetUserOauth 根据平台和平台用户ID获取绑定记录
+func GetUserOauth(platform, openID string) (*UserOauth, error) {
+	var (
+		binding     UserOauth
+		createdTime time.Time
+	)
+	err := db.DB.QueryRow(
+		"SELECT user_id, platform, open_id, nickname, avatar, created_time FROM user_oauth WHERE platform = ? AND open_id = ?",
+		platform, openID,
+	).Scan(&binding.UserID, &binding.Platform, &binding.OpenID, &binding.Nickname, &binding.Avatar, &createdTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("获取第三方账号绑定失败: %w", err)
+	}
+	binding.CreatedAt = createdTime.Unix()
+	return &binding, nil
+}
+
+// GetUserOauthList 获取用户绑定的全部第三方平台账号
+func GetUserOauthList(userID int) ([]*UserOauth, error) {
+	rows, err := db.DB.Query(
+		"SELECT user_id, platform, open_id, nickname, avatar, created_time FROM user_oauth WHERE user_id = ? ORDER BY created_time",
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取第三方账号绑定列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	bindings := []*UserOauth{}
+	for rows.Next() {
+		var (
+			binding     UserOauth
+			createdTime time.Time
+		)
+		if err := rows.Scan(&binding.UserID, &binding.Platform, &binding.OpenID, &binding.Nickname, &binding.Avatar, &createdTime); err != nil {
+			return nil, fmt.Errorf("扫描第三方账号绑定行失败: %w", err)
+		}
+		binding.CreatedAt = createdTime.Unix()
+		bindings = append(bindings, &binding)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历第三方账号绑定行失败: %w", err)
+	}
+
+	return bindings, nil
+}
+
+// UpdateUserBindThirdParty 更新用户绑定第三方平台账号，同一平台只能绑定一个账号，重复绑定时覆盖
+func UpdateUserBindThirdParty(id int, platform, openID, nickname, avatar string) error {
+	existing, err := GetUserOauth(platform, openID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.UserID != id {
+		return ErrThirdPartyBound
+	}
+
+	_, err = db.DB.Exec(
+		`INSERT INTO user_oauth (user_id, platform, open_id, nickname, avatar, created_time) VALUES (?, ?, ?, ?, ?, NOW())
+		ON DUPLICATE KEY UPDATE open_id = VALUES(open_id), nickname = VALUES(nickname), avatar = VALUES(avatar)`,
+		id, platform, openID, nickname, avatar,
+	)
+	if err != nil {
+		return fmt.Errorf("绑定第三方账号失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteUserBindThirdParty 删除用户绑定第三方平台账号
+func DeleteUserBindThirdParty(id int, platform string) error {
+	_, err := db.DB.Exec("DELETE FROM user_oauth WHERE user_id = ? AND platform = ?", id, platform)
+	if err != nil {
+		return fmt.Errorf("解绑第三方账号失败: %w", err)
+	}
+	return nil
+}
+
+// CreateUserByOauth 为首次使用第三方登录的访客创建用户并绑定第三方账号
+func CreateUserByOauth(platform, openID, nickname, avatar string) (*User, error) {
+	username, hashedPassword, err := randomCredentials(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`INSERT INTO user (username, password, email, nickname, avatar, register_type, created_time)
+		VALUES (?, ?, '', ?, ?, ?, NOW())`,
+		username, hashedPassword, nickname, avatar, platform,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("创建第三方登录用户失败: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO user_oauth (user_id, platform, open_id, nickname, avatar, created_time) VALUES (?, ?, ?, ?, ?, NOW())",
+		id, platform, openID, nickname, avatar,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("绑定第三方账号失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("提交事务失败: %w", err)
+	}
+
+	return &User{
+		ID:          int(id),
+		Username:    username,
+		CreatedTime: time.Now(),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// DailyViewStat 每日访问统计模型
+type DailyViewStat struct {
+	// 统计日期，格式 2006-01-02
+	Day string `json:"day" db:"day" example:"2024-01-01"`
+	// 浏览量(PV)
+	PageViewCount int64 `json:"page_view_count" db:"page_view_count" example:"120"`
+	// 访客数(UV)
+	UserViewCount int64 `json:"user_view_count" db:"user_view_count" example:"35"`
+}
+
+// AddArticleViews 批量累加文章浏览量，views 为 文章ID -> 新增浏览量
+func AddArticleViews(views map[string]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("UPDATE article SET views_count = views_count + ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("预处理浏览量语句失败: %w", err)
+	}
+	defer stmt.Close()
+
+	for articleID, count := range views {
+		if _, err := stmt.Exec(count, articleID); err != nil {
+			return fmt.Errorf("累加文章浏览量失败: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交浏览量事务失败: %w", err)
+	}
+	return nil
+}
+
+// AddDailyViewStat 累加某一天的浏览量和访客数
+func AddDailyViewStat(day string, pv, uv int64) error {
+	_, err := db.DB.Exec(
+		`INSERT INTO daily_view_stat (day, page_view_count, user_view_count) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE page_view_count = page_view_count + VALUES(page_view_count),
+		user_view_count = user_view_count + VALUES(user_view_count)`,
+		day, pv, uv,
+	)
+	if err != nil {
+		return fmt.Errorf("累加每日访问统计失败: %w", err)
+	}
+	return nil
+}
+
+// GetDailyViewStats 获取最近 days 天的每日访问统计，按日期升序
+func GetDailyViewStats(days int) ([]*DailyViewStat, error) {
+	rows, err := db.DB.Query(
+		`SELECT DATE_FORMAT(day, '%Y-%m-%d'), page_view_count, user_view_count FROM daily_view_stat
+		WHERE day > DATE_SUB(CURDATE(), INTERVAL ? DAY) ORDER BY day ASC`,
+		days,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("获取每日访问统计失败: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*DailyViewStat
+	for rows.Next() {
+		stat := &DailyViewStat{}
+		if err := rows.Scan(&stat.Day, &stat.PageViewCount, &stat.UserViewCount); err != nil {
+			return nil, fmt.Errorf("扫描每日访问统计行失败: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历每日访问统计行失败: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetViewTotals 获取累计浏览量(PV)和累计访客数(UV)
+func GetViewTotals() (pv, uv int64, err error) {
+	err = db.DB.QueryRow(
+		"SELECT COALESCE(SUM(page_view_count), 0), COALESCE(SUM(user_view_count), 0) FROM daily_view_stat",
+	).Scan(&pv, &uv)
+	if err != nil {
+		return 0, 0, fmt.Errorf("获取累计访问统计失败: %w", err)
+	}
+	return pv, uv, nil
+}
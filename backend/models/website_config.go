@@ -0,0 +1,223 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/db"
+)
+
+// ErrWebsiteConfigVersionNotFound 网站配置版本不存在
+var ErrWebsiteConfigVersionNotFound = errors.New("网站配置版本不存在")
+
+// 网站配置项
+const (
+	ConfigKeyWebsite = "website_config" // 网站配置
+	ConfigKeyAboutMe = "about_me"       // 关于我
+)
+
+// WebsiteConfig 网站配置
+type WebsiteConfig struct {
+	// 后台地址
+	AdminUrl string `json:"admin_url"`
+	// websocket地址
+	WebsocketUrl string `json:"websocket_url"`
+	// 游客头像
+	TouristAvatar string `json:"tourist_avatar"`
+	// 用户默认头像
+	UserAvatar string `json:"user_avatar"`
+	// 网站功能
+	WebsiteFeature WebsiteFeature `json:"website_feature"`
+	// 网站信息
+	WebsiteInfo WebsiteInfo `json:"website_info"`
+	// 打赏二维码
+	RewardQrCode RewardQrCode `json:"reward_qr_code"`
+	// 作者社交地址列表
+	SocialUrlList []SocialAccountInfo `json:"social_url_list"`
+}
+
+// WebsiteFeature 网站功能开关，0关闭 1开启
+type WebsiteFeature struct {
+	IsChatRoom      int `json:"is_chat_room"`
+	IsCommentReview int `json:"is_comment_review"`
+	IsEmailNotice   int `json:"is_email_notice"`
+	IsMessageReview int `json:"is_message_review"`
+	IsMusicPlayer   int `json:"is_music_player"`
+	IsReward        int `json:"is_reward"`
+}
+
+// WebsiteInfo 网站信息
+type WebsiteInfo struct {
+	WebsiteAuthor     string `json:"website_author"`
+	WebsiteAvatar     string `json:"website_avatar"`
+	WebsiteCreateTime string `json:"website_create_time"`
+	WebsiteIntro      string `json:"website_intro"`
+	WebsiteName       string `json:"website_name"`
+	WebsiteNotice     string `json:"website_notice"`
+	WebsiteRecordNo   string `json:"website_record_no"`
+}
+
+// RewardQrCode 打赏二维码
+type RewardQrCode struct {
+	AlipayQrCode string `json:"alipay_qr_code"`
+	WeixinQrCode string `json:"weixin_qr_code"`
+}
+
+// SocialAccountInfo 作者社交账号
+type SocialAccountInfo struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	LinkUrl  string `json:"link_url"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// ThirdPlatformInfo 第三方登录平台
+type ThirdPlatformInfo struct {
+	Name         string `json:"name"`
+	Platform     string `json:"platform"`
+	AuthorizeUrl string `json:"authorize_url"`
+	Enabled      bool   `json:"enabled"`
+}
+
+// AboutMe 关于我
+type AboutMe struct {
+	Content string `json:"content"`
+}
+
+// WebsiteConfigVersion 网站配置的历史版本
+type WebsiteConfigVersion struct {
+	// 配置项
+	ConfigKey string `json:"config_key"`
+	// 版本号
+	Version int `json:"version"`
+	// 该版本的配置内容
+	ConfigValue json.RawMessage `json:"config_value"`
+	// 修改人ID
+	OperatorID int `json:"operator_id"`
+	// 回滚来源版本，不是回滚产生的版本为0
+	RollbackFrom int   `json:"rollback_from"`
+	CreatedAt    int64 `json:"created_at"`
+}
+
+// GetWebsiteConfigValue 获取配置项的当前内容和版本号，配置项不存在时返回空字符串和0
+func GetWebsiteConfigValue(key string) (string, int, error) {
+	var (
+		value   string
+		version int
+	)
+	err := db.DB.QueryRow("SELECT config_value, version FROM website_config WHERE config_key = ?", key).Scan(&value, &version)
+	if err == sql.ErrNoRows {
+		return "", 0, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("获取网站配置失败: %w", err)
+	}
+	return value, version, nil
+}
+
+// SaveWebsiteConfigValue 保存配置项的新内容并记录一个新版本，返回新的版本号；
+// rollbackFrom 为回滚来源版本，普通修改传0
+func SaveWebsiteConfigValue(key, value string, operatorID, rollbackFrom int) (int, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("开启事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	var version int
+	err = tx.QueryRow("SELECT version FROM website_config WHERE config_key = ? FOR UPDATE", key).Scan(&version)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, fmt.Errorf("获取网站配置版本失败: %w", err)
+	}
+	version++
+
+	now := time.Now()
+	_, err = tx.Exec(
+		`INSERT INTO website_config (config_key, config_value, version, created_time, updated_time)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE config_value = VALUES(config_value), version = VALUES(version), updated_time = VALUES(updated_time)`,
+		key, value, version, now, now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("保存网站配置失败: %w", err)
+	}
+	_, err = tx.Exec(
+		`INSERT INTO website_config_version (config_key, version, config_value, operator_id, rollback_from, created_time)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		key, version, value, operatorID, rollbackFrom, now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("记录网站配置版本失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("提交网站配置事务失败: %w", err)
+	}
+	return version, nil
+}
+
+// GetWebsiteConfigVersions 获取配置项的历史版本，按版本号倒序
+func GetWebsiteConfigVersions(key string, limit, offset int) ([]WebsiteConfigVersion, int64, error) {
+	var total int64
+	if err := db.DB.QueryRow("SELECT COUNT(*) FROM website_config_version WHERE config_key = ?", key).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("获取网站配置版本总数失败: %w", err)
+	}
+
+	rows, err := db.DB.Query(
+		`SELECT config_key, version, config_value, operator_id, rollback_from, created_time
+		FROM website_config_version WHERE config_key = ? ORDER BY version DESC LIMIT ? OFFSET ?`,
+		key, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取网站配置版本列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []WebsiteConfigVersion{}
+	for rows.Next() {
+		v, err := scanWebsiteConfigVersion(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("扫描网站配置版本行失败: %w", err)
+		}
+		versions = append(versions, *v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("遍历网站配置版本行失败: %w", err)
+	}
+	return versions, total, nil
+}
+
+// GetWebsiteConfigVersion 获取配置项的指定版本
+func GetWebsiteConfigVersion(key string, version int) (*WebsiteConfigVersion, error) {
+	v, err := scanWebsiteConfigVersion(db.DB.QueryRow(
+		`SELECT config_key, version, config_value, operator_id, rollback_from, created_time
+		FROM website_config_version WHERE config_key = ? AND version = ?`,
+		key, version,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrWebsiteConfigVersionNotFound
+		}
+		return nil, fmt.Errorf("获取网站配置版本失败: %w", err)
+	}
+	return v, nil
+}
+
+// scanWebsiteConfigVersion 扫描一行网站配置版本记录
+func scanWebsiteConfigVersion(scanner interface{ Scan(...interface{}) error }) (*WebsiteConfigVersion, error) {
+	var (
+		v           WebsiteConfigVersion
+		value       string
+		createdTime time.Time
+	)
+	if err := scanner.Scan(&v.ConfigKey, &v.Version, &value, &v.OperatorID, &v.RollbackFrom, &createdTime); err != nil {
+		return nil, err
+	}
+	v.ConfigValue = json.RawMessage(value)
+	v.CreatedAt = createdTime.Unix()
+	return &v, nil
+}
@@ -0,0 +1,125 @@
+package netutil
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var (
+	proxiesMu      sync.RWMutex
+	trustedProxies []*net.IPNet
+)
+
+// SetTrustedProxies 设置受信任的反向代理，支持单个IP和CIDR，逗号分隔；
+// 只有直连地址属于受信任代理时才会读取 X-Forwarded-For
+func SetTrustedProxies(list string) error {
+	var nets []*net.IPNet
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return fmt.Errorf("无效的代理地址: %s", item)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return fmt.Errorf("无效的代理网段 %s: %w", item, err)
+		}
+		nets = append(nets, ipNet)
+	}
+
+	proxiesMu.Lock()
+	trustedProxies = nets
+	proxiesMu.Unlock()
+	return nil
+}
+
+// isTrustedProxy 判断地址是否属于受信任代理
+func isTrustedProxy(ip net.IP) bool {
+	proxiesMu.RLock()
+	defer proxiesMu.RUnlock()
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP 获取请求的客户端IP。直连地址是受信任代理时，从右向左读取 X-Forwarded-For，
+// 跳过受信任代理，返回第一个不受信任的地址；客户端自己伪造的靠左部分不会被采用
+func ClientIP(r *http.Request) string {
+	remote := remoteIP(r)
+	ip := net.ParseIP(remote)
+	if ip == nil || !isTrustedProxy(ip) {
+		return remote
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := parseHop(hops[i])
+		if hop == nil {
+			// 无法解析的地址说明链路被篡改，不再继续向左信任
+			break
+		}
+		if !isTrustedProxy(hop) {
+			return hop.String()
+		}
+	}
+
+	if realIP := parseHop(r.Header.Get("X-Real-IP")); realIP != nil {
+		return realIP.String()
+	}
+	return remote
+}
+
+// remoteIP 返回直连地址中的IP部分，IPv4 映射的 IPv6 地址转换为 IPv4 形式
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	return host
+}
+
+// parseHop 解析代理头中的一个地址，兼容部分代理附带端口的写法，如 1.2.3.4:5678、[::1]:80
+func parseHop(hop string) net.IP {
+	hop = strings.TrimSpace(hop)
+	if ip := net.ParseIP(hop); ip != nil {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		return net.ParseIP(host)
+	}
+	return nil
+}
+
+// MaskIP 隐藏IP的后半部分，在公开接口或广播中展示其他人的IP时使用
+func MaskIP(addr string) string {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return ""
+	}
+	if v4 := ip.To4(); v4 != nil {
+		parts := strings.Split(v4.String(), ".")
+		return parts[0] + "." + parts[1] + ".*.*"
+	}
+	return strings.Join(strings.Split(ip.String(), ":")[:2], ":") + ":*"
+}
@@ -0,0 +1,33 @@
+package netutil
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RequestOrigin 返回请求的协议和主机，如 https://example.com。直连地址是受信任代理时，
+// 采用 X-Forwarded-Proto 和 X-Forwarded-Host，否则只看连接本身和 Host 头
+func RequestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+
+	if ip := net.ParseIP(remoteIP(r)); ip != nil && isTrustedProxy(ip) {
+		if proto := firstHeaderValue(r, "X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if forwardedHost := firstHeaderValue(r, "X-Forwarded-Host"); forwardedHost != "" {
+			host = forwardedHost
+		}
+	}
+	return scheme + "://" + host
+}
+
+// firstHeaderValue 返回逗号分隔的代理头中最靠左的值，即最外层代理收到的值
+func firstHeaderValue(r *http.Request, name string) string {
+	value := strings.SplitN(r.Header.Get(name), ",", 2)[0]
+	return strings.ToLower(strings.TrimSpace(value))
+}
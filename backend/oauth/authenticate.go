@@ -0,0 +1,55 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+var (
+	// ErrMissingCode 缺少授权码
+	ErrMissingCode = errors.New("授权码不能为空")
+	// ErrProviderFailure 第三方平台请求失败
+	ErrProviderFailure = errors.New("第三方平台授权失败，请稍后再试")
+)
+
+// Authenticate 校验state并通过授权码获取第三方用户资料
+// clientNonce 为获取授权地址时客户端提交的随机串；userID 为 0 时只校验state，
+// 非 0 时还要求state由该用户发起，用于绑定账号
+func Authenticate(ctx context.Context, platform, code, state, clientNonce string, userID int) (*Profile, error) {
+	provider, err := Get(platform)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return nil, ErrMissingCode
+	}
+
+	claims, err := VerifyState(state, platform, clientNonce)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 && claims.UserID != userID {
+		return nil, ErrInvalidState
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	token, err := provider.Exchange(ctx, code)
+	if err != nil {
+		log.Printf("第三方平台 %s 换取令牌失败: %v", platform, err)
+		return nil, ErrProviderFailure
+	}
+	profile, err := provider.FetchProfile(ctx, token)
+	if err != nil {
+		log.Printf("第三方平台 %s 获取用户资料失败: %v", platform, err)
+		return nil, ErrProviderFailure
+	}
+	if profile.OpenID == "" {
+		log.Printf("第三方平台 %s 返回的用户资料缺少用户ID", platform)
+		return nil, ErrProviderFailure
+	}
+	return profile, nil
+}
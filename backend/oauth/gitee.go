@@ -0,0 +1,72 @@
+package oauth
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Gitee 码云登录
+type Gitee struct {
+	endpoint Endpoint
+}
+
+// NewGitee 创建 Gitee 平台
+func NewGitee(endpoint Endpoint) *Gitee {
+	endpoint.AuthURL = withDefault(endpoint.AuthURL, "https://gitee.com/oauth/authorize")
+	endpoint.TokenURL = withDefault(endpoint.TokenURL, "https://gitee.com/oauth/token")
+	endpoint.UserInfoURL = withDefault(endpoint.UserInfoURL, "https://gitee.com/api/v5/user")
+	return &Gitee{endpoint: endpoint}
+}
+
+// Name 平台标识
+func (p *Gitee) Name() string {
+	return "gitee"
+}
+
+// AuthorizeURL 构建授权地址
+func (p *Gitee) AuthorizeURL(state string) string {
+	return buildURL(p.endpoint.AuthURL, url.Values{
+		"client_id":     {p.endpoint.ClientID},
+		"redirect_uri":  {p.endpoint.RedirectURL},
+		"response_type": {"code"},
+		"scope":         {"user_info"},
+		"state":         {state},
+	})
+}
+
+// Exchange 使用授权码换取访问令牌
+func (p *Gitee) Exchange(ctx context.Context, code string) (*Token, error) {
+	var resp tokenResponse
+	err := postFormJSON(ctx, p.endpoint.TokenURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {p.endpoint.ClientID},
+		"client_secret": {p.endpoint.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {p.endpoint.RedirectURL},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.toToken()
+}
+
+// FetchProfile 获取用户资料
+func (p *Gitee) FetchProfile(ctx context.Context, token *Token) (*Profile, error) {
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+		Email     string `json:"email"`
+	}
+	if err := getJSON(ctx, p.endpoint.UserInfoURL, token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+	return &Profile{
+		OpenID:   strconv.FormatInt(user.ID, 10),
+		Nickname: withDefault(user.Name, user.Login),
+		Avatar:   user.AvatarURL,
+		Email:    user.Email,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package oauth
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// GitHub GitHub 登录
+type GitHub struct {
+	endpoint Endpoint
+}
+
+// NewGitHub 创建 GitHub 平台
+func NewGitHub(endpoint Endpoint) *GitHub {
+	endpoint.AuthURL = withDefault(endpoint.AuthURL, "https://github.com/login/oauth/authorize")
+	endpoint.TokenURL = withDefault(endpoint.TokenURL, "https://github.com/login/oauth/access_token")
+	endpoint.UserInfoURL = withDefault(endpoint.UserInfoURL, "https://api.github.com/user")
+	return &GitHub{endpoint: endpoint}
+}
+
+// Name 平台标识
+func (p *GitHub) Name() string {
+	return "github"
+}
+
+// AuthorizeURL 构建授权地址
+func (p *GitHub) AuthorizeURL(state string) string {
+	return buildURL(p.endpoint.AuthURL, url.Values{
+		"client_id":    {p.endpoint.ClientID},
+		"redirect_uri": {p.endpoint.RedirectURL},
+		"scope":        {"read:user user:email"},
+		"state":        {state},
+	})
+}
+
+// Exchange 使用授权码换取访问令牌
+func (p *GitHub) Exchange(ctx context.Context, code string) (*Token, error) {
+	var resp tokenResponse
+	err := postFormJSON(ctx, p.endpoint.TokenURL, url.Values{
+		"client_id":     {p.endpoint.ClientID},
+		"client_secret": {p.endpoint.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {p.endpoint.RedirectURL},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.toToken()
+}
+
+// FetchProfile 获取用户资料
+func (p *GitHub) FetchProfile(ctx context.Context, token *Token) (*Profile, error) {
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+		Email     string `json:"email"`
+	}
+	if err := getJSON(ctx, p.endpoint.UserInfoURL, token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+	return &Profile{
+		OpenID:   strconv.FormatInt(user.ID, 10),
+		Nickname: withDefault(user.Name, user.Login),
+		Avatar:   user.AvatarURL,
+		Email:    user.Email,
+	}, nil
+}
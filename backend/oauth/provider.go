@@ -0,0 +1,196 @@
+// Package oauth 实现第三方登录：各平台的授权地址构建、授权码换取令牌和用户资料获取，以及防CSRF的签名state
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// ErrUnknownPlatform 未注册的平台
+var ErrUnknownPlatform = errors.New("不支持的第三方平台")
+
+// Profile 第三方平台的用户资料
+type Profile struct {
+	OpenID   string
+	Nickname string
+	Avatar   string
+	Email    string
+}
+
+// Token 第三方平台返回的访问令牌
+type Token struct {
+	AccessToken string
+}
+
+// Provider 第三方平台
+type Provider interface {
+	// Name 平台标识，如 github
+	Name() string
+	// AuthorizeURL 构建跳转到平台授权页的地址
+	AuthorizeURL(state string) string
+	// Exchange 使用授权码换取访问令牌
+	Exchange(ctx context.Context, code string) (*Token, error)
+	// FetchProfile 使用访问令牌获取用户资料
+	FetchProfile(ctx context.Context, token *Token) (*Profile, error)
+}
+
+// Endpoint 平台配置，各地址为空时使用平台的默认地址，测试时可指向本地的假OAuth服务
+type Endpoint struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+}
+
+var (
+	mu        sync.RWMutex
+	providers = make(map[string]Provider)
+)
+
+// Register 注册平台，同名平台会被覆盖
+func Register(p Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[p.Name()] = p
+}
+
+// Get 获取已注册的平台
+func Get(platform string) (Provider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := providers[platform]
+	if !ok {
+		return nil, ErrUnknownPlatform
+	}
+	return p, nil
+}
+
+// Platforms 返回已注册的平台标识，按字母排序
+func Platforms() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// httpClient 请求第三方平台使用的客户端
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// withDefault 返回 value，为空时返回 defaultValue
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// buildURL 在 base 上追加查询参数
+func buildURL(base string, params url.Values) string {
+	if strings.Contains(base, "?") {
+		return base + "&" + params.Encode()
+	}
+	return base + "?" + params.Encode()
+}
+
+// doJSON 发送请求并把JSON响应解析到 out
+func doJSON(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求第三方平台失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("读取第三方平台响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("第三方平台返回错误状态 %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("解析第三方平台响应失败: %w", err)
+	}
+	return nil
+}
+
+// getJSON 发送GET请求并解析JSON响应
+func getJSON(ctx context.Context, rawURL, bearer string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	return doJSON(req, out)
+}
+
+// postFormJSON 发送表单POST请求并解析JSON响应
+func postFormJSON(ctx context.Context, rawURL string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doJSON(req, out)
+}
+
+// tokenResponse 标准OAuth2令牌响应
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// toToken 检查令牌响应中的错误信息
+func (t *tokenResponse) toToken() (*Token, error) {
+	if t.AccessToken == "" {
+		if t.Error != "" {
+			return nil, fmt.Errorf("换取访问令牌失败: %s %s", t.Error, t.ErrorDescription)
+		}
+		return nil, errors.New("换取访问令牌失败: 响应中没有 access_token")
+	}
+	return &Token{AccessToken: t.AccessToken}, nil
+}
+
+// Init 根据配置注册已启用的平台，并设置state签名密钥
+func Init(cfg *config.Config) {
+	SetStateKey([]byte(cfg.OAuthStateSecret))
+
+	for platform, c := range cfg.OAuth {
+		endpoint := Endpoint{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			RedirectURL:  c.RedirectURL,
+			AuthURL:      c.AuthURL,
+			TokenURL:     c.TokenURL,
+			UserInfoURL:  c.UserInfoURL,
+		}
+		switch platform {
+		case "github":
+			Register(NewGitHub(endpoint))
+		case "gitee":
+			Register(NewGitee(endpoint))
+		case "qq":
+			Register(NewQQ(endpoint, c.OpenIDURL))
+		}
+	}
+}
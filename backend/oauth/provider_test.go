@@ -0,0 +1,270 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const (
+	testClientID     = "client-id"
+	testClientSecret = "client-secret"
+	testRedirectURL  = "https://blog.example.com/oauth/callback"
+	testCode         = "good-code"
+	testAccessToken  = "access-token"
+)
+
+// fakeServer 本地假OAuth服务，按路径返回令牌和用户资料
+func fakeServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	for path, h := range handlers {
+		mux.HandleFunc(path, h)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// writeJSON 输出JSON响应
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// tokenHandler 校验表单或查询参数中的客户端凭据和授权码，通过时返回访问令牌
+func tokenHandler(t *testing.T, method string, extra url.Values) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			t.Errorf("token request method = %s, want %s", r.Method, method)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+		want := url.Values{
+			"client_id":     {testClientID},
+			"client_secret": {testClientSecret},
+			"redirect_uri":  {testRedirectURL},
+		}
+		for key, values := range extra {
+			want[key] = values
+		}
+		for key := range want {
+			if got := r.Form.Get(key); got != want.Get(key) {
+				t.Errorf("token request %s = %q, want %q", key, got, want.Get(key))
+			}
+		}
+		if r.Form.Get("code") != testCode {
+			writeJSON(w, map[string]string{"error": "bad_verification_code", "error_description": "code 无效"})
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": testAccessToken})
+	}
+}
+
+// bearerUserHandler 校验 Bearer 令牌后返回用户资料
+func bearerUserHandler(user map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testAccessToken {
+			http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, user)
+	}
+}
+
+func testEndpoint(srv *httptest.Server) Endpoint {
+	return Endpoint{
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		AuthURL:      srv.URL + "/authorize",
+		TokenURL:     srv.URL + "/token",
+		UserInfoURL:  srv.URL + "/user",
+	}
+}
+
+// checkAuthorizeURL 校验授权地址指向假服务并带上了必需参数
+func checkAuthorizeURL(t *testing.T, p Provider, srv *httptest.Server, want url.Values) {
+	t.Helper()
+	u, err := url.Parse(p.AuthorizeURL("the-state"))
+	if err != nil {
+		t.Fatalf("AuthorizeURL: %v", err)
+	}
+	if got := u.Scheme + "://" + u.Host + u.Path; got != srv.URL+"/authorize" {
+		t.Errorf("AuthorizeURL base = %q, want %q", got, srv.URL+"/authorize")
+	}
+	want.Set("client_id", testClientID)
+	want.Set("redirect_uri", testRedirectURL)
+	want.Set("state", "the-state")
+	for key := range want {
+		if got := u.Query().Get(key); got != want.Get(key) {
+			t.Errorf("AuthorizeURL %s = %q, want %q", key, got, want.Get(key))
+		}
+	}
+}
+
+func TestGitHub(t *testing.T) {
+	srv := fakeServer(t, map[string]http.HandlerFunc{
+		"/token": tokenHandler(t, http.MethodPost, nil),
+		"/user": bearerUserHandler(map[string]interface{}{
+			"id": 42, "login": "octocat", "name": "", "avatar_url": "https://avatars.example.com/42", "email": "octo@example.com",
+		}),
+	})
+	p := NewGitHub(testEndpoint(srv))
+	checkAuthorizeURL(t, p, srv, url.Values{"scope": {"read:user user:email"}})
+
+	ctx := context.Background()
+	token, err := p.Exchange(ctx, testCode)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	profile, err := p.FetchProfile(ctx, token)
+	if err != nil {
+		t.Fatalf("FetchProfile: %v", err)
+	}
+	want := Profile{OpenID: "42", Nickname: "octocat", Avatar: "https://avatars.example.com/42", Email: "octo@example.com"}
+	if *profile != want {
+		t.Errorf("profile = %+v, want %+v", *profile, want)
+	}
+
+	if _, err := p.Exchange(ctx, "bad-code"); err == nil || !strings.Contains(err.Error(), "bad_verification_code") {
+		t.Errorf("Exchange with a bad code: err = %v, want the provider error", err)
+	}
+	if _, err := p.FetchProfile(ctx, &Token{AccessToken: "wrong"}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("FetchProfile with a bad token: err = %v, want a 401 error", err)
+	}
+}
+
+func TestGitee(t *testing.T) {
+	srv := fakeServer(t, map[string]http.HandlerFunc{
+		"/token": tokenHandler(t, http.MethodPost, url.Values{"grant_type": {"authorization_code"}}),
+		"/user": bearerUserHandler(map[string]interface{}{
+			"id": 7, "login": "gitee-user", "name": "码云用户", "avatar_url": "https://gitee.example.com/7.png",
+		}),
+	})
+	p := NewGitee(testEndpoint(srv))
+	checkAuthorizeURL(t, p, srv, url.Values{"response_type": {"code"}, "scope": {"user_info"}})
+
+	ctx := context.Background()
+	token, err := p.Exchange(ctx, testCode)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	profile, err := p.FetchProfile(ctx, token)
+	if err != nil {
+		t.Fatalf("FetchProfile: %v", err)
+	}
+	want := Profile{OpenID: "7", Nickname: "码云用户", Avatar: "https://gitee.example.com/7.png"}
+	if *profile != want {
+		t.Errorf("profile = %+v, want %+v", *profile, want)
+	}
+}
+
+func TestQQ(t *testing.T) {
+	srv := fakeServer(t, map[string]http.HandlerFunc{
+		"/token": tokenHandler(t, http.MethodGet, url.Values{"grant_type": {"authorization_code"}, "fmt": {"json"}}),
+		"/me": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("access_token") != testAccessToken {
+				writeJSON(w, map[string]string{"error": "100016", "error_description": "access token check failed"})
+				return
+			}
+			writeJSON(w, map[string]string{"client_id": testClientID, "openid": "QQ-OPENID"})
+		},
+		"/user": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("openid") != "QQ-OPENID" || q.Get("oauth_consumer_key") != testClientID {
+				writeJSON(w, map[string]interface{}{"ret": 1002, "msg": "openid 无效"})
+				return
+			}
+			writeJSON(w, map[string]interface{}{
+				"ret": 0, "nickname": "QQ用户",
+				"figureurl_qq_1": "http://thirdqq.example.com/40", "figureurl_qq_2": "http://thirdqq.example.com/100",
+			})
+		},
+	})
+	p := NewQQ(testEndpoint(srv), srv.URL+"/me")
+	checkAuthorizeURL(t, p, srv, url.Values{"response_type": {"code"}, "scope": {"get_user_info"}})
+
+	ctx := context.Background()
+	token, err := p.Exchange(ctx, testCode)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	profile, err := p.FetchProfile(ctx, token)
+	if err != nil {
+		t.Fatalf("FetchProfile: %v", err)
+	}
+	want := Profile{OpenID: "QQ-OPENID", Nickname: "QQ用户", Avatar: "https://thirdqq.example.com/100"}
+	if *profile != want {
+		t.Errorf("profile = %+v, want %+v", *profile, want)
+	}
+
+	if _, err := p.FetchProfile(ctx, &Token{AccessToken: "wrong"}); err == nil {
+		t.Error("FetchProfile with a bad token returned no error")
+	}
+}
+
+func TestDefaultEndpoints(t *testing.T) {
+	p := NewGitHub(Endpoint{ClientID: testClientID})
+	if !strings.HasPrefix(p.AuthorizeURL("s"), "https://github.com/login/oauth/authorize?") {
+		t.Errorf("GitHub AuthorizeURL = %q, want the default endpoint", p.AuthorizeURL("s"))
+	}
+	// 自定义地址已带查询参数时追加而不是覆盖
+	p = NewGitHub(Endpoint{AuthURL: "https://sso.example.com/auth?tenant=1"})
+	if u := p.AuthorizeURL("s"); !strings.HasPrefix(u, "https://sso.example.com/auth?tenant=1&") {
+		t.Errorf("AuthorizeURL = %q, want parameters appended", u)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	SetStateKey([]byte("test-state-key"))
+	srv := fakeServer(t, map[string]http.HandlerFunc{
+		"/token": tokenHandler(t, http.MethodPost, nil),
+		"/user":  bearerUserHandler(map[string]interface{}{"id": 42, "login": "octocat"}),
+	})
+	Register(NewGitHub(testEndpoint(srv)))
+	const clientNonce = "0123456789abcdef0123"
+	ctx := context.Background()
+
+	state, err := NewState("github", 0, clientNonce)
+	if err != nil {
+		t.Fatalf("NewState: %v", err)
+	}
+	profile, err := Authenticate(ctx, "github", testCode, state, clientNonce, 0)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if profile.OpenID != "42" || profile.Nickname != "octocat" {
+		t.Errorf("profile = %+v", *profile)
+	}
+
+	tests := []struct {
+		name     string
+		platform string
+		code     string
+		userID   int
+		want     error
+	}{
+		{"未注册平台", "nope", testCode, 0, ErrUnknownPlatform},
+		{"缺少授权码", "github", "", 0, ErrMissingCode},
+		{"绑定时用户不一致", "github", testCode, 9, ErrInvalidState},
+		{"授权码无效", "github", "bad-code", 0, ErrProviderFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := NewState("github", 0, clientNonce)
+			if err != nil {
+				t.Fatalf("NewState: %v", err)
+			}
+			if _, err := Authenticate(ctx, tt.platform, tt.code, state, clientNonce, tt.userID); !errors.Is(err, tt.want) {
+				t.Errorf("Authenticate err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
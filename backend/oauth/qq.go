@@ -0,0 +1,102 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// QQ QQ互联登录，获取用户资料前需要额外请求一次 openid
+type QQ struct {
+	endpoint  Endpoint
+	openIDURL string
+}
+
+// NewQQ 创建 QQ 平台，openIDURL 为空时使用默认地址
+func NewQQ(endpoint Endpoint, openIDURL string) *QQ {
+	endpoint.AuthURL = withDefault(endpoint.AuthURL, "https://graph.qq.com/oauth2.0/authorize")
+	endpoint.TokenURL = withDefault(endpoint.TokenURL, "https://graph.qq.com/oauth2.0/token")
+	endpoint.UserInfoURL = withDefault(endpoint.UserInfoURL, "https://graph.qq.com/user/get_user_info")
+	return &QQ{
+		endpoint:  endpoint,
+		openIDURL: withDefault(openIDURL, "https://graph.qq.com/oauth2.0/me"),
+	}
+}
+
+// Name 平台标识
+func (p *QQ) Name() string {
+	return "qq"
+}
+
+// AuthorizeURL 构建授权地址
+func (p *QQ) AuthorizeURL(state string) string {
+	return buildURL(p.endpoint.AuthURL, url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.endpoint.ClientID},
+		"redirect_uri":  {p.endpoint.RedirectURL},
+		"scope":         {"get_user_info"},
+		"state":         {state},
+	})
+}
+
+// Exchange 使用授权码换取访问令牌
+func (p *QQ) Exchange(ctx context.Context, code string) (*Token, error) {
+	var resp tokenResponse
+	err := getJSON(ctx, buildURL(p.endpoint.TokenURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {p.endpoint.ClientID},
+		"client_secret": {p.endpoint.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {p.endpoint.RedirectURL},
+		"fmt":           {"json"},
+	}), "", &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.toToken()
+}
+
+// FetchProfile 获取用户资料
+func (p *QQ) FetchProfile(ctx context.Context, token *Token) (*Profile, error) {
+	var me struct {
+		OpenID string `json:"openid"`
+	}
+	err := getJSON(ctx, buildURL(p.openIDURL, url.Values{
+		"access_token": {token.AccessToken},
+		"fmt":          {"json"},
+	}), "", &me)
+	if err != nil {
+		return nil, err
+	}
+	if me.OpenID == "" {
+		return nil, errors.New("获取QQ openid失败")
+	}
+
+	var user struct {
+		Ret          int    `json:"ret"`
+		Msg          string `json:"msg"`
+		Nickname     string `json:"nickname"`
+		FigureURLQQ2 string `json:"figureurl_qq_2"`
+		FigureURLQQ1 string `json:"figureurl_qq_1"`
+	}
+	err = getJSON(ctx, buildURL(p.endpoint.UserInfoURL, url.Values{
+		"access_token":       {token.AccessToken},
+		"oauth_consumer_key": {p.endpoint.ClientID},
+		"openid":             {me.OpenID},
+	}), "", &user)
+	if err != nil {
+		return nil, err
+	}
+	if user.Ret != 0 {
+		return nil, fmt.Errorf("获取QQ用户信息失败: %s", user.Msg)
+	}
+
+	avatar := withDefault(user.FigureURLQQ2, user.FigureURLQQ1)
+	return &Profile{
+		OpenID:   me.OpenID,
+		Nickname: user.Nickname,
+		Avatar:   strings.Replace(avatar, "http://", "https://", 1),
+	}, nil
+}
@@ -0,0 +1,131 @@
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StateTTL state有效期，需要覆盖用户在第三方平台完成授权的时间
+const StateTTL = 10 * time.Minute
+
+// ErrInvalidState state无效、过期或已被使用
+var ErrInvalidState = errors.New("授权状态无效或已过期，请重新发起授权")
+
+// ErrInvalidClientNonce 缺少客户端随机串或长度不符合要求
+var ErrInvalidClientNonce = errors.New("client_nonce 长度需要在16到128个字符之间")
+
+// 客户端随机串的长度范围
+const (
+	minClientNonceLength = 16
+	maxClientNonceLength = 128
+)
+
+// State state中携带的信息
+type State struct {
+	Platform string `json:"p"`
+	// UserID 发起授权时已登录的用户，0 表示未登录，用于绑定时确认是同一个用户
+	UserID int `json:"u,omitempty"`
+	// Binding 发起授权的客户端随机串的哈希，回调时必须由同一个客户端带回原值，
+	// 防止攻击者把自己的授权码和state交给受害者提交（登录CSRF）
+	Binding  string `json:"b"`
+	Nonce    string `json:"n"`
+	ExpireAt int64  `json:"e"`
+}
+
+var (
+	stateKey []byte
+
+	usedMu     sync.Mutex
+	usedNonces = make(map[string]time.Time)
+)
+
+// SetStateKey 设置state签名密钥
+func SetStateKey(key []byte) {
+	stateKey = key
+}
+
+// NewState 生成签名的state，格式为 base64(载荷).base64(HMAC-SHA256)；
+// clientNonce 是客户端生成并自行保存的随机串，state中只保存它的哈希
+func NewState(platform string, userID int, clientNonce string) (string, error) {
+	if len(clientNonce) < minClientNonceLength || len(clientNonce) > maxClientNonceLength {
+		return "", ErrInvalidClientNonce
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(State{
+		Platform: platform,
+		UserID:   userID,
+		Binding:  bindingHash(clientNonce),
+		Nonce:    hex.EncodeToString(nonce),
+		ExpireAt: time.Now().Add(StateTTL).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + sign(encoded), nil
+}
+
+// VerifyState 校验state的签名、平台、有效期以及是否由持有 clientNonce 的客户端发起，
+// 校验通过后state立即失效，不能重复使用
+func VerifyState(raw, platform, clientNonce string) (*State, error) {
+	encoded, signature, ok := strings.Cut(raw, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sign(encoded))) {
+		return nil, ErrInvalidState
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	var state State
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return nil, ErrInvalidState
+	}
+
+	now := time.Now()
+	if state.Platform != platform || now.Unix() > state.ExpireAt {
+		return nil, ErrInvalidState
+	}
+	if clientNonce == "" || !hmac.Equal([]byte(state.Binding), []byte(bindingHash(clientNonce))) {
+		return nil, ErrInvalidState
+	}
+
+	usedMu.Lock()
+	defer usedMu.Unlock()
+	for nonce, expireAt := range usedNonces {
+		if now.After(expireAt) {
+			delete(usedNonces, nonce)
+		}
+	}
+	if _, used := usedNonces[state.Nonce]; used {
+		return nil, ErrInvalidState
+	}
+	usedNonces[state.Nonce] = time.Unix(state.ExpireAt, 0)
+
+	return &state, nil
+}
+
+func sign(encoded string) string {
+	mac := hmac.New(sha256.New, stateKey)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// bindingHash 计算客户端随机串的哈希，state 可以被第三方平台和浏览器历史记录看到，不能直接放原值
+func bindingHash(clientNonce string) string {
+	sum := sha256.Sum256([]byte(clientNonce))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,78 @@
+package oauth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	SetStateKey([]byte("test-state-key"))
+	const clientNonce = "client-nonce-0123456789"
+
+	state, err := NewState("github", 5, clientNonce)
+	if err != nil {
+		t.Fatalf("NewState: %v", err)
+	}
+	if strings.Contains(state, clientNonce) {
+		t.Error("state contains the raw client nonce")
+	}
+
+	tests := []struct {
+		name        string
+		raw         string
+		platform    string
+		clientNonce string
+	}{
+		{"平台不一致", state, "gitee", clientNonce},
+		{"缺少客户端随机串", state, "github", ""},
+		{"客户端随机串不一致", state, "github", "another-nonce-0123456789"},
+		{"签名被篡改", state + "x", "github", clientNonce},
+		{"没有签名", strings.SplitN(state, ".", 2)[0], "github", clientNonce},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyState(tt.raw, tt.platform, tt.clientNonce); !errors.Is(err, ErrInvalidState) {
+				t.Errorf("VerifyState err = %v, want ErrInvalidState", err)
+			}
+		})
+	}
+
+	claims, err := VerifyState(state, "github", clientNonce)
+	if err != nil {
+		t.Fatalf("VerifyState: %v", err)
+	}
+	if claims.Platform != "github" || claims.UserID != 5 {
+		t.Errorf("claims = %+v", *claims)
+	}
+	if _, err := VerifyState(state, "github", clientNonce); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("reused state: err = %v, want ErrInvalidState", err)
+	}
+}
+
+func TestStateSignedWithOtherKey(t *testing.T) {
+	const clientNonce = "client-nonce-0123456789"
+	SetStateKey([]byte("old-key"))
+	state, err := NewState("github", 0, clientNonce)
+	if err != nil {
+		t.Fatalf("NewState: %v", err)
+	}
+	SetStateKey([]byte("test-state-key"))
+	if _, err := VerifyState(state, "github", clientNonce); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("VerifyState err = %v, want ErrInvalidState", err)
+	}
+}
+
+func TestNewStateClientNonceLength(t *testing.T) {
+	SetStateKey([]byte("test-state-key"))
+	for _, nonce := range []string{"", "short", strings.Repeat("a", 129)} {
+		if _, err := NewState("github", 0, nonce); !errors.Is(err, ErrInvalidClientNonce) {
+			t.Errorf("NewState(len %d) err = %v, want ErrInvalidClientNonce", len(nonce), err)
+		}
+	}
+	for _, nonce := range []string{strings.Repeat("a", 16), strings.Repeat("a", 128)} {
+		if _, err := NewState("github", 0, nonce); err != nil {
+			t.Errorf("NewState(len %d): %v", len(nonce), err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package prerender
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/markdown"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/seo"
+	"github.com/jayden/personal-blog-backend/siteconfig"
+)
+
+// 分类和标签页列出的文章数及每篇的摘要长度
+const (
+	listSize      = 20
+	summaryLength = 120
+)
+
+// page 预渲染页面的模板数据
+type page struct {
+	Title       string
+	Description string
+	Keywords    string
+	Canonical   string
+	FeedURL     string
+	OpenGraph   *seo.OpenGraph
+	Twitter     *seo.TwitterCard
+	JSONLD      interface{}
+	Redirect    bool // 是否让浏览器跳转到前台页面
+	NoIndex     bool
+	Home        string
+	SiteName    string
+	Heading     string
+	Article     *articleView
+	List        []listItem
+}
+
+// articleView 文章页正文
+type articleView struct {
+	Title     string
+	Published time.Time
+	Category  *link
+	Cover     string
+	Body      template.HTML
+	Tags      []string
+}
+
+// link 带名称的前台链接
+type link struct {
+	Name string
+	Link string
+}
+
+// listItem 分类和标签页中的一篇文章
+type listItem struct {
+	Title     string
+	Link      string
+	Summary   string
+	Published time.Time
+}
+
+// serve 渲染 kind（article、category、tag）对应的页面；redirect 为 true 时页面中带跳转到前台的脚本
+func serve(w http.ResponseWriter, r *http.Request, kind, id string, redirect bool) {
+	website, _, err := siteconfig.Website()
+	if err != nil {
+		log.Printf("读取网站配置失败: %v", err)
+		website = siteconfig.DefaultWebsiteConfig()
+	}
+	info := website.WebsiteInfo
+	site := seo.SiteURL(r)
+
+	var p *page
+	switch kind {
+	case "article":
+		p, err = articlePage(site, id, info)
+	case "category", "tag":
+		p, err = listPage(r, site, kind, id, info)
+	}
+	if err != nil {
+		log.Printf("预渲染页面 %s/%s 失败: %v", kind, id, err)
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if p == nil {
+		status = http.StatusNotFound
+		p = &page{Title: "页面不存在 - " + info.WebsiteName, Heading: "页面不存在", NoIndex: true}
+	}
+	p.Home = site
+	p.SiteName = info.WebsiteName
+	p.Redirect = redirect && p.Canonical != ""
+
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, p); err != nil {
+		log.Printf("预渲染页面 %s/%s 失败: %v", kind, id, err)
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// articlePage 生成文章页，文章不存在或未公开时返回 nil
+func articlePage(site, id string, info models.WebsiteInfo) (*page, error) {
+	article, err := models.GetPublicArticle(id)
+	if err != nil || article == nil {
+		return nil, err
+	}
+
+	body, err := markdown.Render(article.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := seo.NewArticleMeta(site, article, info)
+	view := &articleView{
+		Title:     article.Title,
+		Published: article.CreatedAt,
+		Cover:     meta.OpenGraph.Image,
+		Body:      template.HTML(body),
+		Tags:      article.TagNames,
+	}
+	if article.CategoryName != "" {
+		view.Category = &link{Name: article.CategoryName, Link: site + "/category/" + article.CategoryID}
+	}
+	return &page{
+		Title:       meta.Title,
+		Description: meta.Description,
+		Keywords:    meta.Keywords,
+		Canonical:   meta.CanonicalURL,
+		OpenGraph:   &meta.OpenGraph,
+		Twitter:     &meta.TwitterCard,
+		JSONLD:      meta.JSONLD,
+		Article:     view,
+	}, nil
+}
+
+// listPage 生成分类或标签页，列出其中最新的公开文章；分类或标签不存在时返回 nil
+func listPage(r *http.Request, site, kind, id string, info models.WebsiteInfo) (*page, error) {
+	var (
+		name, label       string
+		categoryID, tagID string
+	)
+	if kind == "category" {
+		category, err := models.FindCategory(id)
+		if err != nil || category == nil {
+			return nil, err
+		}
+		name, label, categoryID = category.Name, "分类", category.ID
+	} else {
+		tag, err := models.FindTag(id)
+		if err != nil || tag == nil {
+			return nil, err
+		}
+		name, label, tagID = tag.Name, "标签", tag.ID
+	}
+
+	articles, err := models.GetPublicArticles(categoryID, tagID, listSize)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := site + "/" + kind + "/" + id
+	title := label + "：" + name
+	description := info.WebsiteName + "中" + label + "为「" + name + "」的文章"
+	items := make([]listItem, 0, len(articles))
+	for _, article := range articles {
+		items = append(items, listItem{
+			Title:     article.Title,
+			Link:      site + "/article/" + article.ID,
+			Summary:   markdown.Excerpt(article.Content, summaryLength),
+			Published: article.CreatedAt,
+		})
+	}
+
+	return &page{
+		Title:       title + " - " + info.WebsiteName,
+		Description: description,
+		Keywords:    name,
+		Canonical:   canonical,
+		FeedURL:     netutil.RequestOrigin(r) + "/" + kind + "/" + id + "/feed.xml",
+		OpenGraph: &seo.OpenGraph{
+			Type:        "website",
+			Title:       title,
+			Description: description,
+			URL:         canonical,
+			SiteName:    info.WebsiteName,
+			Locale:      "zh_CN",
+		},
+		Twitter: &seo.TwitterCard{Card: "summary", Title: title, Description: description},
+		JSONLD: map[string]string{
+			"@context":    "https://schema.org",
+			"@type":       "CollectionPage",
+			"name":        title,
+			"description": description,
+			"url":         canonical,
+		},
+		Heading: title,
+		List:    items,
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Package prerender 为搜索引擎爬虫和聊天软件的链接预览输出服务端渲染的文章、分类和标签页面。
+// 前台是单页应用，爬虫拿到的只有空壳；这里按 User-Agent 识别爬虫，或通过 /s/ 开头的分享地址，
+// 直接返回带标题、描述、Open Graph 标签和正文的简单 HTML
+package prerender
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// defaultBotAgents User-Agent 中包含这些片段（忽略大小写）时视为爬虫或链接预览抓取程序
+var defaultBotAgents = []string{
+	"googlebot", "bingbot", "baiduspider", "yandex", "duckduckbot", "sogou", "360spider", "bytespider",
+	"petalbot", "applebot", "yahoo! slurp", "facebookexternalhit", "facebot", "twitterbot", "linkedinbot",
+	"slackbot", "discordbot", "telegrambot", "whatsapp", "skypeuripreview", "embedly", "pinterest",
+}
+
+var botAgents = defaultBotAgents
+
+// Init 根据配置追加需要识别的爬虫 User-Agent 片段
+func Init(cfg *config.Config) {
+	botAgents = append([]string{}, defaultBotAgents...)
+	for _, agent := range strings.Split(cfg.PrerenderBotAgents, ",") {
+		if agent = strings.ToLower(strings.TrimSpace(agent)); agent != "" {
+			botAgents = append(botAgents, agent)
+		}
+	}
+}
+
+// IsBot 判断 User-Agent 是否属于爬虫或链接预览抓取程序
+func IsBot(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+	if userAgent == "" {
+		return false
+	}
+	for _, agent := range botAgents {
+		if strings.Contains(userAgent, agent) {
+			return true
+		}
+	}
+	return false
+}
+
+// frontendPath 需要预渲染的前台地址：/article/{id}、/category/{id}、/tag/{id}
+var frontendPath = regexp.MustCompile(`^/(article|category|tag)/([^/]+)/?$`)
+
+// Middleware 爬虫访问前台文章、分类和标签地址时返回预渲染页面，其余请求交给 next。
+// 适用于前台和后端共用域名、由反向代理把这些地址转发到后端的部署方式
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		match := frontendPath.FindStringSubmatch(r.URL.Path)
+		if match == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "User-Agent")
+		if !IsBot(r.UserAgent()) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		serve(w, r, match[1], match[2], false)
+	})
+}
+
+// ArticleHandler 文章分享地址 /s/{id}，不区分 User-Agent 始终返回预渲染页面，浏览器打开后跳转到前台文章页
+func ArticleHandler(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, "article", mux.Vars(r)["id"], true)
+}
+
+// CategoryHandler 分类分享地址 /s/category/{category_id}
+func CategoryHandler(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, "category", mux.Vars(r)["category_id"], true)
+}
+
+// TagHandler 标签分享地址 /s/tag/{tag_id}
+func TagHandler(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, "tag", mux.Vars(r)["tag_id"], true)
+}
@@ -0,0 +1,102 @@
+package prerender
+
+import "html/template"
+
+// pageTemplate 预渲染页面的模板，只输出爬虫和链接预览需要的内容，不包含样式和前台脚本
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+{{- if .NoIndex}}
+<meta name="robots" content="noindex">
+{{- end}}
+{{- with .Description}}
+<meta name="description" content="{{.}}">
+{{- end}}
+{{- with .Keywords}}
+<meta name="keywords" content="{{.}}">
+{{- end}}
+{{- with .Canonical}}
+<link rel="canonical" href="{{.}}">
+{{- end}}
+{{- with .FeedURL}}
+<link rel="alternate" type="application/rss+xml" title="RSS" href="{{.}}">
+{{- end}}
+{{- with .OpenGraph}}
+<meta property="og:type" content="{{.Type}}">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.URL}}">
+<meta property="og:site_name" content="{{.SiteName}}">
+<meta property="og:locale" content="{{.Locale}}">
+{{- with .Image}}
+<meta property="og:image" content="{{.}}">
+{{- end}}
+{{- with .PublishedTime}}
+<meta property="article:published_time" content="{{.}}">
+{{- end}}
+{{- with .ModifiedTime}}
+<meta property="article:modified_time" content="{{.}}">
+{{- end}}
+{{- with .Section}}
+<meta property="article:section" content="{{.}}">
+{{- end}}
+{{- range .Tags}}
+<meta property="article:tag" content="{{.}}">
+{{- end}}
+{{- end}}
+{{- with .Twitter}}
+<meta name="twitter:card" content="{{.Card}}">
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+{{- with .Site}}
+<meta name="twitter:site" content="{{.}}">
+{{- end}}
+{{- with .Image}}
+<meta name="twitter:image" content="{{.}}">
+{{- end}}
+{{- end}}
+{{- with .JSONLD}}
+<script type="application/ld+json">{{.}}</script>
+{{- end}}
+{{- if .Redirect}}
+<script>location.replace({{.Canonical}})</script>
+{{- end}}
+</head>
+<body>
+<header><a href="{{.Home}}">{{.SiteName}}</a></header>
+<main>
+{{- with .Article}}
+<article>
+<h1>{{.Title}}</h1>
+<p><time datetime="{{.Published.Format "2006-01-02T15:04:05Z07:00"}}">{{.Published.Format "2006-01-02"}}</time>
+{{- with .Category}} · <a href="{{.Link}}">{{.Name}}</a>{{end}}</p>
+{{- with .Cover}}
+<p><img src="{{.}}" alt=""></p>
+{{- end}}
+<div>{{.Body}}</div>
+{{- with .Tags}}
+<p>{{range .}}<span>#{{.}}</span> {{end}}</p>
+{{- end}}
+</article>
+{{- else}}
+<h1>{{.Heading}}</h1>
+{{- with .Description}}
+<p>{{.}}</p>
+{{- end}}
+<ul>
+{{- range .List}}
+<li><a href="{{.Link}}">{{.Title}}</a> <time datetime="{{.Published.Format "2006-01-02T15:04:05Z07:00"}}">{{.Published.Format "2006-01-02"}}</time>
+{{- with .Summary}}
+<p>{{.}}</p>
+{{- end}}
+</li>
+{{- end}}
+</ul>
+{{- end}}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,164 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jayden/personal-blog-backend/auth"
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/netutil"
+)
+
+// errorResponse 与 v1 统一响应格式保持一致的错误响应
+type errorResponse struct {
+	Code    int         `json:"code"`
+	Data    interface{} `json:"data"`
+	Msg     string      `json:"msg"`
+	TraceId string      `json:"trace_id"`
+}
+
+// Limiter 按策略对请求限流
+type Limiter struct {
+	store    Store
+	policies map[string]Policy
+}
+
+var defaultLimiter *Limiter
+
+// Init 根据配置初始化全局限流器，使用内存存储
+func Init(cfg *config.Config) error {
+	policies, err := ParsePolicies(DefaultPolicies)
+	if err != nil {
+		return err
+	}
+	custom, err := ParsePolicies(cfg.RateLimitPolicies)
+	if err != nil {
+		return err
+	}
+	// 配置中的策略覆盖同名默认策略
+	for name, policy := range custom {
+		policies[name] = policy
+	}
+	defaultLimiter = NewLimiter(NewMemoryStore(), policies)
+	return nil
+}
+
+// NewLimiter 创建限流器
+func NewLimiter(store Store, policies map[string]Policy) *Limiter {
+	return &Limiter{store: store, policies: policies}
+}
+
+// Middleware 使用全局限流器按指定策略限流的中间件，可直接用于 mux 的 Use
+func Middleware(policyName string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if defaultLimiter == nil {
+			return next
+		}
+		return defaultLimiter.Middleware(policyName)(next)
+	}
+}
+
+// Wrap 使用全局限流器按指定策略包装单个处理函数，每个路由单独计数
+func Wrap(policyName string, h http.HandlerFunc) http.Handler {
+	if defaultLimiter == nil {
+		return h
+	}
+	return defaultLimiter.RouteMiddleware(policyName)(h)
+}
+
+// Middleware 按指定策略限流的中间件，策略不存在时不限流。
+// 经过该中间件的所有路由共用一个令牌桶，适合挂在路由器上作为整体的兜底限制
+func (l *Limiter) Middleware(policyName string) mux.MiddlewareFunc {
+	return l.middleware(policyName, false)
+}
+
+// RouteMiddleware 与 Middleware 相同，但每个路由单独计数，
+// 同一策略下的登录、获取验证码等接口不会互相占用次数
+func (l *Limiter) RouteMiddleware(policyName string) mux.MiddlewareFunc {
+	return l.middleware(policyName, true)
+}
+
+func (l *Limiter) middleware(policyName string, perRoute bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		policy, ok := l.policies[policyName]
+		if !ok {
+			log.Printf("限流策略 %s 未配置，不限流", policyName)
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := policy.Name + "|" + clientKey(r)
+			if perRoute {
+				key = policy.Name + "|" + routeKey(r) + "|" + clientKey(r)
+			}
+			result, err := l.store.Take(r.Context(), key, policy)
+			if err != nil {
+				// 存储不可用时放行，避免限流组件故障导致整站不可用
+				log.Printf("限流存储出错: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			writeHeaders(w, policy, result)
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(errorResponse{
+					Code: http.StatusTooManyRequests,
+					Data: nil,
+					Msg:  fmt.Sprintf("请求过于频繁，请%d秒后再试", ceilSeconds(result.RetryAfter)),
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientKey 登录用户按用户ID计数，游客按客户端IP计数
+func clientKey(r *http.Request) string {
+	if claims := auth.ClaimsFromRequest(r); claims != nil {
+		return "user:" + strconv.Itoa(claims.UserID)
+	}
+	return "ip:" + netutil.ClientIP(r)
+}
+
+// routeKey 返回请求匹配的路由模板，如 /blog-api/v1/talk/{id}；没有匹配的路由时使用请求路径
+func routeKey(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return r.Method + " " + tpl
+		}
+	}
+	return r.Method + " " + r.URL.Path
+}
+
+// writeHeaders 写入 IETF RateLimit 草案中的响应头。
+// 同一请求经过多个策略时，保留剩余次数最少的一组
+func writeHeaders(w http.ResponseWriter, policy Policy, result Result) {
+	h := w.Header()
+	if current := h.Get("RateLimit-Remaining"); current != "" {
+		if remaining, err := strconv.Atoi(current); err == nil && remaining <= result.Remaining {
+			return
+		}
+	}
+	h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Period.Seconds())))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func testLimiter() *Limiter {
+	return NewLimiter(NewMemoryStore(), map[string]Policy{
+		"auth": {Name: "auth", Limit: 2, Period: time.Hour, Burst: 2},
+	})
+}
+
+// do 以同一客户端请求 path，返回状态码
+func do(h http.Handler, method, path string) int {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestRouteMiddlewareSeparatesRoutes(t *testing.T) {
+	l := testLimiter()
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	r := mux.NewRouter()
+	r.Handle("/login", l.RouteMiddleware("auth")(http.HandlerFunc(ok))).Methods("POST")
+	r.Handle("/captcha", l.RouteMiddleware("auth")(http.HandlerFunc(ok))).Methods("POST")
+	r.Handle("/talk/{id}", l.RouteMiddleware("auth")(http.HandlerFunc(ok))).Methods("PUT")
+
+	// 获取验证码用完次数后不影响登录
+	for i := 0; i < 2; i++ {
+		if code := do(r, "POST", "/captcha"); code != http.StatusOK {
+			t.Fatalf("captcha request %d: status %d", i+1, code)
+		}
+	}
+	if code := do(r, "POST", "/captcha"); code != http.StatusTooManyRequests {
+		t.Errorf("third captcha request: status %d, want 429", code)
+	}
+	if code := do(r, "POST", "/login"); code != http.StatusOK {
+		t.Errorf("login after captcha limit: status %d, want 200", code)
+	}
+
+	// 同一路由模板下不同的路径参数共用次数
+	do(r, "PUT", "/talk/1")
+	do(r, "PUT", "/talk/2")
+	if code := do(r, "PUT", "/talk/3"); code != http.StatusTooManyRequests {
+		t.Errorf("third talk request: status %d, want 429", code)
+	}
+}
+
+func TestMiddlewareSharesBudget(t *testing.T) {
+	l := testLimiter()
+	r := mux.NewRouter()
+	r.Use(l.Middleware("auth"))
+	r.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {})
+	r.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {})
+
+	do(r, "GET", "/a")
+	do(r, "GET", "/b")
+	if code := do(r, "GET", "/a"); code != http.StatusTooManyRequests {
+		t.Errorf("third request across routes: status %d, want 429", code)
+	}
+}
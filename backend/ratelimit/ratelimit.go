@@ -0,0 +1,84 @@
+// Package ratelimit 基于令牌桶的限流：每个路由可以配置独立的策略，同一策略挂在多个路由上时各路由分别计数，
+// 挂在路由器上的兜底策略整体计数；登录用户按用户ID计数，游客按客户端IP计数
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 内置策略名称
+const (
+	PolicyDefault = "default" // 所有接口共用的兜底策略
+	PolicyRead    = "read"    // 读接口
+	PolicyAuth    = "auth"    // 登录、注册、发送验证码等
+	PolicyComment = "comment" // 发表、修改评论和留言
+	PolicyLike    = "like"    // 点赞
+)
+
+// DefaultPolicies 未配置时使用的策略，格式与 RATE_LIMIT_POLICIES 相同
+const DefaultPolicies = "default=1200/1m,read=600/1m,auth=10/1m,comment=5/1m,like=30/1m"
+
+// Policy 限流策略：每 Period 补充 Limit 个令牌，桶容量为 Burst
+type Policy struct {
+	Name   string
+	Limit  int
+	Period time.Duration
+	Burst  int
+}
+
+// Result 一次取令牌的结果
+type Result struct {
+	Allowed    bool
+	Limit      int           // 桶容量
+	Remaining  int           // 剩余令牌数
+	Reset      time.Duration // 令牌桶补满所需时间
+	RetryAfter time.Duration // 被拒绝时下一个令牌到达的时间
+}
+
+// Store 令牌桶存储，单机使用内存实现，多实例部署时可以换成共享存储（如 Redis）
+type Store interface {
+	// Take 从 key 对应的令牌桶中取一个令牌
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+// ParsePolicies 解析策略配置，格式为 name=limit/period[/burst]，多个用逗号分隔，
+// 例如 "comment=5/1m,like=30/1m/10"
+func ParsePolicies(s string) (map[string]Policy, error) {
+	policies := make(map[string]Policy)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, spec, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("无效的限流策略: %s", item)
+		}
+		parts := strings.Split(spec, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("无效的限流策略: %s", item)
+		}
+		limit, err := strconv.Atoi(parts[0])
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("无效的限流次数: %s", item)
+		}
+		period, err := time.ParseDuration(parts[1])
+		if err != nil || period <= 0 {
+			return nil, fmt.Errorf("无效的限流周期: %s", item)
+		}
+		burst := limit
+		if len(parts) == 3 {
+			burst, err = strconv.Atoi(parts[2])
+			if err != nil || burst <= 0 {
+				return nil, fmt.Errorf("无效的突发容量: %s", item)
+			}
+		}
+		name = strings.TrimSpace(name)
+		policies[name] = Policy{Name: name, Limit: limit, Period: period, Burst: burst}
+	}
+	return policies, nil
+}
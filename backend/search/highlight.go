@@ -0,0 +1,96 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// 高亮标签
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+)
+
+// matchMask 标记文本中命中检索词的字符，英文不区分大小写
+func matchMask(runes []rune, tokens []string) []bool {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	mask := make([]bool, len(runes))
+	for _, token := range tokens {
+		t := []rune(token)
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == token {
+				for j := i; j < i+len(t); j++ {
+					mask[j] = true
+				}
+			}
+		}
+	}
+	return mask
+}
+
+// render 转义文本并用高亮标签包住命中的部分，相邻的命中合并为一段
+func render(runes []rune, mask []bool) string {
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && mask[j] == mask[i] {
+			j++
+		}
+		text := html.EscapeString(string(runes[i:j]))
+		if mask[i] {
+			b.WriteString(highlightOpen + text + highlightClose)
+		} else {
+			b.WriteString(text)
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// Highlight 转义文本并高亮其中的检索词
+func Highlight(text string, tokens []string) string {
+	runes := []rune(text)
+	return render(runes, matchMask(runes, tokens))
+}
+
+// Snippet 截取第一个命中位置附近不超过 length 个字符的摘要并高亮，没有命中时取开头；
+// 截断的一端用省略号表示
+func Snippet(text string, tokens []string, length int) string {
+	runes := []rune(text)
+	mask := matchMask(runes, tokens)
+	if len(runes) <= length {
+		return render(runes, mask)
+	}
+
+	start := 0
+	for i, hit := range mask {
+		if hit {
+			// 命中位置前保留一小段上下文
+			start = i - length/5
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start+length > len(runes) {
+		start = len(runes) - length
+	}
+	end := start + length
+
+	snippet := render(runes[start:end], mask[start:end])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(runes) {
+		snippet += "..."
+	}
+	return snippet
+}
@@ -0,0 +1,217 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// 各字段的权重，命中标题比命中正文更相关
+const (
+	titleWeight    = 3
+	taxonomyWeight = 2
+	contentWeight  = 1
+)
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// minPrefixLength 英文检索词至少多长时才做前缀匹配，方便边输入边检索
+const minPrefixLength = 2
+
+// memoryDoc 内存索引中的一篇文档
+type memoryDoc struct {
+	doc    *models.ArticleSearchDoc
+	length float64  // 加权后的词数
+	terms  []string // 文档包含的词，删除时用于清理倒排表
+}
+
+// MemoryIndex 进程内的倒排索引，按 BM25 计算相关度，适合文章数量不多的博客
+type MemoryIndex struct {
+	mu       sync.RWMutex
+	docs     map[string]*memoryDoc
+	postings map[string]map[string]float64 // 词 -> 文档ID -> 加权词频
+	totalLen float64
+}
+
+// NewMemoryIndex 创建内存索引
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		docs:     make(map[string]*memoryDoc),
+		postings: make(map[string]map[string]float64),
+	}
+}
+
+// Index 写入或更新文档
+func (m *MemoryIndex) Index(doc *models.ArticleSearchDoc) error {
+	freqs := make(map[string]float64)
+	var length float64
+	addField := func(text string, weight float64) {
+		for _, token := range Tokenize(text) {
+			freqs[token] += weight
+			length += weight
+		}
+	}
+	addField(doc.Title, titleWeight)
+	addField(doc.CategoryName, taxonomyWeight)
+	for _, tag := range doc.TagNames {
+		addField(tag, taxonomyWeight)
+	}
+	addField(doc.Content, contentWeight)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(doc.ID)
+
+	entry := &memoryDoc{doc: doc, length: length, terms: make([]string, 0, len(freqs))}
+	for term, freq := range freqs {
+		posting := m.postings[term]
+		if posting == nil {
+			posting = make(map[string]float64)
+			m.postings[term] = posting
+		}
+		posting[doc.ID] = freq
+		entry.terms = append(entry.terms, term)
+	}
+	m.docs[doc.ID] = entry
+	m.totalLen += length
+	return nil
+}
+
+// Delete 删除文档
+func (m *MemoryIndex) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(id)
+	return nil
+}
+
+// Reset 清空索引后写入全部文档；新索引建好后再替换，重建期间检索使用旧索引
+func (m *MemoryIndex) Reset(docs []*models.ArticleSearchDoc) error {
+	fresh := NewMemoryIndex()
+	for _, doc := range docs {
+		if err := fresh.Index(doc); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	m.docs, m.postings, m.totalLen = fresh.docs, fresh.postings, fresh.totalLen
+	m.mu.Unlock()
+	return nil
+}
+
+// remove 从索引中删除文档，调用方需持有写锁
+func (m *MemoryIndex) remove(id string) {
+	entry, ok := m.docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range entry.terms {
+		posting := m.postings[term]
+		delete(posting, id)
+		if len(posting) == 0 {
+			delete(m.postings, term)
+		}
+	}
+	m.totalLen -= entry.length
+	delete(m.docs, id)
+}
+
+// Search 检索包含全部检索词的文档，按相关度倒序分页返回
+func (m *MemoryIndex) Search(query string, limit, offset int) ([]*models.ArticleSearchMatch, int64, error) {
+	tokens := QueryTokens(query)
+	if len(tokens) == 0 {
+		return []*models.ArticleSearchMatch{}, 0, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	n := float64(len(m.docs))
+	avgLen := 1.0
+	if n > 0 && m.totalLen > 0 {
+		avgLen = m.totalLen / n
+	}
+
+	var scores map[string]float64
+	for _, token := range tokens {
+		// 每个检索词取各展开词中得分最高的一个，文档必须命中每个检索词
+		tokenScores := make(map[string]float64)
+		for _, term := range m.expand(token) {
+			posting := m.postings[term]
+			idf := math.Log(1 + (n-float64(len(posting))+0.5)/(float64(len(posting))+0.5))
+			for id, freq := range posting {
+				length := m.docs[id].length
+				score := idf * freq * (bm25K1 + 1) / (freq + bm25K1*(1-bm25B+bm25B*length/avgLen))
+				if score > tokenScores[id] {
+					tokenScores[id] = score
+				}
+			}
+		}
+
+		if scores == nil {
+			scores = tokenScores
+			continue
+		}
+		for id := range scores {
+			if score, ok := tokenScores[id]; ok {
+				scores[id] += score
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	matches := make([]*models.ArticleSearchMatch, 0, len(scores))
+	for id, score := range scores {
+		matches = append(matches, &models.ArticleSearchMatch{Doc: m.docs[id].doc, Score: score})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return newerID(matches[i].Doc.ID, matches[j].Doc.ID)
+	})
+
+	total := int64(len(matches))
+	if offset >= len(matches) {
+		return []*models.ArticleSearchMatch{}, total, nil
+	}
+	end := offset + limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+	return matches[offset:end], total, nil
+}
+
+// expand 返回检索词对应的索引词：中文精确匹配，英文额外匹配以它为前缀的词
+func (m *MemoryIndex) expand(token string) []string {
+	terms := []string{}
+	if _, ok := m.postings[token]; ok {
+		terms = append(terms, token)
+	}
+	if len(token) < minPrefixLength || isCJK([]rune(token)[0]) {
+		return terms
+	}
+	for term := range m.postings {
+		if term != token && strings.HasPrefix(term, token) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// newerID 相关度相同时较新的文章排在前面，ID 为自增数字，位数多的更新
+func newerID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a > b
+}
@@ -0,0 +1,34 @@
+package search
+
+import "github.com/jayden/personal-blog-backend/models"
+
+// MySQLIndex 基于 MySQL ngram 全文索引的检索引擎，索引保存在 article_search 表中，多个进程共享
+type MySQLIndex struct{}
+
+// Index 写入或更新文档
+func (MySQLIndex) Index(doc *models.ArticleSearchDoc) error {
+	return models.SaveArticleSearchIndex(doc)
+}
+
+// Delete 删除文档
+func (MySQLIndex) Delete(id string) error {
+	return models.DeleteArticleSearchIndex(id)
+}
+
+// Reset 清空索引后写入全部文档
+func (MySQLIndex) Reset(docs []*models.ArticleSearchDoc) error {
+	if err := models.ClearArticleSearchIndex(); err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		if err := models.SaveArticleSearchIndex(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search 按相关度倒序分页检索
+func (MySQLIndex) Search(query string, limit, offset int) ([]*models.ArticleSearchMatch, int64, error) {
+	return models.SearchArticleIndex(query, limit, offset)
+}
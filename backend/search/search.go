@@ -0,0 +1,179 @@
+// Package search 文章全文检索：索引文章标题、去掉 Markdown 标记的正文、分类和标签，
+// 按相关度排序并返回高亮摘要。检索引擎可以是进程内的倒排索引，也可以是 MySQL ngram 全文索引
+package search
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/markdown"
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// 检索引擎驱动
+const (
+	DriverMemory = "memory"
+	DriverMySQL  = "mysql"
+)
+
+// Engine 检索引擎
+type Engine interface {
+	// Index 写入或更新文档
+	Index(doc *models.ArticleSearchDoc) error
+	// Delete 删除文档
+	Delete(id string) error
+	// Reset 清空索引后写入全部文档
+	Reset(docs []*models.ArticleSearchDoc) error
+	// Search 按相关度倒序分页检索，返回当前页的命中和总数
+	Search(query string, limit, offset int) ([]*models.ArticleSearchMatch, int64, error)
+}
+
+// Hit 一条检索结果，标题和摘要已转义并高亮
+type Hit struct {
+	// 文章ID
+	ID string `json:"id" example:"1"`
+	// 高亮后的标题
+	ArticleTitle string `json:"article_title" example:"<mark>Go</mark> 并发编程"`
+	// 高亮后的正文摘要
+	ArticleContent string `json:"article_content" example:"...使用 <mark>Go</mark> 的 channel..."`
+	// 分类名
+	CategoryName string `json:"category_name" example:"技术"`
+	// 标签名列表
+	TagNameList []string `json:"tag_name_list"`
+	// 相关度
+	Score float64 `json:"score" example:"3.2"`
+}
+
+// Service 文章检索服务，负责从数据库加载文档并同步到检索引擎
+type Service struct {
+	engine        Engine
+	snippetLength int
+}
+
+var defaultService = New(NewMemoryIndex(), 120)
+
+// Init 根据配置创建检索服务并建立索引；建立索引失败时只记录日志，之后可以由管理员手动重建
+func Init(cfg *config.Config) error {
+	var engine Engine
+	switch cfg.SearchDriver {
+	case DriverMemory, "":
+		engine = NewMemoryIndex()
+	case DriverMySQL:
+		engine = MySQLIndex{}
+	default:
+		return fmt.Errorf("未知的检索引擎: %s", cfg.SearchDriver)
+	}
+	defaultService = New(engine, cfg.SearchSnippetLength)
+
+	count, err := defaultService.Rebuild()
+	if err != nil {
+		log.Printf("建立文章索引失败: %v", err)
+		return nil
+	}
+	log.Printf("已建立文章索引，共 %d 篇", count)
+	return nil
+}
+
+// Default 返回全局检索服务
+func Default() *Service {
+	return defaultService
+}
+
+// New 创建检索服务，snippetLength 为摘要的最大字符数
+func New(engine Engine, snippetLength int) *Service {
+	if snippetLength <= 0 {
+		snippetLength = 120
+	}
+	return &Service{engine: engine, snippetLength: snippetLength}
+}
+
+// prepare 把文章正文转换为索引用的纯文本
+func prepare(doc *models.ArticleSearchDoc) *models.ArticleSearchDoc {
+	doc.Content = markdown.Strip(doc.Content)
+	return doc
+}
+
+// Rebuild 从数据库重新加载全部公开文章并重建索引，返回索引的文章数
+func (s *Service) Rebuild() (int, error) {
+	docs, err := models.GetArticleSearchDocs("")
+	if err != nil {
+		return 0, err
+	}
+	for _, doc := range docs {
+		prepare(doc)
+	}
+	if err := s.engine.Reset(docs); err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+// IndexArticle 同步一篇文章的索引：公开文章写入索引，其他状态或已删除的文章从索引中删除
+func (s *Service) IndexArticle(id string) error {
+	if id == "" {
+		return nil
+	}
+	docs, err := models.GetArticleSearchDocs(id)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return s.engine.Delete(id)
+	}
+	return s.engine.Index(prepare(docs[0]))
+}
+
+// RemoveArticle 从索引中删除文章
+func (s *Service) RemoveArticle(id string) error {
+	if id == "" {
+		return nil
+	}
+	return s.engine.Delete(id)
+}
+
+// Search 检索文章，返回当前页高亮后的结果和总数
+func (s *Service) Search(query string, limit, offset int) ([]*Hit, int64, error) {
+	query = strings.TrimSpace(query)
+	tokens := QueryTokens(query)
+	if len(tokens) == 0 {
+		return []*Hit{}, 0, nil
+	}
+
+	matches, total, err := s.engine.Search(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]*Hit, 0, len(matches))
+	for _, match := range matches {
+		tags := match.Doc.TagNames
+		if tags == nil {
+			tags = []string{}
+		}
+		hits = append(hits, &Hit{
+			ID:             match.Doc.ID,
+			ArticleTitle:   Highlight(match.Doc.Title, tokens),
+			ArticleContent: Snippet(match.Doc.Content, tokens, s.snippetLength),
+			CategoryName:   match.Doc.CategoryName,
+			TagNameList:    tags,
+			Score:          match.Score,
+		})
+	}
+	return hits, total, nil
+}
+
+// IndexArticle 使用全局检索服务同步文章索引，失败时只记录日志
+func IndexArticle(id string) {
+	if err := defaultService.IndexArticle(id); err != nil {
+		log.Printf("同步文章 %s 索引失败: %v", id, err)
+	}
+}
+
+// RemoveArticle 使用全局检索服务删除文章索引，失败时只记录日志
+func RemoveArticle(id string) {
+	if err := defaultService.RemoveArticle(id); err != nil {
+		log.Printf("删除文章 %s 索引失败: %v", id, err)
+	}
+}
@@ -0,0 +1,98 @@
+package search
+
+import "unicode"
+
+// isCJK 判断是否为中日文字符，这些字符之间没有空格分隔，需要按 n-gram 切分
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r)
+}
+
+// isWordRune 判断是否为组成英文单词的字符
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// segment 文本中连续的一段中文或英文
+type segment struct {
+	text string
+	cjk  bool
+}
+
+// segments 把文本切分为连续的中文段和英文单词，其余字符作为分隔符丢弃，英文转为小写
+func segments(text string) []segment {
+	var (
+		result []segment
+		buf    []rune
+		cjk    bool
+	)
+	flush := func() {
+		if len(buf) > 0 {
+			result = append(result, segment{text: string(buf), cjk: cjk})
+			buf = buf[:0]
+		}
+	}
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			if !cjk {
+				flush()
+			}
+			cjk = true
+			buf = append(buf, r)
+		case isWordRune(r):
+			if cjk {
+				flush()
+			}
+			cjk = false
+			buf = append(buf, unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return result
+}
+
+// Tokenize 切分待索引的文本：中文按二元切分并保留单字，这样单字和词语都能检索到；
+// 英文和数字按单词切分并转为小写
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, seg := range segments(text) {
+		if !seg.cjk {
+			tokens = append(tokens, seg.text)
+			continue
+		}
+		runes := []rune(seg.text)
+		for i := range runes {
+			tokens = append(tokens, string(runes[i]))
+			if i+1 < len(runes) {
+				tokens = append(tokens, string(runes[i:i+2]))
+			}
+		}
+	}
+	return tokens
+}
+
+// QueryTokens 切分检索词：中文只有一个字时按单字检索，否则按二元切分，要求文档包含全部二元词；
+// 英文按单词切分。结果已去重
+func QueryTokens(query string) []string {
+	var tokens []string
+	seen := make(map[string]bool)
+	add := func(token string) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	for _, seg := range segments(query) {
+		runes := []rune(seg.text)
+		if !seg.cjk || len(runes) == 1 {
+			add(seg.text)
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			add(string(runes[i : i+2]))
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,163 @@
+package seo
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/markdown"
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// locale 页面语言
+const locale = "zh_CN"
+
+// ArticleMeta 文章的 SEO 信息，前台按字段写入页面头部
+// @Description 文章页的标题、描述、规范地址、Open Graph、Twitter 卡片和 JSON-LD 结构化数据
+type ArticleMeta struct {
+	// 页面标题
+	Title string `json:"title" example:"Go 并发编程 - 个人博客"`
+	// 描述，取正文纯文本的开头
+	Description string `json:"description" example:"本文介绍 Go 的 goroutine 和 channel..."`
+	// 关键词，分类和标签，逗号分隔
+	Keywords string `json:"keywords" example:"技术,Go,并发"`
+	// 规范地址
+	CanonicalURL string `json:"canonical_url" example:"https://example.com/article/1"`
+	// Open Graph 信息
+	OpenGraph OpenGraph `json:"open_graph"`
+	// Twitter 卡片信息
+	TwitterCard TwitterCard `json:"twitter_card"`
+	// JSON-LD 结构化数据，前台原样序列化后写入 <script type="application/ld+json">
+	JSONLD BlogPosting `json:"json_ld"`
+}
+
+// OpenGraph Open Graph 信息，字段对应 og:* 和 article:* 属性
+type OpenGraph struct {
+	Type          string   `json:"type" example:"article"`
+	Title         string   `json:"title" example:"Go 并发编程"`
+	Description   string   `json:"description"`
+	URL           string   `json:"url" example:"https://example.com/article/1"`
+	Image         string   `json:"image"`
+	SiteName      string   `json:"site_name" example:"个人博客"`
+	Locale        string   `json:"locale" example:"zh_CN"`
+	PublishedTime string   `json:"published_time" example:"2024-01-01T08:00:00+08:00"`
+	ModifiedTime  string   `json:"modified_time" example:"2024-01-02T08:00:00+08:00"`
+	Section       string   `json:"section" example:"技术"`
+	Tags          []string `json:"tags"`
+}
+
+// TwitterCard Twitter 卡片信息，字段对应 twitter:* 属性
+type TwitterCard struct {
+	// 有封面时为 summary_large_image，否则为 summary
+	Card        string `json:"card" example:"summary_large_image"`
+	Site        string `json:"site,omitempty" example:"@example"`
+	Title       string `json:"title" example:"Go 并发编程"`
+	Description string `json:"description"`
+	Image       string `json:"image,omitempty"`
+}
+
+// BlogPosting schema.org 的 BlogPosting 结构化数据
+type BlogPosting struct {
+	Context          string       `json:"@context" example:"https://schema.org"`
+	Type             string       `json:"@type" example:"BlogPosting"`
+	Headline         string       `json:"headline"`
+	Description      string       `json:"description"`
+	Image            []string     `json:"image,omitempty"`
+	URL              string       `json:"url"`
+	MainEntityOfPage JSONLDEntity `json:"mainEntityOfPage"`
+	DatePublished    string       `json:"datePublished"`
+	DateModified     string       `json:"dateModified"`
+	Author           JSONLDEntity `json:"author"`
+	Publisher        JSONLDEntity `json:"publisher"`
+	ArticleSection   string       `json:"articleSection,omitempty"`
+	Keywords         []string     `json:"keywords,omitempty"`
+	InLanguage       string       `json:"inLanguage"`
+}
+
+// JSONLDEntity 结构化数据中引用的对象，如作者、发布者和页面
+type JSONLDEntity struct {
+	Type string        `json:"@type"`
+	ID   string        `json:"@id,omitempty"`
+	Name string        `json:"name,omitempty"`
+	URL  string        `json:"url,omitempty"`
+	Logo *JSONLDEntity `json:"logo,omitempty"`
+}
+
+// NewArticleMeta 根据公开文章和网站信息生成 SEO 信息，site 为前台地址
+func NewArticleMeta(site string, article *models.Article, info models.WebsiteInfo) *ArticleMeta {
+	link := site + "/article/" + article.ID
+	description := markdown.Excerpt(article.Content, options.DescriptionLength)
+	image := absoluteURL(site, article.Cover)
+	published := article.CreatedAt.Format(time.RFC3339)
+	modified := article.UpdatedAt.Format(time.RFC3339)
+
+	var keywords []string
+	if article.CategoryName != "" {
+		keywords = append(keywords, article.CategoryName)
+	}
+	keywords = append(keywords, article.TagNames...)
+
+	title := article.Title
+	if info.WebsiteName != "" {
+		title += " - " + info.WebsiteName
+	}
+
+	tags := article.TagNames
+	if tags == nil {
+		tags = []string{}
+	}
+
+	card := "summary"
+	if image != "" {
+		card = "summary_large_image"
+	}
+
+	posting := BlogPosting{
+		Context:          "https://schema.org",
+		Type:             "BlogPosting",
+		Headline:         article.Title,
+		Description:      description,
+		URL:              link,
+		MainEntityOfPage: JSONLDEntity{Type: "WebPage", ID: link},
+		DatePublished:    published,
+		DateModified:     modified,
+		Author:           JSONLDEntity{Type: "Person", Name: info.WebsiteAuthor, URL: site + "/about"},
+		Publisher:        JSONLDEntity{Type: "Organization", Name: info.WebsiteName, URL: site},
+		ArticleSection:   article.CategoryName,
+		Keywords:         keywords,
+		InLanguage:       "zh-CN",
+	}
+	if image != "" {
+		posting.Image = []string{image}
+	}
+	if logo := absoluteURL(site, info.WebsiteAvatar); logo != "" {
+		posting.Publisher.Logo = &JSONLDEntity{Type: "ImageObject", URL: logo}
+	}
+
+	return &ArticleMeta{
+		Title:        title,
+		Description:  description,
+		Keywords:     strings.Join(keywords, ","),
+		CanonicalURL: link,
+		OpenGraph: OpenGraph{
+			Type:          "article",
+			Title:         article.Title,
+			Description:   description,
+			URL:           link,
+			Image:         image,
+			SiteName:      info.WebsiteName,
+			Locale:        locale,
+			PublishedTime: published,
+			ModifiedTime:  modified,
+			Section:       article.CategoryName,
+			Tags:          tags,
+		},
+		TwitterCard: TwitterCard{
+			Card:        card,
+			Site:        options.TwitterSite,
+			Title:       article.Title,
+			Description: description,
+			Image:       image,
+		},
+		JSONLD: posting,
+	}
+}
@@ -0,0 +1,59 @@
+// Package seo 面向搜索引擎的输出：站点地图、robots.txt，以及供前台注入页面头部的文章 SEO 信息
+package seo
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/netutil"
+)
+
+// maxSitemapURLs 站点地图协议规定单个文件最多包含的地址数
+const maxSitemapURLs = 50000
+
+// Options SEO 配置
+type Options struct {
+	SiteURL           string        // 前台地址，为空时使用请求的地址
+	SitemapMaxURLs    int           // 单个站点地图文件的最大地址数，超过后改为输出站点地图索引
+	SitemapCacheTTL   time.Duration // 站点地图的缓存时间
+	DescriptionLength int           // 文章描述的最大字符数
+	TwitterSite       string        // Twitter 卡片中的站点账号，如 @example
+}
+
+var options = Options{SitemapMaxURLs: maxSitemapURLs, SitemapCacheTTL: 10 * time.Minute, DescriptionLength: 150}
+
+// Init 根据配置设置 SEO 选项
+func Init(cfg *config.Config) {
+	options = Options{
+		SiteURL:           strings.TrimRight(cfg.SiteURL, "/"),
+		SitemapMaxURLs:    cfg.SitemapMaxURLs,
+		SitemapCacheTTL:   cfg.SitemapCacheTTL,
+		DescriptionLength: cfg.SeoDescriptionLength,
+		TwitterSite:       cfg.SeoTwitterSite,
+	}
+	if options.SitemapMaxURLs <= 0 || options.SitemapMaxURLs > maxSitemapURLs {
+		options.SitemapMaxURLs = maxSitemapURLs
+	}
+	if options.DescriptionLength <= 0 {
+		options.DescriptionLength = 150
+	}
+	resetSitemapCache()
+}
+
+// SiteURL 返回前台地址，未配置时使用请求的协议和主机
+func SiteURL(r *http.Request) string {
+	if options.SiteURL != "" {
+		return options.SiteURL
+	}
+	return netutil.RequestOrigin(r)
+}
+
+// absoluteURL 把站内相对地址转换为绝对地址
+func absoluteURL(site, link string) string {
+	if link == "" || strings.Contains(link, "://") || strings.HasPrefix(link, "//") {
+		return link
+	}
+	return site + "/" + strings.TrimLeft(link, "/")
+}
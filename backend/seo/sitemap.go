@@ -0,0 +1,256 @@
+package seo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+)
+
+// sitemapNS 站点地图协议的命名空间
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// indexablePages 对所有访客公开、值得收录的前台页面，键为页面标签，值为前台路径；
+// 文章列表、照片和个人中心等页面依附于其他地址或需要登录，不单独收录
+var indexablePages = map[string]string{
+	"archive":  "/archive",
+	"category": "/category",
+	"tag":      "/tag",
+	"talk":     "/talk",
+	"album":    "/album",
+	"picture":  "/picture",
+	"friend":   "/friend",
+	"message":  "/message",
+	"about":    "/about",
+}
+
+// sitemapEntry 站点地图中的一个前台地址，Path 为相对前台地址的路径
+type sitemapEntry struct {
+	Path    string
+	LastMod time.Time
+}
+
+// sitemapCache 缓存站点地图条目，避免搜索引擎频繁抓取时反复查询数据库
+var sitemapCache struct {
+	mu      sync.Mutex
+	entries []sitemapEntry
+	builtAt time.Time
+}
+
+// resetSitemapCache 清空站点地图缓存
+func resetSitemapCache() {
+	sitemapCache.mu.Lock()
+	sitemapCache.entries = nil
+	sitemapCache.builtAt = time.Time{}
+	sitemapCache.mu.Unlock()
+}
+
+// sitemapEntries 返回站点地图条目，缓存过期后重新生成
+func sitemapEntries() ([]sitemapEntry, error) {
+	sitemapCache.mu.Lock()
+	defer sitemapCache.mu.Unlock()
+
+	if sitemapCache.entries != nil && time.Since(sitemapCache.builtAt) < options.SitemapCacheTTL {
+		return sitemapCache.entries, nil
+	}
+	entries, err := buildSitemapEntries()
+	if err != nil {
+		return nil, err
+	}
+	sitemapCache.entries = entries
+	sitemapCache.builtAt = time.Now()
+	return entries, nil
+}
+
+// buildSitemapEntries 从数据库生成站点地图条目：首页、页面、分类、标签、相册和文章
+func buildSitemapEntries() ([]sitemapEntry, error) {
+	articles, err := models.GetSitemapArticles()
+	if err != nil {
+		return nil, err
+	}
+	pages, err := models.GetSitemapPages()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := models.GetSitemapCategories()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := models.GetSitemapTags()
+	if err != nil {
+		return nil, err
+	}
+	albums, err := models.GetSitemapAlbums()
+	if err != nil {
+		return nil, err
+	}
+
+	// 首页展示最新文章，修改时间取最近修改的文章
+	home := sitemapEntry{Path: "/"}
+	if len(articles) > 0 {
+		home.LastMod = articles[0].UpdatedAt
+	}
+	entries := []sitemapEntry{home}
+
+	for _, page := range pages {
+		if path, ok := indexablePages[page.Key]; ok {
+			entries = append(entries, sitemapEntry{Path: path, LastMod: page.UpdatedAt})
+		}
+	}
+	appendItems := func(prefix string, items []*models.SitemapItem) {
+		for _, item := range items {
+			entries = append(entries, sitemapEntry{Path: prefix + item.Key, LastMod: item.UpdatedAt})
+		}
+	}
+	appendItems("/category/", categories)
+	appendItems("/tag/", tags)
+	appendItems("/album/", albums)
+	appendItems("/article/", articles)
+	return entries, nil
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	NS       string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+// formatLastMod 按 W3C 日期时间格式输出修改时间，零值不输出
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// SitemapHandler 输出 /sitemap.xml：地址数不超过单个文件的上限时直接输出全部地址，
+// 否则输出站点地图索引，指向 /sitemap-1.xml、/sitemap-2.xml ……
+func SitemapHandler(w http.ResponseWriter, r *http.Request) {
+	entries, err := sitemapEntries()
+	if err != nil {
+		log.Printf("生成站点地图失败: %v", err)
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	if len(entries) <= options.SitemapMaxURLs {
+		writeURLSet(w, SiteURL(r), entries)
+		return
+	}
+
+	origin := netutil.RequestOrigin(r)
+	index := sitemapIndex{NS: sitemapNS}
+	for part, start := 1, 0; start < len(entries); part, start = part+1, start+options.SitemapMaxURLs {
+		end := start + options.SitemapMaxURLs
+		if end > len(entries) {
+			end = len(entries)
+		}
+		var lastMod time.Time
+		for _, entry := range entries[start:end] {
+			if entry.LastMod.After(lastMod) {
+				lastMod = entry.LastMod
+			}
+		}
+		index.Sitemaps = append(index.Sitemaps, sitemapURL{
+			Loc:     fmt.Sprintf("%s/sitemap-%d.xml", origin, part),
+			LastMod: formatLastMod(lastMod),
+		})
+	}
+	writeXML(w, index)
+}
+
+// SitemapPartHandler 输出站点地图索引中的第 part 个文件，路由变量 part 从1开始
+func SitemapPartHandler(w http.ResponseWriter, r *http.Request) {
+	part, err := strconv.Atoi(mux.Vars(r)["part"])
+	if err != nil || part < 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	entries, err := sitemapEntries()
+	if err != nil {
+		log.Printf("生成站点地图失败: %v", err)
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+
+	start := (part - 1) * options.SitemapMaxURLs
+	if start >= len(entries) {
+		http.NotFound(w, r)
+		return
+	}
+	end := start + options.SitemapMaxURLs
+	if end > len(entries) {
+		end = len(entries)
+	}
+	writeURLSet(w, SiteURL(r), entries[start:end])
+}
+
+// writeURLSet 输出一组前台地址
+func writeURLSet(w http.ResponseWriter, site string, entries []sitemapEntry) {
+	set := urlSet{NS: sitemapNS, URLs: make([]sitemapURL, 0, len(entries))}
+	for _, entry := range entries {
+		set.URLs = append(set.URLs, sitemapURL{Loc: site + entry.Path, LastMod: formatLastMod(entry.LastMod)})
+	}
+	writeXML(w, set)
+}
+
+// writeXML 输出带 XML 声明的站点地图文档
+func writeXML(w http.ResponseWriter, v interface{}) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Printf("生成站点地图失败: %v", err)
+		http.Error(w, "服务器错误", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(options.SitemapCacheTTL.Seconds())))
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
+
+// disallowedPaths 不允许搜索引擎抓取的路径：接口、文档和需要登录的页面
+var disallowedPaths = []string{
+	"/blog-api/",
+	"/swagger/",
+	"/login",
+	"/register",
+	"/forget-password",
+	"/user",
+	"/oauth/",
+	"/404",
+}
+
+// RobotsHandler 输出 /robots.txt，并声明站点地图的地址
+func RobotsHandler(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	b.WriteString("Allow: /\n")
+	for _, path := range disallowedPaths {
+		b.WriteString("Disallow: " + path + "\n")
+	}
+	b.WriteString("\nSitemap: " + netutil.RequestOrigin(r) + "/sitemap.xml\n")
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Write([]byte(b.String()))
+}
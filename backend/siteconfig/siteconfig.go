@@ -0,0 +1,147 @@
+// Package siteconfig 网站配置：配置保存在数据库中，每次修改记录一个版本，可以回滚到任意历史版本；
+// 读取时使用进程内缓存，本进程写入后立即失效，其他进程的修改在缓存过期后生效
+package siteconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+// entry 缓存的配置项原始内容
+type entry struct {
+	value     string
+	version   int
+	expiresAt time.Time
+}
+
+var (
+	mu      sync.Mutex
+	ttl     = time.Minute
+	entries = make(map[string]entry)
+)
+
+// Init 根据配置设置缓存有效期
+func Init(cfg *config.Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	ttl = cfg.SiteConfigCacheTTL
+}
+
+// DefaultWebsiteConfig 返回数据库中还没有网站配置时使用的默认配置
+func DefaultWebsiteConfig() *models.WebsiteConfig {
+	return &models.WebsiteConfig{
+		WebsiteInfo: models.WebsiteInfo{
+			WebsiteName: "个人博客",
+		},
+		SocialUrlList: []models.SocialAccountInfo{},
+	}
+}
+
+// load 读取配置项的原始内容，优先使用缓存
+func load(key string) (string, int, error) {
+	mu.Lock()
+	e, ok := entries[key]
+	mu.Unlock()
+	if ok && time.Now().Before(e.expiresAt) {
+		return e.value, e.version, nil
+	}
+
+	value, version, err := models.GetWebsiteConfigValue(key)
+	if err != nil {
+		return "", 0, err
+	}
+
+	mu.Lock()
+	entries[key] = entry{value: value, version: version, expiresAt: time.Now().Add(ttl)}
+	mu.Unlock()
+	return value, version, nil
+}
+
+// invalidate 使配置项的缓存失效
+func invalidate(key string) {
+	mu.Lock()
+	delete(entries, key)
+	mu.Unlock()
+}
+
+// save 保存配置项并使缓存失效，返回新的版本号
+func save(key string, v interface{}, operatorID, rollbackFrom int) (int, error) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf("序列化网站配置失败: %w", err)
+	}
+	version, err := models.SaveWebsiteConfigValue(key, string(value), operatorID, rollbackFrom)
+	invalidate(key)
+	return version, err
+}
+
+// Website 返回当前网站配置和版本号，每次返回新的副本，调用方可以随意修改
+func Website() (*models.WebsiteConfig, int, error) {
+	value, version, err := load(models.ConfigKeyWebsite)
+	if err != nil {
+		return nil, 0, err
+	}
+	cfg := DefaultWebsiteConfig()
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), cfg); err != nil {
+			return nil, 0, fmt.Errorf("解析网站配置失败: %w", err)
+		}
+	}
+	if cfg.SocialUrlList == nil {
+		cfg.SocialUrlList = []models.SocialAccountInfo{}
+	}
+	return cfg, version, nil
+}
+
+// Feature 返回网站功能开关，读取失败时返回全部关闭
+func Feature() models.WebsiteFeature {
+	cfg, _, err := Website()
+	if err != nil {
+		return models.WebsiteFeature{}
+	}
+	return cfg.WebsiteFeature
+}
+
+// UpdateWebsite 保存网站配置，返回新的版本号
+func UpdateWebsite(cfg *models.WebsiteConfig, operatorID int) (int, error) {
+	return save(models.ConfigKeyWebsite, cfg, operatorID, 0)
+}
+
+// AboutMe 返回关于我的内容
+func AboutMe() (string, error) {
+	value, _, err := load(models.ConfigKeyAboutMe)
+	if err != nil || value == "" {
+		return "", err
+	}
+	var aboutMe models.AboutMe
+	if err := json.Unmarshal([]byte(value), &aboutMe); err != nil {
+		return "", fmt.Errorf("解析关于我失败: %w", err)
+	}
+	return aboutMe.Content, nil
+}
+
+// UpdateAboutMe 保存关于我的内容，返回新的版本号
+func UpdateAboutMe(content string, operatorID int) (int, error) {
+	return save(models.ConfigKeyAboutMe, models.AboutMe{Content: content}, operatorID, 0)
+}
+
+// Rollback 把配置项恢复为指定历史版本的内容，恢复本身也记录为一个新版本，返回新的版本号
+func Rollback(key string, version, operatorID int) (int, error) {
+	v, err := models.GetWebsiteConfigVersion(key, version)
+	if err != nil {
+		return 0, err
+	}
+	newVersion, err := models.SaveWebsiteConfigValue(key, string(v.ConfigValue), operatorID, version)
+	invalidate(key)
+	return newVersion, err
+}
+
+// ValidKey 判断配置项是否存在
+func ValidKey(key string) bool {
+	return key == models.ConfigKeyWebsite || key == models.ConfigKeyAboutMe
+}
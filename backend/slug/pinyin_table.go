@@ -0,0 +1,402 @@
+package slug
+
+// pinyinTable 按拼音（不带声调，ü 写作 v）列出读该音的汉字，多音字只取最常用的读音。
+// 覆盖 GB2312 全部一级汉字，其余汉字按 Unicode 拼音排序规则插补，没有收录的汉字生成别名时被忽略
+var pinyinTable = map[string]string{
+	"a":      "啊阿",
+	"ai":     "伌哀哎唉啀嗌嗳嘊噯埃塧娭娾嫒愛挨捱敱敳昹欸毐溰溾濭爱癌皑皚矮砹硋碍艾蔼藹躷銰鎄锿隘霭靄騃",
+	"an":     "侒俺儑唵啽垵埯堓婩媕安岸峖庵按揞晻暗案桉氨洝犴玵痷盦盫罯胺腤荌菴萻葊蓭誝諳谙豻銨铵隌雸鞌鞍韽馣鵪鶕鹌",
+	"ang":    "卬岇昂昻枊盎肮骯",
+	"ao":     "傲凹厫嗷嗸坳垇墺奡奥奧媪媼嫯岙岰嶅嶴廒慠懊扷抝拗摮敖柪梎滶澳熬爊獒獓璈磝翱翺聱芺蔜螯袄襖謷謸軪遨鏖镺隞隩骜鰲鳌鷔鼇",
+	"ba":     "仈八叐叭吧哵坝坺垻墢壩夿妭岜峇巴巼弝扒把抜拔捌朳柭欛灞炦爸犮玐疤癹矲笆粑紦罢羓耙胈芭茇菝蚆覇詙豝跁跋軷釛釟鈀钯霸靶颰魃鮊鲃鲅鲌鼥",
+	"bai":    "佰庍拜拝捭摆擺敗柏栢猈瓸白百稗粨絔襬败",
+	"ban":    "伴办半坂坢姅岅怑扮扳拌搬攽斑斒昄板柈湴版班瓣瓪瘢癍秚粄絆绊舨般蝂螌褩辦辬鈑鉡钣闆阪靽頒颁魬鳻",
+	"bang":   "傍垹塝帮幇幚幫捠搒梆棒棓榜浜牓玤磅稖綁縍绑膀蒡蚌蜯谤邦邫镑鞤髈",
+	"bao":    "保儤剥勽包堡堢報媬嫑孢宝宲寚寳寶怉报抱暴曓枹煲爆珤窇笣緥胞苞菢葆蕔薄虣蚫袌褒褓襃豹賲趵鉋铇闁雹靌靤飽饱駂骲髱鮑鲍鳵鴇鸨齙龅",
+	"bei":    "俻倍偝偹備僃北卑备孛悖悲惫揹昁杯桮梖椑焙牬狈狽珼琲盃碑禆背苝藣被貝贝軰辈邶郥鄁鉳錃钡鵯鹎",
+	"ben":    "倴坋坌奔奙捹本栟桳楍泍渀犇畚笨翉苯贲錛锛",
+	"beng":   "傰嘣埄埲塴崩嵭泵琣琫甏甭痭絣綳繃绷菶蹦迸逬镚閍鞛",
+	"bi":     "佊佖俾匕吡哔啚嗶坒堛壁夶妣妼婢嬖币幣庇庳廦弊弻弼彃彼必怭怶愊愎敝斃朼枈柀柲梐楅比毕毖毙沘湢滗滭潷濞煏熚狴獘獙珌畀畢疕疪痹痺皕睤碧秕笓笔筆筚箄箅箆篦篳粃粊綼縪罼聛腷臂舭苾荜荸萆蓖蓽蔽薜蜌螕袐裨觱詖诐豍貏貱賁赑跸辟逼避邲鄙鄪鉍鎞铋閇閉閟闭陛飶馝駜髲鮅鰏鲾鵖鼻",
+	"bian":   "便匥匾卞变変弁徧忭惼扁抃揙昪汳汴煸牑猵玣甂砭碥稨窆笾箯籩糄編緶缏编艑苄萹藊蝙褊覍貶贬辡辧辨辩辫边辺遍邉邊釆鍽閞鞭鯾鯿鳊鴘",
+	"biao":   "儦墂幖彪摽标標淲滮瀌熛爂猋瘭磦穮脿膘臕蔈藨表謤贆鏢鑣镖镳颩颮颷飆飇飈飑飙飚驃驫骉骠髟",
+	"bie":    "別别咇徶憋瘪莂虌蛂蟞襒蹩鱉鳖鼈龞",
+	"bin":    "傧儐宾彬摈斌梹椕槟檳滨濒濱瀕瑸璸繽缤虨豩豳賓賔鑌镔霦顮",
+	"bing":   "丙並併倂兵冰并幷庰怲抦掤摒昞昺柄栤炳病眪禀秉稟窉苪蛃邴鈵鉼陃鞞餅餠饼",
+	"bo":     "亳仢伯侼僠僰剝勃博卜哱啵嚗孹嶓帛愽懪拨挬捕搏撥播檗欂泊波浡渤煿牔犦犻狛猼玻瓝瓟癷盋砵碆礡礴秡箔箥簙簸糪紴缽肑胉脖膊舶艊苩菠萡葧蔔蘗袚袯袰袹襏襮譒豰跛踣蹳郣鈸鉑鉢鋍鎛鑮钵钹铂镈餑餺饽馎馛馞駁駮驋驳髆髉鮁鱍鵓鹁",
+	"bu":     "不佈勏吥咘哺喸埗埠布怖悑抪捗柨步歨歩瓿篰簿荹蔀补補踄部郶钚钸餔餢鵏",
+	"ca":     "擦",
+	"cai":    "倸啋埰寀彩才採材棌毝溨犲猜睬綵纔菜蔡裁財财跴踩采",
+	"can":    "傪参參叄叅喰嬠嬱惨惭慘慙慚憯朁残殘湌灿穇篸蚕蝅蠶蠺飡餐驂骖黪黲",
+	"cang":   "仓倉傖嵢沧滄獊舱艙苍蒼藏螥鶬鸧",
+	"cao":    "嘈嶆操曹曺槽漕糙艚艸草蓸螬褿鏪",
+	"ce":     "侧側册厕厠廁恻惻拺敇测測畟笧策粣萗",
+	"ceng":   "层蹭",
+	"cha":    "侘偛叉嗏垞奼姹察岔嵖差扠挿插揷搽杈查槎檫汊猹疀碴秅肞臿艖茬茶衩詧诧蹅銟鍤鑔锸镲靫餷馇",
+	"chai":   "侪拆柴豺釵钗",
+	"chan":   "丳产僝儃儳冁刬剗剷劖啴嘽嚵囅婵嬋嵼巉幝幨廛忏懴掺搀摌摲攙斺旵棎欃毚浐湹滻潹潺澶瀍瀺灛煘燀獑產産硟磛禅禪簅緾繟纏纒缠艬蒇蕆蝉蟬蟾裧襜覘誗諂譂讇讒谄谗躔辴鄽酁鉆鋋鋓鏟鑱铲镡镵閳闡阐颤饞馋骣",
+	"chang":  "仧倀倡偿僘償兏厂厰唱嘗嚐场場塲娼嫦尝常廠徜怅悵惝敞昌昶晿暢氅淐焻猖玚琩瑒瑺瓺甞畅畼肠腸膓苌菖萇裮誯鋹鋿錩鏛锠镸长閶阊韔鬯鯧鱨鲳鲿鼚",
+	"chao":   "勦吵嘲巢巣弨怊抄晁朝樔欩漅潮炒焯牊窲罺訬謿超轈鄛鈔钞鼂鼌",
+	"che":    "伡俥偖唓坼屮彻徹扯掣撤撦澈烢砗硨硩聅莗蛼車车迠頙",
+	"chen":   "儬儭嗔嚫塵墋夦宸尘忱愖捵揨敐晨曟榇樄櫬沉烥煁琛疢瘎瞋硶碜磣綝縝臣茞莀莐蔯薼螴衬襯訦諃諶謓讖谌谶賝贂趁趂趻踸軙辰迧郴醦鈂鍖陈陳霃鷐麎齓齔龀",
+	"cheng":  "丞乗乘侱偁僜呈城埕堘塍塖娍宬峸庱徎悜惩憆憕懲成承挰掁摚撐撑晟朾枨棖椉橕橙檉檙洆湞溗澂澄瀓牚珵珹畻睈瞠碀秤称程稱穪窚竀筬絾緽脀脭荿蛏蟶裎誠诚赪赬逞郕酲鋮鏳鏿铖靗頳饓騁騬骋",
+	"chi":    "侈侙勅勑匙卶叱叺吃呎哧喫嗤噄坻垑墀媸尺岻弛彨彲彳恜恥抶持摛斥杘欼歭歯池漦灻炽瓻痴癡眵瞝竾笞筂箎篪粎絺翄翅耻胣胵茌荎蚇蚩蚳螭袲袳裭褫訵誺謘貾赤赿趍踟迟遅遟遲鉹饬馳驰魑鴟鸱黐齒齝齿",
+	"chong":  "充冲嘃宠崇崈徸忡憃憧摏沖浺爞珫緟罿翀舂艟茺虫蝩蟲衝褈蹖隀",
+	"chou":   "丑丒仇侴俦偢儔吜嚋婤嬦帱幬怞惆愁懤抽搊杻杽栦椆殠燽犨犫畴疇瘳皗瞅矁稠筹篘籌紬絒綢绸臭臰菗薵裯讎讐踌躊遚酧酬醜醻雔雠魗",
+	"chu":    "亍俶傗储儊儲処出刍初厨嘼埱处媰岀幮廚怵憷拀搐摴敊斶杵柷椘楚楮樗橱檚櫉櫥欪歜滀滁濋犓珿琡矗础礎竌竐篨絀绌耡臅芻蒢蒭蓫蕏藸處蜍褚触觸諔豖豠貙趎踀蹰躇躕鄐鉏鋤锄閦除雏雛鶵鸀黜齣齭齼",
+	"chuai":  "揣",
+	"chuan":  "串伝传傳僢剶喘圌川暷椽歂氚汌猭瑏穿篅舛舡舩船荈踳輲遄",
+	"chuang": "傸创噇幢床摐摤牀牎牕疮瘡磢窓窗窻闖闯",
+	"chui":   "倕吹垂埀捶炊锤陲",
+	"chun":   "偆唇堾媋惷春暙椿橁櫄浱淳湻滣漘犉瑃睶箺純纯脣膥莼萅萶蒓蓴蝽蠢賰輴醇醕錞陙鯙鰆鶞",
+	"chuo":   "娕娖婼惙戳涰绰辵辶",
+	"ci":     "佌佽偨刺刾垐堲嬨庛慈朿柌栨次此泚濨玼珁瓷甆疵皉磁礠祠糍紪絘縒茈茦茨莿薋蛓蠀詞词赐赼趀跐辝辞辤辭雌飺餈骴髊鮆鴜鶿鷀鹚齹",
+	"cong":   "丛从匆囪囱忩怱悤暰枞棇樅樬漗焧熜燪瑽璁瞛篵緫繱聡聦聪聰苁葱蓯蔥蟌鍯鏦騘驄骢",
+	"cou":    "凑",
+	"cu":     "促噈徂憱殂猝瘄瘯簇粗脨蔟觕誎趗踧酢醋麁麄麤",
+	"cuan":   "巑攛櫕欑殩熶穳窜篡蹿躥鑹",
+	"cui":    "伜倅催凗啐啛墔崔嶉忰悴慛摧榱槯毳淬漼焠獕璀疩瘁皠磪粋粹紣綷縗缞翆翠脃脆脺萃趡鏙",
+	"cun":    "侟刌存寸忖拵村澊皴竴踆",
+	"cuo":    "剉剒厝夎嵯嵳挫措搓撮斮棤瑳痤睉矬磋脞莝莡蒫蓌蔖虘蹉躦逪遳酂醝锉错鹺鹾",
+	"da":     "剳匒呾噠垯大妲怛打搭撘汏沓炟畗畣瘩眔笪答羍荙薘蟽褡詚躂达迖逹達鎉鎝鐽阘靼鞑韃龖龘",
+	"dai":    "代傣叇呆呔垈埭岱帒带帯帶廗待怠懛戴曃柋歹殆獃玳瑇甙紿緿绐袋貸贷軑軚軩轪迨逮骀鴏",
+	"dan":    "丹亶伔但儋刐勯匰单単啖啗啿單妉媅帎弹弾惮抌担掸撢撣擔旦柦殚殫氮沊淡澸狚玬瓭甔疍疸瘅癉眈砃箪簞紞耼耽聃聸胆膽萏蛋衴褝襌诞赕躭郸鄲頕黕黮",
+	"dang":   "党凼噹圵垱宕当挡擋攩档欓氹澢灙珰璫當砀筜簹艡荡蟷裆襠譡讜谠黨",
+	"dao":    "倒刀刂到叨噵壔导導岛島嶋嶌嶹忉悼捣捯搗擣朷氘焘盗盜祷禂禱稲稻箌翢舠菿蹈道釖隝隯魛鱽",
+	"de":     "得德的鍀",
+	"deng":   "凳噔墱嬁嶝戥朩灯燈璒登瞪竳等簦覴豋蹬邓鄧隥",
+	"di":     "低俤偙厎呧唙啇啲嘀嚁地坔坘埊埞堤奃娣媂嫡帝底廸弟弤彽怟抵拞掋敌敵旳杕柢梊梑棣樀涤渧滌滴焍牴狄玓珶眱睇砥磾祶笛第篴籴糴缔羝翟聜苖茋荻菂菧蒂蔋蔐藡袛覿觌觝詆诋谛豴趆蹢軧迪递逓邸釱鍉鏑镝阺隄靮鞮頔馰骶髢鬄鸐",
+	"dian":   "佃傎典厧嚸坫垫墊壂奌奠婝婰嵮巅巓巔店惦扂掂攧敁敟槇槙橂橝殿淀滇澱点猠玷琔电甸瘨癫癲碘蒧蕇蜔跕踮蹎钿阽電靛顚顛颠點齻",
+	"diao":   "伄凋刁叼吊奝屌弔弴彫扚掉殦汈琱瞗碉窎虭蛁訋调貂钓雕鮉鯛鲷鳭鵰鼦",
+	"die":    "叠喋垤堞峌嵽恎惵戜挕揲昳殜爹牃牒瓞畳眣碟絰绖耋胅臷艓苵蜨蝶褋褺詄谍趃跌迭镻",
+	"ding":   "丁仃叮啶奵定嵿帄忊椗濎玎疔盯矴碇耵腚薡虰訂订酊釘鐤钉铤锭靪頂顶飣饤鼎鼑",
+	"diu":    "丢",
+	"dong":   "东侗倲冬冻动咚垌埬墥姛娻嬞岽峒崠崬徚恫懂挏昸東栋氡氭洞涷笗箽苳菄董蕫蝀諌鯟鴤鶇鸫鼕",
+	"dou":    "兜唞抖斗枓枡梪毭浢痘脰荳蚪豆逗郖酘鈄陡饾鬥",
+	"du":     "凟匵堵妒妬嬻帾度杜椟櫝殰毒涜渎渡瀆牍牘犊犢独獨琽瓄皾督睹碡秺笃篤肚芏荰蝳裻覩読讀讟读豄賭贕赌都醏錖鑟镀闍靯韇韣韥騳髑黩黷",
+	"duan":   "塅断椴段煅瑖短碫端缎腶葮褍鍴锻",
+	"dui":    "兊兌兑堆塠对嵟痽磓鐜队頧鴭",
+	"dun":    "伅吨噸囤墩墪庉惇撉撴敦橔沌炖犜獤盹盾砘礅蜳趸蹲蹾躉逇遁钝顿驐",
+	"duo":    "亸凙刴剁剟剫咄哆哚喥嚉嚲垛垜埵堕墮墯多夛夺奪奲尮崜嶞悳惰憜挅挆掇敓敚敠敪朵朶柁柮桗椯毲炨畓痥綞缍舵裰趓跢跥跺踱躱躲軃鈬鍺鐸铎陊陏飿饳鮵鵽",
+	"e":      "俄匎厄吪呃呝咢咹噁囮垩姶娥屵岋峉峨峩恶戹扼枙歺涐珴皒睋砈砐砨磀苊莪蚅蛾訛誐譌讹轭迗遏鄂鈋锇阨阸隲頋頟額额饿騀魤鰪鵝鵞鹅",
+	"en":     "恩",
+	"er":     "二佴侕儿児兒刵厼咡唲尒尓尔峏弍弐栭栮毦洏洱爾珥粫而耳聏胹荋薾袻贰趰輀轜迩邇铒陑隭餌饵駬髵鮞鲕鴯鸸",
+	"fa":     "乏伐佱傠发垡姂彂栰橃沷法浌灋珐疺発發瞂砝筏罚罰罸茷藅酦醱閥阀",
+	"fan":    "凡凢凣勫反噃墦奿嬏帆幡忛憣払旙旛杋柉棥樊橎氾汎泛渢瀪瀿烦煩燔犯璠番矾礬笲籓籵緐繁繙羳翻膰舧范蕃薠藩蘩蠜襎訉贩蹯轓返釩鐇鐢钒颿飜饭鱕鷭",
+	"fang":   "仿倣坊埅妨彷房放方旊昉昘枋汸淓牥瓬眆紡纺肪舫芳蚄訪访趽邡鈁钫防髣魴鰟鲂鴋鶭",
+	"fei":    "匪吠啡奜妃婓废悱扉斐昲朏杮棐榧沸淝渄狒猆篚緋绯翡肥肺胇腓芾菲蕜蜚蜰蟦裶誹诽费霏非靟飛飝飞餥馡騑騛鲱",
+	"fen":    "份偾兝兺分吩哛坟墳奋妢岎帉幩弅忿愤昐朆朌枌梤棻棼橨氛汾濆炃焚燌燓秎粉粪紛纷羒羵翂肦芬蒶蕡蚠蚡衯訜豮豶轒酚鈖鐼隫雰餴饙馚馩魵黂黺鼖鼢",
+	"feng":   "丰仹俸偑僼冯凤凨凬凮唪堸夆奉妦寷封峯峰崶捀摓枫桻楓檒沣沨浲湗漨灃烽焨煈犎猦甮疯瘋盽砜碸篈綘缝艂葑蘴蜂蠭覂諷讽豐逢鄷酆鋒鏠锋闏霻靊風飌风馮麷",
+	"fo":     "佛",
+	"fou":    "否",
+	"fu":     "乀乶付伏俌俘俛俯偩傅冨冹凫刜副匐呒咈咐哹嘸圑坿垘垺复夫妇娐婦媍嬔孚孵富尃岪峊巿幅幞府弗弣彿復怤怫懯扶抚拂拊捬撨撫敷斧旉服枎柎柫栿桴棴椱榑氟泭洑浮涪滏澓炥烰焤父玸琈甫甶畉畐痡癁盙砆砩祓祔福秿稃稪竎符笰筟箙簠粰糐紨紱紼絥綍綒緮縛绂绋缚罘罦翇肤胕脯腐腑腹膚艀艴芙芣苻茀茯荂荴莩菔萯葍蕧虙蚥蚨蚹蛗蜅蜉蝜蝠蝮衭袝袱複褔襆覆訃詂諨讣豧負賦賻负赋赙赴趺跗踾輔輹輻辅辐郙郛鄜酜釜釡鈇鉘鉜鍑鍢阜阝附陚韍韨頫颫馥駙驸髴鬴鮄鮒鰒鲋鳆鳧鳬鳺鴔鵩鶝麩麬麱麸黻黼",
+	"ga":     "嘎嘠噶尜钆",
+	"gai":    "丐乢匃匄垓姟峐忋戤摡改晐杚概溉畡盖祴絠絯荄葢該该豥賅赅郂鈣钙阣陔隑",
+	"gan":    "乹亁仠倝凎凲坩尲尴尶尷干幹忓感扞擀攼敢旰杆柑桿榦橄檊汵泔淦漧澉玕甘疳皯盰矸秆稈竿笴筸簳粓紺绀肝芉苷衦詌贑赣赶趕迀酐骭魐鰔鱤鳡鳱",
+	"gang":   "冈冮刚剛堈堽岗岡崗掆杠棡港牨犅疘矼綱纲缸罁罓罡肛釭鋼鎠钢",
+	"gao":    "吿告夰搞暠杲槀槁槔槹橰檺櫜滜皋皐睾稾稿篙糕縞缟羔羙膏臯菒藁藳镐餻高髙鷎鷱鼛",
+	"ge":     "个仡佮個割匌各呄哥哿嗝圪塥愅戈戓戨挌搁搿擱敋格槅歌滆滒牫牱犵獦疙硌纥肐胳膈臵舸茖葛虼蛒蛤袼裓觡諽謌輵轕鎶铬镉閣閤阁隔革鞈鞷韐韚騔骼鬲鮯鴐鴚鴿鸽",
+	"gei":    "给",
+	"gen":    "根跟",
+	"geng":   "哽埂峺庚挭搄更梗浭焿畊絚綆緪縆绠羮羹耕耿莄菮賡赓郠骾鯁鲠鶊鹒",
+	"gong":   "供公共功匑厷塨宫宮工巩幊廾弓恭愩拱拲攻杛栱汞熕玜珙碽糼肱觥觵贡躬躳輁鋛鞏髸龏龔龚",
+	"gou":    "佝冓勾坸垢够姤岣构枸沟溝狗玽笱篝緱缑耇耈耉芶苟茩蚼袧褠诟豿购鈎鉤钩鞲韝",
+	"gu":     "估傦僱凅古呱咕唂唃啒嘏固堌夃姑嫴孤尳峠崓崮愲扢故柧梏棝榖榾橭毂汩沽泒淈濲瀔牯牿痼皷皼盬瞽祻稒穀笟箍箛糓縎罛罟羖股脵臌苽菇菰蓇薣蛄蛊蛌蠱觚詁诂谷軱軲轂轱辜逧酤鈲鈷錮钴锢雇顧顾餶馉骨鮕鯝鲴鴣鶻鸪鹄鹘鼓鼔",
+	"gua":    "冎刮剐剮劀卦叧啩坬寡挂掛栝歄煱瓜絓緺罣罫聒胍褂诖趏踻銽颳騧鴰鸹",
+	"guai":   "乖叏夬怪拐掴摑枴柺箉",
+	"guan":   "丱倌关冠官悹悺惯慣掼摜棺樌毌泴涫潅灌爟琯瓘痯瘝癏盥矔礶祼窤筦管罆罐舘莞蒄覌観觀观貫贯輨遦錧鏆関闗關雚館馆鰥鱞鳏鳤鹳",
+	"guang":  "侊俇僙光咣垙姯广広廣桄洸灮炗炛烡犷獷珖胱臩茪輄逛銧黆",
+	"gui":    "亀佹刽刿匦匭厬圭垝妫姽媯嫢嬀宄巂帰庋庪廆归恑摫攰攱昋晷朹柜桂桧椝槻槼櫷歸氿湀猤珪瑰璝瓌癸皈瞡硅祪窐筀簋胿膭茥蓕蛫螝蟡袿規规觤詭诡貴贵跪軌轨邽郌閨闺陒騩鬶鬹鬼鮭鲑龜龟",
+	"gun":    "棍滚滾磙緄蓘蔉輥辊鮌鯀鲧",
+	"guo":    "嘓囯囶囻国圀國堝墎崞帼幗彉彍惈慖果椁槨淉漍濄猓瘑粿綶聝腘膕菓蔮虢蜾蝈蟈裹輠过郭鈛錁鍋鐹锅餜馃馘",
+	"ha":     "哈",
+	"hai":    "亥妎孩害氦海烸胲還酼醢骇骸",
+	"han":    "丆傼函凾厈含咁哻唅喊圅垾娢嫨寒屽岾崡嵅悍憨憾捍撖撼旱晗晘晥暵梒歛汉汗浛浫涆涵漢澏焊焓熯猂琀甝皔睅筨罕翰肣莟菡蔊虷蛿蜬蜭谽豃貋邗邯酣釬銲鋎鋡閈闬阚韓韩頇颔馠馯鬫魽鼾",
+	"hang":   "夯斻杭珩笐绗航苀迒",
+	"hao":    "儫号哠嗥嘷噑嚎壕好峼恏悎昊昦椃毫浩濠獆獋獔秏籇耗蠔諕譹豪郝",
+	"he":     "何佫劾厒合呵咊和哬啝喝嗃嗬垎姀峆惒敆曷柇核楁毼河涸渮澕焃煂熆熇狢皬盇盉盍盒碋礉禾秴篕籺紇翮荷菏萂蚵螛蠚袔褐覈訶訸詥貈貉賀贺赫輅郃鉌鑉闔阂阖鞨頜颌饸魺鲄鶡鹖鹤麧齕龁龢",
+	"hei":    "嘿黑",
+	"hen":    "佷很恨狠痕詪鞎",
+	"heng":   "亨哼啈姮恆恒悙桁横橫烆胻脝衡鸻",
+	"hong":   "仜吰哄嚝垬妅娂宏宖峵弘揈汯泓洪浤渹烘焢玒硔硡竑紅紘紭红纮翃耾苰荭薨虹訇谹谾軣輷轟轰鍧闳鸿",
+	"hou":    "侯候厚后吼喉垕堠帿後洉犼猴瘊睺矦篌糇翭翵葔豞逅郈鄇鍭餱骺鮜鯸鱟鲎鲘",
+	"hu":     "乎乕乥互俿冱冴匫呼唬唿喖嗀嘑嘝嚛囫垀壶壷壺婟媩嫭嫮寣岵帍幠弖弧忽怘怙恗惚戯戶户戸戽扈抇护搰摢斛昈昒曶枑楛楜槲槴歑汻沍沪泘浒淴湖滬滸滹瀫烀焀煳熩狐猢琥瑚瓠瓳祜笏箶簄粐糊絗綔縠胡膴芐苸萀葫蔛蔰虎虖虝蝴螜衚觳謼護軤轷鄠醐鍙鍸隺雐雽韄頀頶餬鬍魱鰗鱯鳠鳸鵠鶘鶦鸌鹕鹱",
+	"hua":    "划化华哗嘩夻姡搳撶杹滑猾画磆花芲華蒊蕐螖譁话釪釫鋘錵鏵铧驊骅鷨",
+	"huai":   "坏徊怀懐懷槐櫰淮瀤耲蘹褢褱踝",
+	"huan":   "唤喚喛圜奂奐嬛宦寏寰峘嵈幻患愌换換擐攌桓梙槵欢洹浣涣渙漶澣澴烉焕煥狟环瑍環瓛痪瘓睆糫絙綄緩繯缓缳羦肒荁萈萑藧豢豲貆轘还逭郇鉮鍰鐶锾镮闤阛雈鬟鯇鰀鲩鹮",
+	"huang":  "偟兤凰喤堭塃墴奛媓宺崲幌徨怳恍惶愰慌晃晄曂朚楻榥櫎湟滉潢炾煌熀熿獚瑝璜癀皇皝皩磺穔篁篊簧艎荒葟蝗蟥衁詤諻謊谎趪遑鍠鎤鐄锽隍韹餭騜鰉鱑鳇鷬黃黄",
+	"hui":    "会佪僡匯卉咴哕喙嘒噅噕囘回囬圚婎媈寭幑廻廽彗彙彚徽恚恛恢恵悔惠慧拻挥揮撝晖晦暉會楎檓毀毁毇汇泋洃洄浍湏滙瀈灰烠烣烩煇燬珲痐瘣睳禈秽絵绘缋翙翚翬芔茴荟蔧蘳虺蚘蛔蛕蜖袆褘詯詼誨譭讳诙诲豗賄贿輝辉迴逥阓隓隳颒鮰鰴麾",
+	"hun":    "俒倱圂堚婚忶惛掍昏昬梡棔殙浑涽混渾琿睧睯繉荤葷诨轋閽阍餛馄魂鯶鼲",
+	"huo":    "伙佸俰咟夥奯惑或捇掝攉旤楇沎活湱漷濩火獲眓砉祸禍秮秳获蒦豁貨货邩鈥钬閄霍騞",
+	"ji":     "丮乩亟亼亽伋伎佶偈偮僟兾冀几击刉刏剂剞剤劑勣卙即卽及叽吉咭哜唧喞嗘嘰嚌圾坖垍基塈塉墼妀妓姞姫姬嫉季寂寄屐岌峜嵆嵇嵴嶯己幾庴彐彑彶徛忌忣急悸惎愱懻戟戢技挤掎揤撃撠擊擠擮敧旡既旣暨暩曁朞机极枅梞棘楫極槉槣機橶檕檝檵櫅殛毄汲泲洎济済湒漃漈潗激濈濟瀱焏犄犱狤玑璣畸畿疾痵瘠癠皀皍矶磯祭禝禨积稘稩稷稽穄穊積穖穧笄笈筓箕箿簊籍紀紒級継緝績繋繼级纪继绩缉罽羁羇羈耤耭肌脊膌臮艥芨芰茍茤荠葪蒺蓟蔇蕀蕺薊薺蘎蘮蘻虀虮螏蟣裚褀襀襋覉覊覬觊觙觭計記誋諅譏譤计讥记诘谻賫賷赍趌跡跻跽踖蹐蹟躋躸轚辑迹郆鄿鈘銈銡錤鍓鏶鐖鑇鑙钑际際隮集雞雦雧霁霵霽鞿韲飢饑饥驥骥髻鬾魕魢鯚鰶鰿鱀鱭鱾鲚鲫鳮鵋鶏鶺鷄鷑鸄鸡鹡麂齌齎齏齑",
+	"jia":    "乫仮价伽佳假傢價加叚唊嘉圿埉夹夾婽嫁家岬幏徦忦恝戛戞扴抸拁斚斝架枷梜椵榎榢槚檟毠泇浃浹犌猳玾珈甲痂瘕稼笳耞胛腵荚莢葭蛱蛺袈袷裌豭貑賈贾跏跲迦郏郟鉀鉫鉿鋏鎵钾铗镓鞂頬頰颊餄駕驾鴶鵊麚",
+	"jian":   "件俭俴倹偂健僭儉兼冿减剑剣剪剱劍劎劗囏囝坚堅堿奸姦姧寋尖幵建弿徤惤戔戩戬拣挸捡揀揃搛撿旔暕枧柬栫梘检検椷椾楗榗槛樫檢櫼歼殲毽洊涧渐減湔湕溅漸澗瀐瀸瀽煎熞熸牋牮犍猏玪珔瑊瑐监監睑睷瞼硷碊碱礆礛笕笺筧简箋箭篯簡籛絸緘縑繭缄缣翦肩腱臶舰艰艱茧荐菅菺葌葥蒹蔪蕑蕳藆虃蠒袸裥襇襉襺見覸见詃謇謭譾谏谫豜豣賎贱趼践蹇釼鉴鐗鐧鑯锏键間间鞬鞯韀韉餰饯馢鬋鰎鰹鲣鳒鳽鵳鶼鹣鹸鹻鹼麉",
+	"jiang":  "傋僵勥匞匠壃夅奖奨奬姜将將嵹弜弶彊摪摾桨槳橿殭江洚浆滰漿犟獎畕畺疅疆礓糡糨絳繮绛缰翞耩膙茳葁蒋蔣薑螀螿袶講謽讲豇酱醤醬降韁顜鱂鳉",
+	"jiao":   "交佼侥僥僬儌剿劋叫呌嘂嘄嘦嚼姣娇嬌孂峤峧嶕嶣徺徼恔憍憿挍挢捁搅摷撟撹攪敎教敫敽敿斠晈暞曒椒浇湫湬滘漖澆灚烄焦煍燋燞狡珓璬皎皦矫矯礁穚窌窖簥絞繳绞缴胶脚腳膠膲臫茭茮蕉虠蛟蟜蟭角訆譑賋跤踋較轇轿较郊酵鉸鐎铰隦餃饺驕骄鮫鱎鲛鵁鷍鷦鷮鹪",
+	"jie":    "丯介借倢偼傑刦刧刼劫劼卩卪吤喈喼嗟堦堺姐婕媎媘嫅孑尐屆届岊岕崨嵥巀幯庎徣悈戒截拮捷接掲揭擑昅杰桀桔椄楐楬楶榤檞櫭毑洁湝滐潔煯犗玠琾界畍疌疖疥痎癤皆睫砎碣秸稭竭節結絜结羯脻节芥莭菨蓵藉蚧蛶蜐蝍蝔蠘蠞蠽街衱衸袺褯解觧訐詰誡誱謯讦诫踕躤迼鉣鍻鎅阶階鞊颉飷骱魝魪鮚鲒鶛",
+	"jin":    "仅今伒侭僅儘兓劤劲勁卺厪唫埐堇堻妗嫤寖尽嶜巹巾廑惍搢斤晉晋枃槿津浕浸溍漌烬珒琎瑾盡矜祲禁筋紟紧緊缙荕荩菫蓳衿襟觔謹谨赆近进進金釿錦钅锦靳饉馑鹶黅",
+	"jing":   "丼井京亰俓倞傹儆兢净凈刭剄坓境妌婙婛婧宑幜弪弳径徑惊憬憼敬旌旍景晶暻曔桱梷汫汬泾浄涇淨濪瀞燛猄獍璟璥痉痙睛秔稉穽竞竟竧竫競竸粳精経經经聙肼胫脛腈茎荆荊莖菁葏蟼誩警踁迳逕鏡镜阱靓靖静靚靜頚頸颈驚鯨鲸鵛鶁鶄麖麠鼱",
+	"jiong":  "浻炯烱煚窘逈",
+	"jiu":    "久乆九乣倃匓匛厩咎啾奺媨就廄廐捄揂揪揫摎救旧朻柩柾桕樛灸牞玖疚究糺糾紤纠臼舅舏萛赳酒镹阄韭韮鬏鬮鳩鸠",
+	"ju":     "举乬侷俱倨倶僪具冣剧劇勮句咀啹埧埾壉姖娵婅婮寠局居屦屨岠崌巈巨巪弆怇怐怚惧愳懅懼拒拘拠挙挶据掬據擧昛梮椇椈椐榉榘橘檋櫸欅歫毩毱沮泃泦洰涺淗湨澽炬焗犋犑狊狙琚疽痀眗矩砠秬窭窶筥簴粔粷罝耟聚聥腒舉艍苣苴莒菊菹蒟蘜虡蚷蜛袓裾詎諊讵豦貗趄趜跔跙距跼踘踙踞踽蹫躆躹輂遽邭郹醵鉅鋦鋸鐻钜锔锯閰陱雎鞠鞫颶飓駏駒駶驧驹鮈鮔鴡鵙鵴鶋鶪鼳齟龃",
+	"juan":   "倦劵勌勬卷呟埍奆娟巻帣捐捲桊涓淃焆狷瓹眷绢脧臇菤蠲裐錈鎸鐫锩镌隽鵑鹃",
+	"jue":    "亅倔傕决刔劂勪匷厥噱孒孓屩屫崛嶥弡彏憠憰戄抉挗捔掘撅撧攫斍桷橛橜欮殌氒決泬焳熦爑爝爴爵獗玦玨珏瑴疦瘚矍砄絕絶绝臄芵蕝蕨虳蚗蟨蟩覐覚覺觉觖觼訣譎诀谲赽趉趹蹶蹷蹻逫鈌鐍鐝镢駃鴂鴃鶌",
+	"jun":    "俊军君呁均埈姰峻捃晙桾棞汮浚焌珺畯皲皸皹碅竣莙菌蚐袀覠軍郡鈞銁銞鍕钧陖馂骏鮶鲪麇麏麕",
+	"ka":     "卡咖咯喀擖衉",
+	"kai":    "凯凱剀剴嘅垲塏奒嵦开恺愷慨揩楷蒈鐦铠锎開闿",
+	"kan":    "侃偘冚刊勘坎埳堪塪嵁惂戡栞檻欿歁看砍竷莰輡轗顑龕龛",
+	"kang":   "亢伉匟囥嫝嵻康慷扛抗摃槺漮炕犺穅糠躿邟鏮鱇",
+	"kao":    "拷栲洘烤燺犒稁考銬铐靠鲓",
+	"ke":     "克刻剋勀勊可咳嗑坷壳娔客尅岢嵑嶱恪揢搕敤柯棵榼樖殼渇渴炣牁犐珂疴瞌砢磕礍礚科稞窠翗胢苛萪薖蝌课趷軻轲醘鈳錒顆颏颗髁",
+	"ken":    "啃垦恳肯肻",
+	"keng":   "吭坑",
+	"kong":   "倥埪孔崆恐悾控涳硿空箜錓鵼",
+	"kou":    "冦剾劶口叩宼寇彄扣抠摳敂眍瞘芤",
+	"ku":     "俈哭喾圐堀崫库庫枯桍焅狜瘔秙窟絝绔胐苦袴裤趶跍酷骷鮬",
+	"kua":    "侉咵垮夸姱挎胯誇跨銙",
+	"kuai":   "侩哙块塊快狯筷脍郐",
+	"kuan":   "宽寛寬欵款臗髋髖",
+	"kuang":  "儣况劻匡匩卝哐圹夼岲忹恇懭抂旷昿框況洭狂眖眶矿硄筐纩誆誑诓诳贶軖軭邝邼鵟",
+	"kui":    "亏傀刲匮喟喹夔奎媿尯岿巋巙悝愦愧戣揆晆暌楏楑櫆欳溃煃犪盔睽窥窺聧腃葵蒉藈蘬蘷虁虧蝰跬蹞躨逵鄈鍨鍷闚隗頄頍頯顝馈馗騤骙魁",
+	"kun":    "困坤堃壸壼婫崐崑悃捆昆晜梱焜猑琨瑻硱祵稇稛綑菎蜫裈裍裩褌貇醌錕锟閫閸阃騉髠髡髨鯤鲲鵾鶤鹍齫",
+	"kuo":    "廓扩拡括挄桰筈萿葀蛞阔",
+	"la":     "剌啦喇垃拉揦揧搚攋旯柆楋爉瓎瘌砬磖翋腊臈臘菈藞蜡蝋蝲蠟辢辣邋鑞镴鬎鯻",
+	"lai":    "來俫倈唻婡崃崍庲徕徠来梾棶涞淶猍琜睐睞筙箂莱萊赉赖逨郲錸铼騋鯠鶆麳",
+	"lan":    "儖兰厱囒囕壈婪嬾孄孏岚嵐幱惏懒懢懶拦揽擥攔攬斓斕栏榄欄欖欗浨滥漤澜瀾灆灠灡烂燣燷璼礷篮籃籣繿纜缆罱葻蓝藍蘭褴襕襤襴覧覽览譋讕谰躝醂钄镧闌阑韊顲",
+	"lang":   "埌塱嫏崀廊斏朖朗朤桹榔樃欴浪烺狼琅瑯硠稂筤艆蓈蓢蜋螂誏躴郎郞鋃鎯锒阆駺",
+	"lao":    "佬僗劳労勞咾哰唠嘮姥崂嶗恅憥捞撈栳橑浶涝烙牢狫痨癆磱窂簩老耂耢荖蟧轑酪醪銠鐒铑铹顟髝",
+	"le":     "乐勒",
+	"lei":    "傫儡儽厽垒壘壨嫘擂樏檑櫐櫑欙泪洡涙淚灅瓃畾癗磊磥礌礧礨类累絫縲纍纝缧罍羸耒肋腂蔂蕌蕾藟蘲蘽虆蠝誄讄诔轠鐳鑘鑸镭雷靁鸓鼺",
+	"leng":   "冷棱楞碐稜薐輘",
+	"li":     "丽例俐俚俪傈儮儷兣凓利剓剺劙力励勵历厉厘厤厯厲吏呖哩唎唳喱嚟嚦囄囇坜塛壢娌娳婯嫠孋孷屴岦峛峢峲巁廲悡悧慄戾搮攊攡攦攭斄暦曆曞朸李枥栃栎栗栛梨梩梸棃棙樆櫔櫟櫪欐欚歴歷沥沴浬涖溧漓澧濿瀝灕爄爏犁犂犡狸猁珕理琍瑮璃瓅瓈瓑瓥疠疬痢癘癧皪盠盭睝矋砅砺砾磿礪礫礰礼禮禲离秝穲立笠筣篥篱籬粒粚粝粴糎糲綟縭纚缡罹脷艃苈苙茘荔荲莅莉菞蒚蒞蓠蔾藜藶蘺蚸蛎蛠蜊蜧蝷蟍蟸蠇蠡蠣蠫裏褵觻詈謧讈豊貍赲跞躒轢轣轹逦邌邐郦酈醨醴里釐鉝鋫鋰錅鎘鏫鑗锂隶隷隸離雳靂靋騹驪骊鬁鯉鯬鱧鱱鱳鱺鲡鲤鳢鳨鴗鵹鷅鸝鹂麗麜黎黧",
+	"lia":    "俩",
+	"lian":   "亷僆劆匲匳嗹噒堜奩媡嫾嬚帘廉怜恋慩憐摙敛斂梿槤櫣殓浰涟湅溓漣濂濓炼熑燫琏璉磏簾籢籨縺练羷翴联聫聮聯脸臁臉莲萰蓮蔹薕蘞螊蠊裢裣褳襝覝謰蹥连連鄻鎌鐮链镰鬑鰱鲢",
+	"liang":  "両两亮俍兩凉哴唡啢喨墚悢掚晾梁椋樑涼湸粮粱糧綡緉脼良蜽裲谅踉輬辆辌量魉魎",
+	"liao":   "了僚叾嘹嫽寥寮尞尥尦屪嵺嶚嶛廖廫憀憭撂撩敹料暸曢漻潦炓燎爒獠璙疗療瞭窷簝繚缭聊膋膫蓼藔蟟豂賿蹘蹽辽遼鄝釕鐐钌镣镽飉髎鷯鹩",
+	"lie":    "冽列劣劽哷埒埓姴挒捩栵洌浖烈猎脟茢蛚裂迾",
+	"lin":    "临亃冧凛凜厸吝啉壣崊嶙廩廪恡悋懍懔拎撛斴晽暽林檁檩淋潾澟瀶燐獜琳璘痳癛癝瞵矝碄磷箖粦粼繗翷臨菻赁轔辚遴邻鄰鏻隣霖驎鱗鳞麐麟",
+	"ling":   "令伶凌另呤囹坽夌姈婈孁岭岺嶺彾掕昤朎柃棂櫺欞泠淩澪灵炩燯爧狑玲琌瓴皊砱祾秢竛笭紷綾绫羚翎聆舲苓菱蔆蕶蘦蛉衑袊裬詅跉軨酃醽鈴錂铃閝阾陵零霊霛霝靈領领駖魿鯪鲮鴒鸰鹷麢齡齢龄龗",
+	"liu":    "六刘劉嚠媹嬼嵧懰旈旒柳栁桺榴橊橮沠流浏溜瀏熘熮珋琉瑠瑬璢畄留畱疁瘤癅硫磂綹绺罶羀蒥蓅藰蟉裗蹓遛鉚鋶鎏鎦鏐锍镏镠飀飅飗飹馏駠駵騮驑骝鰡鶹鹠麍",
+	"long":   "儱咙哢嚨垄垅壟壠屸嶐巃巄徿拢挵攏昽曨朧栊梇槞櫳泷湰滝漋瀧爖珑瓏癃眬矓砻礱礲窿竉竜笼篢篭簼籠聋聾胧茏蕯蘢蠪蠬襱豅贚躘鏧鑨陇隆隴霳靇驡鸗龍龒龓龙",
+	"lou":    "偻僂塿娄婁屚嵝嶁廔慺搂摟楼樓溇漊漏熡甊篓簍耧耬艛蒌蔞蝼螻謱軁遱陋鞻髅髏",
+	"lu":     "侓僇剹勎勠卢卤嚕嚧圥坴垆塶塷壚娽峍庐廘廬彔录戮掳摝擄擼攎曥栌椂樐樚橹櫓櫚櫨氌泸淕淥渌滷漉潞瀂瀘炉熝爐獹玈琭璐璷瓐甪盝盧睩矑硉硵碌磠祿禄稑穋箓簏簬簶籚粶纑罏胪膔臚舻艣艪艫芦菉蓾蔍蕗蘆虏虜螰蠦觮賂赂趢路踛蹗轆轤轳辂辘逯醁錄録錴鏀鏕鏴鐪鑥鑪镥陆陸露顱颅騄髗魯魲鯥鱸鲁鲈鵦鵱鸕鸬鹭鹵鹿麓黸",
+	"lv":     "侣侶儢吕呂垏寽屡屢履嵂律挔捋捛旅梠榈氀氯滤率祣稆穞穭絽縷绿缕膂膐膢葎藘虑褛褸郘鋁铝閭闾馿驢驴鷜",
+	"luan":   "乱卵圝圞奱孌孪孿峦巒挛攣曫栾欒滦灓灤癴癵羉脔臠虊銮鑾鵉鸞鸾",
+	"lue":    "掠略",
+	"lun":    "仑伦侖倫囵圇埨婨崘崙惀抡掄棆沦淪碖稐綸纶耣腀菕蜦论踚輪轮錀陯鯩",
+	"luo":    "倮儸剆啰囉峈攞曪椤欏泺洛洜猡玀珞瘰癳硦笿箩籮絡络罖罗羅脶腡臝荦萝落蓏蘿螺蠃裸覙覶覼躶逻邏鏍鑼锣镙頱饠騾驘骆骡鸁",
+	"ma":     "亇傌吗唛嗎嘛嘜妈媽嬤嬷孖杩榪溤犘犸獁玛瑪痲睰码碼礣祃禡罵蔴蚂螞蟇遤鎷閁馬駡马骂鬕鰢鷌麻",
+	"mai":    "买佅劢卖嘪埋売脉荬蕒薶買迈霾鷶麦",
+	"man":    "僈墁屘幔慢慲摱曼樠満满滿漫獌睌瞒瞞矕缦蔄蔓蛮螨蟎蠻襔謾谩鄤鏋鞔顢饅馒鬗鬘鰻鳗",
+	"mang":   "哤娏尨庬忙恾杗杧氓汒浝牻狵痝盲硭笀芒茫莽蛖釯鋩铓駹",
+	"mao":    "乮兞冃冇冐冒卯堥夘媢嫹峁帽愗戼旄昴暓枆柕楙毛毷氂泖渵牦犛猫瑁皃眊瞀矛笷罞耄芼茂茅茆萺蓩蝥蟊袤覒貌貓貿贸軞鄚酕錨铆锚髦髳鶜",
+	"me":     "么",
+	"mei":    "凂堳塺妹娒媄媒媚媺嬍寐嵄嵋徾抺挴攗旀昧枚栂梅楣楳槑毎每没沬浼渼湄湈煤燘猸玫珻瑂眉眛睂矀祙禖穈美脄脢腜苺莓葿蘪袂郿酶鋂鎂鎇镁镅霉鶥鹛黣黴",
+	"men":    "亹们悶懑懣扪捫暪焖燜玧璊菛虋鍆钔門閅门闷",
+	"meng":   "儚勐孟幪懜懞懵曚朦梦橗檬氋濛猛獴瓾甍盟瞢矇矒礞艋艨萌萠蒙蕄蘉蜢蝱蠓鄳鄸錳锰霿靀顭饛鯍鯭鸏鹲鼆",
+	"mi":     "侎冖冞嘧塓孊宓宻密峚幂幎弥弭彌戂擟攠敉榓汨沕沵泌洣淧淿渳滵漞濔瀰灖熐爢猕獼瓕眫眯瞇祕祢禰秘米糜糸縻罙羋脒芈葞蒾蔝蔤蘼蜜覓覔覛觅詸謎谜谧迷醚醾醿釄銤镾靡鸍麊麋麛",
+	"mian":   "丏偭免冕勉勔喕娩婂媔嬵愐棉檰櫋汅沔渑湎澠眄眠矈矊矏絻綿緜緬绵缅腼臱葂蝒面靣鮸麫黽黾",
+	"miao":   "妙媌庙描杪淼渺眇瞄秒篎緢緲缈苗藐邈鱙鶓鹋",
+	"mie":    "搣滅灭烕蔑覕",
+	"min":    "冺刡勄姄岷崏忞怋悯抿捪敃敏敯旻旼民泯珉琘瑉痻皿盿砇碈緍緡缗罠苠鈱錉鍲闵闽鴖",
+	"ming":   "佲冥凕名命姳嫇慏明暝朙榠洺溟猽眀眳瞑茗蓂螟覭鄍酩銘铭鳴鸣",
+	"miu":    "谬",
+	"mo":     "劘劰唜嗼嚤嚩嚰圽塻墨妺嫫嫼寞帓帞懡抹摩摸摹擵昩暯末枺模橅歾歿殁沫湐漠獏瘼皌眜眽眿瞐瞙砞磨秣粖糢絈膜茉莈莫蓦蘑蛨謨谟貃貊銆镆陌靺饃饝馍髍魔魩麽默黙",
+	"mou":    "侔劺恈某洠牟眸瞴繆缪蛑謀谋踎鉾鍪鴾麰",
+	"mu":     "亩仫凩募坶墓姆峔幕幙慔慕拇暮木朰楘母毣沐炑牡牧牳狇畆畒畝畞畮目睦砪穆縸胟艒苜莯蚞踇鉧鉬钼雮霂鞪",
+	"na":     "乸呐哪嗱妠娜拿挐纳肭衲那鎿钠镎雫",
+	"nai":    "乃倷奈奶妳嬭廼柰氖疓耏耐艿迺釢",
+	"nan":    "侽南娚枏枬柟男畘莮难",
+	"nang":   "囊",
+	"nao":    "匘垴堖夒嫐峱嶩巎恼悩惱憹挠撓淖猱獶獿瑙硇碙碯脑腦蛲蟯詉譊鐃铙闹",
+	"ne":     "呢",
+	"nei":    "內内脮腇餒馁鮾鯘",
+	"nen":    "嫩",
+	"neng":   "能",
+	"ni":     "伱伲你倪儗儞匿坭埿堄妮婗嫟孴尼屔屰怩惄愵抳拟擬旎昵晲柅棿檷氼泥淣溺狔猊眤睨秜籾聣聻胒腝腻臡苨薿蚭蜺觬貎跜輗迡逆郳鈮铌隬霓馜鯓鯢鲵麑齯",
+	"nian":   "卄年廿念拈捻撚撵攆涊淰焾碾秊秥簐蔫跈蹍蹨躎輦辇鮎鯰鲇鲶黏",
+	"niang":  "娘酿醸釀",
+	"niao":   "嫋嬝嬲尿樢茑蔦袅裊褭鳥鸟",
+	"nie":    "啮喦嗫噛嚙圼孼孽嵲嶭帇惗捏揑摰敜枿槷涅湼痆篞聂聶臬臲苶菍踂踗蹑錜鎳镊镍闑陧隉颞",
+	"nin":    "您",
+	"ning":   "佞侫儜凝咛嚀嬣宁寍寕寗寜寧拧擰柠橣檸泞狞獰甯矃聍聹苧薴鑏鬡鸋",
+	"niu":    "忸扭汼炄牛狃纽钮",
+	"nong":   "侬儂农哝噥弄檂欁浓濃燶禯秾穠繷脓膿蕽襛農辳醲",
+	"nu":     "伮努奴孥弩怒砮笯胬駑驽",
+	"nv":     "女",
+	"nuan":   "暖",
+	"nue":    "疟虐",
+	"nuo":    "傩儺喏愞懦懧挪掿搦搻梛榒橠稬穤糑糥糯諾诺蹃逽锘",
+	"o":      "哦",
+	"ou":     "偶吘呕嘔塸櫙欧歐殴毆沤漚熰瓯甌耦腢膒蕅藕謳鏂鴎鷗鸥齵",
+	"pa":     "啪帊帕怕掱杷潖爬琶筢舥葩趴",
+	"pai":    "俳哌廹徘拍排棑派湃牌犤猅簰簲輫",
+	"pan":    "冸判叛媻幋拚搫攀槃沜泮洀潘瀊炍爿牉畔盘盤盼磐磻縏蒰蟠跘蹒蹣鎜鞶",
+	"pang":   "乓厐厖嗙嫎庞徬旁沗滂炐耪肨胖胮膖舽螃覫逄雱霶鳑龎龐",
+	"pao":    "刨匏咆垉奅庖抛拋泡炮炰爮狍脬袍跑軳鞄麃麅",
+	"pei":    "伂佩俖呸培姵帔怌斾旆柸毰沛浿珮肧胚衃裴裵賠赔配醅锫阫陪駍",
+	"pen":    "喷噴歕瓫盆",
+	"peng":   "倗剻嘭堋塳弸彭恲憉抨挷捧掽朋梈棚椖椪槰樥淎漰澎烹熢皏砰硑硼碰磞稝竼篣篷纄膨芃莑蓬蟚蟛踫軯輣錋鑝閛韸韼騯髼鬅鬔鵬鹏",
+	"pi":     "仳僻劈匹啤噼噽嚊嚭圮坯埤壀媲嫓屁岯崥庀悂憵批披抷揊擗旇朇枇毗毘毞淠渒潎澼炋焷狉狓琵甓疈疋疲痞癖皮睥砒磇礔礕秛秠稫篺紕纰罴羆翍耚肶脴脾腗膍芘苉蚍蚽蚾蜱螷諀譬豼豾貔邳郫釽鈈鈚鈹鉟銔銢錍铍阰陴霹駓髬魮魾鮍鲏鴄鵧鼙",
+	"pian":   "偏媥楄楩片犏篇翩胼腁覑諚諞谝貵賆跰蹁鍂駢騈骈骗骿鶣",
+	"piao":   "僄勡嘌嫖彯徱旚殍漂犥瓢皫瞟票竂篻縹翲薸螵醥闝顠飃飄飘魒",
+	"pie":    "撆撇暼瞥",
+	"pin":    "品嚬娦嫔嬪拼榀汖牝獱玭琕矉礗穦聘薲蠙貧贫頻顰频颦馪驞",
+	"ping":   "乒俜凭呯坪娉屏屛帡帲平枰泙洴涄淜玶瓶甹砯竮聠胓艵苹荓萍评郱頩",
+	"po":     "剖叵嘙坡婆尀岶敀昢桲櫇泼洦溌潑炇烞珀皤破砶笸粕蒪蔢謈迫鄱醗釙鉕鏺钷頗颇駊魄",
+	"pu":     "仆僕匍噗圃圤埔墣扑撲擈攴普曝朴樸檏氆浦溥潽濮瀑烳獛璞瞨穙纀舖舗莆菐菩葡蒱蒲襥諩譜谱蹼酺鋪鏷鐠铺镤镨陠鯆",
+	"qi":     "七乞亓亝企俟倛僛其凄剘启呇呮咠唘唭啓啔啟嘁噐器圻埼夡奇契妻娸婍屺岂岐岓崎帺弃忔忯悽愭慼慽憇憩懠戚掑摖攲斉斊旂旗晵暣期杞柒栔栖桤桼棄棊棋棨棲榿槭檱櫀欫欺歧气気氣汔汽沏泣淇淒湆湇漆濝炁猉玂玘琦琪璂甈畁畦疧盀盵矵砌碁碕碛碶磜磧磩祁祇祈祺禥竒粸綥綦綨綮綺緀纃绮缼罊耆肵脐臍艩芑芞芪萁萋萕葺蕲藄蘄蚑蚔蚚蛣蛴蜝蜞蟿蠐訖諆諬諿讫豈起跂踑蹊軝迄迉邔郪釮錡鏚锜闙霋頎颀騎騏骐骑鬐鬿魌鯕鰭鲯鳍鵸鶀鶈麒麡鼜齊齐",
+	"qia":    "冾圶帢恰拤掐洽葜跒酠",
+	"qian":   "乾仟仱佥俔倩傔僉兛凵刋前千嗛圱圲堑塹墘奷婜媊孅孯岍岒嵌嵰忴悓悭愆慊慳扦扲拑拪掔掮揵搴撁攐攑攓杄棈椠榩槏橬檶櫏欠欦歉歬汘汧浅淺潛潜濳灊牵牽瓩皘签箝箞簽籤粁繾缱羬肷脥膁臤芊芡茜茾蒨蕁虔蚈蜸褰諐謙譴谦谴谸軡迁遣遷釺鈆鈐鉗鉛銭錢钎钤钱钳铅阡雃靬韆顅騚騝騫骞鬜鬝鰬鵮鹐黔黚",
+	"qiang":  "丬呛嗆墙墻嫱嬙嶈廧強强戕戗戧抢斨枪椌槍樯檣溬漒牄牆猐玱瑲篬羌羗羫腔艢蔃蔷薔蘠蜣謒跄蹌蹡錆鎗鏘锖锵镪",
+	"qiao":   "乔侨俏僑僺劁喬嘺墝墽嫶峭嵪巧帩幧悄愀憔撬撽敲桥樵橇橋殻毃燆癄瞧硗硚磽礄窍繑缲翘荍荞菬蕎藮誚诮谯趫趬跷踍蹺郻鄡鄥釥鍫鍬鐈鐰锹陗鞒鞘鞽頝顦骹髚髜",
+	"qie":    "且切匧妾怯窃茄郄",
+	"qin":    "亲侵勤吢吣嗪噙坅埁媇嫀寑寝寢寴嵚嶔庈慬懃懄抋捦擒斳昑梫檎欽沁溱澿珡琴琹瘽禽秦笉綅耹芩芹菦菳蚙螓螼蠄衾親誛赾鈙鋟钦锓雂靲顉駸骎鬵鮼鳹鵭",
+	"qing":   "倾傾剠勍卿圊埥夝寈庆庼廎情擎擏晴暒棾樈檠檾殑氢氫氰淸清漀甠苘葝蜻請请輕轻郬鑋青頃顷鲭黥",
+	"qiong":  "惸桏焪焭琼穷穹笻筇茕赹",
+	"qiu":    "丘丠俅叴唒囚坵媝恘扏梂楸殏毬求汓泅浗犰玌球秋秌穐篍紌緧肍莍萩蓲虬虯蚯蝵蟗蠤觓訄訅趥逎逑邱酋釓釚鞦鞧鰌鰍鳅鶖鹙龝",
+	"qu":     "伹佉佢刞劬匤区區厺去取呿唟坥娶屈岖岨岴嶇忂憈戵抾敺斪曲朐欋氍浀淭渠灈璖璩癯瞿磲祛竘竬筁籧粬紶絇翑耝胊胠臞菃葋蕖蘧蛆蛐蝺螶蟝蠷蠼衢袪覰覻觑詓詘誳诎趋趣趨躣躯軀軥鑺镼阒阹駆駈驅驱髷魼鰸鱋鴝鸜鸲麯麴麹黢鼩齲龋",
+	"quan":   "佺全券劝啳圈圏埢姾婘孉巏惓拳搼权棬権權汱泉洤湶烇牷犈犬瑔畎痊硂筌絟綣縓绻荃葲虇蜷蠸觠詮诠跧踡輇辁醛銓鐉铨顴颧駩騡鬈鰁鳈齤",
+	"que":    "却卻埆塙墧寉崅悫愨慤搉榷灍炔燩琷瘸皵硞确碏確碻礐礭缺蒛趞闋闕阕阙雀鹊",
+	"qun":    "羣群裙",
+	"ran":    "冄冉嘫姌染然燃繎苒髥髯",
+	"rang":   "嚷壌壤攘爙瓤穰纕让躟鬤",
+	"rao":    "嬈扰擾桡橈绕蕘襓隢饒饶",
+	"re":     "惹热",
+	"ren":    "人亻仁仞仭任刃刄壬妊屻岃忈忍忎扨朲杒栠栣棯牣秂秹稔纫纴肕芢荏荵认讱轫鈓銋韧魜鵀",
+	"reng":   "仍扔",
+	"ri":     "日",
+	"rong":   "冗媶嫆嬫容嵘嵤嶸巆戎搈搑曧栄榕榮榵毧溶瀜烿熔爃狨瑢穁絨縙绒羢肜茙茸荣蓉蝾融螎蠑褣鎔镕駥髶",
+	"rou":    "媃揉柔楺渘煣瑈瓇粈糅肉葇蝚蹂輮鍒鞣韖騥鰇鶔",
+	"ru":     "乳侞儒入嗕嚅如媷嬬孺帤擩曘桇汝洳渪溽濡燸筎缛肗茹蒘蓐蕠薷蝡蠕袽褥襦辱鄏醹銣铷顬颥鱬鴑鴽",
+	"ruan":   "朊软阮",
+	"rui":    "枘橤汭瑞繠芮蕊蕋蘂蘃蚋锐",
+	"run":    "润闰",
+	"ruo":    "偌弱若",
+	"sa":     "卅撒泧洒潵灑脎萨訯躠靸飒",
+	"sai":    "嗮噻塞毸腮赛顋鰓鳃",
+	"san":    "三仐伞俕傘叁帴弎悷散毵毿犙糁糂糝糣糤繖鏒鏾霰饊馓鬖",
+	"sang":   "丧嗓搡桑磉褬鎟顙颡",
+	"sao":    "嫂扫掃搔溞繅缫臊騒騷骚鰠鱢鳋",
+	"se":     "啬嗇栜歮洓涩琗瑟色铯雭",
+	"sen":    "森",
+	"seng":   "僧",
+	"sha":    "乷倽傻儍刹剎唦唼啑啥杀桬榝樧殺毮沙煞猀痧砂硰粆紗纱莎蔱裟鎩铩魦鯊鯋鲨",
+	"shai":   "晒筛",
+	"shan":   "傓僐删刪剡剼善嘇埏墠墡姍姗嬗山幓彡扇挻掞搧擅晱杉柵樿檆歚汕潬潸澘煔煽熌狦珊疝痁睒磰笘縿缮羴羶脠膳膻舢芟苫衫覢訕謆讪赡赸跚軕邖鄯釤銏钐閃闪陕陝骟鯅",
+	"shang":  "丄上仩伤傷商垧墒尙尚恦慯扄晌殇殤滳漡熵緔绱蔏螪裳觞觴謪賞贘赏鑜鞝鬺",
+	"shao":   "劭勺卲哨少捎旓柖梢烧焼燒玿稍竰筲绍艄芍苕莦蛸輎邵韶颵髾鮹",
+	"she":    "佘厍厙奢射弽慑捨摂摄檨涉涻渉猞畬畲社舌舍虵蛇蛥設设賒賖赊赦輋",
+	"shen":   "伸侁侺兟呻哂妽姺娠婶嬸审宷審屾峷弞愼慎扟敒昚曋曑柛棽氠沈涁深渖渗瀋燊珅甚甡甧申眒眘瞫矤矧砷神祳穼籶籸紳绅肾胂脤腎莘葠蓡蔘薓裑覾訠訷詵諗讅诜谂谉身邥頣頥駪魫鯵鰺鲹鵢",
+	"sheng":  "偗剩剰升呏圣声憴斘昇晠栍殅泩渻湦焺牲狌珄生甥盛省眚笙繩绳聲胜苼譝鉎阩陞陹鵿鼪",
+	"shi":    "世丗乨乭亊事什仕佦使侍兘冟势勢十卋叓史呞呩嗜噬埘塒士失奭始姼媞嬕实実室宩寔實尸屍屎峕崼市师師式弑弒徥忕恀恃戺拭拾揓施时旹是昰時枾柹柿栻榯氏浉湜湤湿溡溮溼澨濕炻烒狮獅瑡眂眎眡睗矢石示礻祏竍笶筮簭絁舐舓莳葹蒒蒔蓍虱蚀蝕蝨螫褷襫襹視视觢試詩誓諟諡謚识试诗谥豉豕貰贳軾轼辻适逝遈適遾邿釈释釋釶鈟鈰鉂鉃鉇鉈鉐鉽銴鍦铈食飠飾餙餝饣饰駛驶鯴鰣鰤鲥鲺鳲鳾鶳鸤鼫鼭",
+	"shou":   "兽受售垨壽夀守寿手授收涭狩痩瘦绶艏首",
+	"shu":    "书侸倏倐儵凁叔咰塾墅姝婌孰尌尗属屬庶庻怷恕戍抒捒掓摅攄数暏暑曙書朮术束杸枢树梳樞橾殊殳毹沭淑漱潻焂熟瑹璹疎疏癙秫竖竪紓絉綀纾署腧舒荗菽蒁蔬薥薯藷蜀術裋襡襩贖赎跾踈軗輸输述鄃鉥钃陎隃鮛鵨黍鼠鼡",
+	"shua":   "刷唰耍",
+	"shuai":  "帅摔甩衰",
+	"shuan":  "拴栓閂",
+	"shuang": "双孀孇欆爽礵艭雙霜騻驦骦鷞鸘鹴",
+	"shui":   "帨水涗涚睡祱稅税脽裞誰谁",
+	"shun":   "吮橓瞚瞬舜蕣順顺",
+	"shuo":   "哾妁朔欶烁硕說説说铄",
+	"si":     "丝亖伺似佀価兕凘厮司咝嗣嘶噝四姒娰媤孠寺巳廝思撕斯杫柶楒榹死汜泀泗泤洍涘澌燍牭磃祀禗禠私竢笥籭糹絲緦缌罳耜肂肆蕬虒蛳蜤螄蟖蟴覗釲鉰鋖鐁锶颸飔飤饲騦驷鷥鸶鼶",
+	"song":   "倯傱凇娀宋崧嵩嵷庺怂悚愯慫憽松枀柗梥楤檧淞濍硹竦耸聳菘蜙讼诵送鍶颂駷鬆",
+	"sou":    "傁叜叟嗾搜摉摗擞溲獀瞍艘蒐蓃螋醙鎪锼颼颾飕餿馊騪",
+	"su":     "俗傃僳嗉嗽囌塐塑夙嫊宿愫愬憟梀榡樎樕橚櫯殐泝洬涑溯溸潚潥玊珟璛甦碿稣穌窣簌粛粟素縤肃肅膆苏莤蔌藗蘇蘓觫謖诉谡趚蹜速遡遬酥鋉餗驌骕鱐鷫鹔",
+	"suan":   "匴祘笇筭算蒜酸",
+	"sui":    "亗倠哸埣嬘岁嵗檅檖歲歳浽滖澻濉瀡煫熣燧璲瓍眭睟睢砕碎祟禭穂穗綏绥膸荽荾葰虽誶谇賥遀遂隋随隧隨雖鞖髄髓",
+	"sun":    "孙孫损搎槂狲猻笋荪蓀蕵薞飧飱",
+	"suo":    "乺傞唆唢嗍娑惢所摍桫梭琐睃簑簔索縮缩羧莏蓑趖锁髿鮻",
+	"ta":     "亣他嚃塌塔墖她它崉挞搨撻榙榻橽毾涾溚溻澾濌牠狧獭獺祂禢褟誻趿跶踏蹋蹹遝遢錔铊闧闼鰨鳎",
+	"tai":    "儓冭台坮太夳嬯忲态抬擡旲枱檯汰泰炱炲箈籉肽胎臺舦苔菭薹跆邰酞钛颱駘鮐鲐",
+	"tan":    "倓傝僋叹嗿嘆坍坛坦埮墰墵壇壜婒忐怹惔憛憳憻抩探摊擹攤昙曇榃檀毯湠滩潭灘炭燂璮痑痰瘫癱碳磹罈罎舑菼藫袒襢覃談譚譠谈谭貚貪贪郯醈醓醰鉭錟钽锬顃餤",
+	"tang":   "伖倘偒傏傥儻劏唐啺嘡坣堂塘帑戃搪摥曭棠榶樘橖汤淌湯溏漟烫煻爣瑭矘磄禟篖糃糖糛羰耥膅膛蓎薚蝪螗螳赯趟踼蹚躺鄌醣鎕鎲鏜鐋钂铴镋镗闛隚鞺餳餹饄饧鶶鼞",
+	"tao":    "匋咷啕套嫍幍慆掏搯桃梼槄檮洮涛淘滔濤瑫祹絛綯縚縧绦绹萄蜪裪討詜謟讨轁迯逃醄鋾錭陶鞀鞉鞱韜韬飸饀饕駣騊鼗",
+	"te":     "特",
+	"teng":   "儯幐滕漛疼痋縢腾藤誊謄邆駦",
+	"ti":     "体倜偍剃剔厗啼嗁嚏嚔屉崹徲悌悐惕惖惿戻挮掦提揥擿替朑梯楴歒殢洟涕漽瑅瓋碮禵稊籊綈緹绨缇罤苐荑蕛薙蝭裼褅褆謕趧趯踢蹄蹏躰軆迏逖逷遆醍銻鍗锑題题騠骵體髰鬀鮧鮷鯷鳀鴺鵜鶗鶙鷈鷉鷤鹈",
+	"tian":   "倎兲唺塡填天婖屇忝恬悿搷晪殄沺淟添湉琠璳甛甜田畋畑畠痶盷睓磌窴緂胋腆舔菾觍酟鈿闐阗靔靝鷆鷏黇",
+	"tiao":   "嬥宨岧岹挑斢晀朓条條樤眺祒祧窕窱笤粜絩聎脁芀萔蓚蓨蜩覜誂趒跳迢鋚鎥鞗髫鯈鰷鲦齠龆",
+	"tie":    "帖怗聑萜貼贴铁",
+	"ting":   "亭侹停厅厛听圢娗婷嵉庁庭廰廳廷挺桯梃楟榳汀涏渟烃烴烶珽町甼筳綎耓聤聴聼聽脡艇艼莛葶蜓蝏諪邒閮霆鞓鼮",
+	"tong":   "仝佟僮勭同哃嗵峂峝庝彤恸捅晍曈朣桐桶樋橦氃浵潼烔燑犝狪獞痌痛眮瞳砼秱童筒筩粡統綂统膧茼蓪蚒詷赨通酮鉖鉵銅铜餇鮦鲖",
+	"tou":    "亠偷偸头妵婾媮投敨紏緰蘣透鋀鍮钭頭飳骰黈",
+	"tu":     "兎兔凃凸吐唋図图圕圖圗土圡堍堗塗宊屠峹嵞嶀庩廜徒怢悇捈捸揬梌汢涂涋湥潳痜瘏禿秃稌突筡腯荼菟葖蒤跿迌途酴釷鈯鋵鍎钍馟駼鵌鵚鵵鶟鷋鷵鼵",
+	"tuan":   "团湍煓猯貒",
+	"tui":    "侻俀僓娧尵弚推煺穨腿蓷藬蘈蛻蜕褪蹆蹪退隤頹頺頽颓骽魋",
+	"tun":    "吞呑啍噋坉屯忳暾朜涒焞臀芚豘豚軘霕飩饨魨鲀黗",
+	"tuo":    "佗侂咃唾坨堶妥媠嫷岮庹彵托扡拓拕拖挩捝杔柝椭楕槖橐橢毤毻汑沰沱沲涶狏砣砤碢箨籜紽脫脱莌萚蘀袉袥託跅跎迱酡陀陁飥饦馱駄駞騨驒驝驮驼鬌魠鮀鰖鴕鵎鸵鼉鼍鼧",
+	"wa":     "佤咓哇嗗嗢娃娲媧屲挖搲攨洼溛漥瓦瓲畖窊窪聉腽膃蛙袜襪邷韈韤鼃",
+	"wai":    "喎外崴歪竵",
+	"wan":    "万丸倇刓剜卍卐唍埦塆壪妧婉婠完宛岏帵弯彎忨惋抏挽捖捥晚晩晼梚椀汍湾潫灣烷玩琓琬畹皖盌睕碗紈綩綰纨绾翫脕脘腕芄菀萖萬蜿豌貦踠輓鋄鋔頑顽",
+	"wang":   "亡亾仼兦妄尩彺往徃徍忘惘旺暀望朢枉棢汪瀇王盳網网罒罔莣菵蚟蛧蝄誷輞辋迋魍",
+	"wei":    "为伟伪位偉偎偽僞儰卫危厃叞味唯喂喡喴囗围圍圩壝委威娓媁媙媦寪尉尾屗峗峞崣嵔嵬巍帏帷幃徫微惟愄愇慰懀揋揻撱斖暐未桅梶椲椳楲欈沩洈洧浘涠渨渭湋溈溦潍潙潿濰濻瀢炜為烓煒煟煨熭燰爲犚犩猥玮琟瑋璏畏痏痿癓硊硙碨磈磑維緭緯纬维罻胃腲艉芛苇苿荱菋萎葦葨葳蒍蓶蔚蔿薇薳蘤蜲蜼蝛螱衛衞褽覣覹詴諉謂诿谓踓軎违逶違鄬醀鍏鍡闈闱隇隈霺韋韑韙韡韦韪頠颹餧餵骩骪骫魏鮇鮠鮪鰃鰄鲔鳂",
+	"wen":    "刎匁吻呡彣忟抆文桽榅殟温溫炆玟珳琝瑥瘒瘟稳穏穩紊紋纹聞肳脗芠蕰蚉蚊螡蟁豱輼轀辒閺閿闅闦问闻阌雯鞰馼魰鰛鰮鳁鳼鴍鼤",
+	"weng":   "勜嗡塕奣嵡暡滃瓮瞈翁聬蓊螉鎓鶲鹟",
+	"wo":     "仴倭偓卧唩婐媉幄我挝捰捾握撾斡枂楃沃涡涴涹渥渦焥猧硪窝窩肟腛臥莴萵蜗蝸踒",
+	"wu":     "乄乌五仵伆伍侮俉倵儛兀剭务勿午卼吳吴吾呉呜唔啎嗚圬坞塢奦妩娪娬婺嫵寤屋屼岉嵍嵨巫庑廡弙忢忤怃悞悟悮憮戊扤捂摀敄无旿晤杇杌梧橆歍武毋汙汚污洖洿浯溩潕烏焐無熃熓物牾玝珷珸瑦璑甒痦矹碔祦禑窏窹箼粅舞芜芴茣莁蕪蘁蜈螐蟱誈誣誤譕诬误躌迕逜遻邬郚鄔鋈鎢钨铻阢隖雺雾霚霧靰騖骛鯃鰞鴮鵐鵡鶩鷡鹀鹉鹜鼯鼿齀",
+	"xi":     "习係俙傒僖兮凞匸卌卥厀吸呬咥唏唽喜喺嘻噏嚱囍墍壐夕奚媳嬆嬉屃屖屣屭嵠嶍嶲巇希席徆徙徯忚忥怬怸恄恓息悉悕惁惜慀憘憙戏戱戲扱扸捿昔晞晰晳暿曦析枲桸椞椺榽槢樨橀檄欯欷歖氥汐洗浠淅溪滊漇漝潝潟澙烯焁焈焟焬煕熂熄熈熙熹熺熻燨爔牺犀犔犧狶玺琋璽瘜皙盻睎瞦矖矽硒磎磶禊禧稀稧穸窸粞糦系細綌緆縘縰繥繫细绤羲習翕翖肸肹膝舃舄舾莃菥葈葸蒠蒵蓆蓰蕮薂虩蜥螅螇蟋蟢蠵衋袭襲西覀覡覤觋觹觽觿諰謑謵譆谿豀豨豯貕赥赩趇趘蹝躧郋郗郤鄎酅醯釳釸鈢鉨鉩錫鎴鏭鑴铣锡闟阋隙隟隰隵雟霫霼飁餏餼饩饻騱騽驨鬩鰼鱚鳛鵗鸂黖鼷",
+	"xia":    "丅下乤侠俠傄匣厦吓夏峡峽敮暇柙炠烚煆煵狎狭狹珨瑕疜瞎硖硤碬磍祫筪縀縖翈舝舺蕸虾蝦谺赮轄辖遐鍜鎋閕閜陜陿霞颬騢魻鰕鶷黠",
+	"xian":   "仙伣伭佡僊僩僴先冼县咞咸哯唌啣嘕垷壏奾妶姭娊娨娴娹婱嫌嫺嫻嬐宪尟尠岘峴崄嶮幰廯弦忺憪憲憸挦掀搟撊撏攇攕显晛暹杴枮橌櫶毨氙涀涎澖瀗灦烍燅燹狝猃献獫獮獻玁现珗現甉痫癇癎県睍瞯硍礥祆禒秈稴筅箲籼粯糮絃絤綫線縣纎纖纤线缐羡羨胘腺臔臽舷苋苮莧莶薟藓藖蘚蚬蚿蛝蜆衔衘褼襳訮誢誸諴譣豏賢贒贤赻跣跹蹮躚輱酰醎銑銛銜鋧錎鍁鍌铦锨閑閒闲限陥险陷険險韅韯韱顕顯餡馅馦鮮鱻鲜鶱鷳鷴鷼鹇鹹麙麲鼸",
+	"xiang":  "乡享亯佭像勨厢向响啌塂姠嶑巷庠廂忀想晑曏栙橡欀湘珦瓖瓨相祥稥箱絴緗缃缿翔膷芗萫葙薌蚃蠁衖襄詳详象跭郷鄉鄊鄕銄銗鑲镶響項项飨餉饗饟饷香驤骧鮝鯗鱶鲞麘",
+	"xiao":   "俲削効咲哮啸嘋嘐嘵嚣嚻囂婋孝宯宵小崤庨彇憢揱效晓暁曉校梟櫹歊殽毊洨消涍淆潇瀟焇猇獢痚痟皛皢硝硣穘窙笅笑筊筱筿箫篠簘簫綃绡翛肖膮萧萷蕭藃虈虓蟂蟏蟰蠨訤誵謏踃逍郩銷销霄驍髇髐魈鴞鴵鸮",
+	"xie":    "些亵伳偕偞偰僁写冩劦勰协協卨卸嗋噧垥塮奊娎媟寫屑屓屟屧峫嶰廨徢恊愶懈拹挟挾揳携撷擕擷攜斜旪暬械楔榍榭歇泄泻洩渫澥瀉瀣灺炧烲焎熁燮燲爕猲獬瑎祄禼糏紲絏絬綊緤緳繲纈绁缬缷翓胁脅脇膎薢薤藛蝎蝢蟹蠍衺褉褻襭諧謝讗谐谢邂邪鞋鞢鞵韰頡駴龤",
+	"xin":    "伈伩信俽囟妡嬜孞廞心忻惞新昕杺枔欣歆炘盺脪芯薪衅襑訢軐辛邤鈊鋅鐔鑫锌阠馨馫",
+	"xing":   "侀兴刑型垶姓娙幸形性惺擤星曐杏洐滎煋猩瑆皨睲硎箵篂腥荥蛵行觪觲邢郉醒鈃鉶銒鋞鍟钘铏陉陘騂骍鮏鯹",
+	"xiong":  "兄兇凶匈哅忷恟汹洶熊胷胸訩詾讻賯雄",
+	"xiu":    "休俢修咻嗅岫峀庥朽樇滫烋烌珛琇秀糔綇绣羞脙脩臹苬袖貅銝鎀鏅锈飍饈馐髤髹鱃鵂鸺",
+	"xu":     "伵侐俆偦冔勖勗卹叙呴喣嘘噓垿墟壻姁婿媭嬃幁序徐怴恤慉戌揟敍敘旭昫晇暊朂栩楈欰歔殈汿沀洫湑溆烅烼煦珝珬畜盨稰窢糈絮縃繻绪续芧蒣蓄蕦虗虚虛蝑裇訏許訹詡諝譃许诩谞鄦酗醑鑐需須頊须顼驉鬚魆魖",
+	"xuan":   "儇咺喧塇媗嫙宣弲怰悬愃愋懁懸揎旋昍昡晅暄暶梋檈泫漩炫烜煊玄玹琁瑄璇璿痃癣癬眩睻矎禤箮縇绚翧翾萱萲蓒蕿藼蘐蜁蝖蠉諠諼譞谖軒轩选選鋗鍹顈駽",
+	"xue":    "乴壆学學岤峃嶨斈泶澩燢穴茓薛血袕觷踅辥辪雤雪靴鞾鱈鳕鷽鸴",
+	"xun":    "伨侚偱勋勛勲勳卂噀噚埙塤壎壦奞寻尋峋巡巺巽廵徇循恂愻揗攳旬曛杊栒桪槆樳殉殾毥汛洵浔潃潠潯灥焄熏燖燻爋狥獯珣璕畃矄稄窨紃纁臐荀荨蔒蕈薫薰蘍蟳訊訙詢训讯询賐迅迿逊遜鄩醺鑂顨馴駨驯鱏鱘鲟鵕",
+	"ya":     "丫乛亚亜亞伢俹劜厊压厑厓吖呀哑唖啞圔圠圧垭埡堐壓娅婭孲岈崕崖庌庘押挜掗揠枒桠椏氩氬涯漄牙犽猚猰玡琊瑘痖瘂睚砑稏窫笌聐芽蕥蚜衙襾訝讶軋迓錏鐚铔雅鴉鴨鵶鸦鸭齖齾",
+	"yan":    "严乵俨偃偐偣傿儼兖兗匽厌厣厭厳厴咽唁啱喭噞嚴堰塩墕壛壧夵奄妍妟姲姸娫娮嫣嬊嬮孍宴岩崦嵃嵒嵓嶖巌巖巗巘巚延弇彥彦愝懕懨戭扊抁掩揅揜敥昖晏暥曮棪椻椼楌檐檿櫩沇沿淊淹渰渷湮湺溎滟演漹炎烟烻焉焑焔焰焱煙燄燕牪狿猒珚琂琰甗盐眼研砚硏硯硽碞礹筵篶簷綖縯罨胭腌臙艳芫莚菸萒葕蔅虤蜒蝘衍裺褗覎觃言詽讠谚谳躽遃郔郾鄢酓酽醃閆閹閻闫阉阎隁隒雁顏顔顩颜餍験验魇魘鰋鳫鴈鶠鹽麣黡黤黫黬黭黶鼴鼹齞齴龑",
+	"yang":   "仰佒佯傟养劷咉坱垟央姎岟崵崸徉怏恙慃懩扬抰揚攁敭旸昜暘杨柍样楊楧様殃氜氧氱泱洋漾炀炴烊煬珜疡痒瘍癢眏眻禓秧紻羊羏羕胦蛘蝆詇諹軮輰鉠鍚鐊钖阦阳陽雵霷鞅颺飏養駚鰑鴦鴹鸉鸯",
+	"yao":    "仸倄偠傜咬喓嗂垚堯妖姚婹媱宎尧尭岆峣崾嶢嶤徭愮抭揺搖摇暚杳枖柼楆榚榣殀溔烑爻狕猺珧瑤瑶眑祅穾窅窈窑窯窰繇耀肴腰舀苭药葽蓔蘨要訞謠謡谣軺轺遙遥邀邎銚鎐闄顤颻飖餆餚騕鰩鳐鴁鴢鷕鼼齩",
+	"ye":     "业也亱僷冶叶吔啘嘢噎嚈埜堨墷壄夜嶪嶫抴捓掖揶擛擨擪擫晔暍曄曅曗曳曵枼枽椰楪業歋殗液漜潱澲烨燁爗爷皣瞱瞸礏耶腋葉蠮謁谒邺鄓鄴野釾鋣鍱鎁鎑鐷铘靥靨頁页餣饁馌驜鵺鸈",
+	"yi":     "一乁乂义乊乙亄亦亿以仪伇伊伿佁佚佾侇依俋倚偯儀億兿冝凒刈劓劮勚勩匇匜医吚呓呭呹咦咿唈噫囈圛圯坄垼埶埸墿壱壹夁夷奕姨媐嫕嫛嬄嬑嬟宐宜宧寱寲屹峄峓崺嶧嶬嶷已巸帟帠幆庡廙异弈弋弌弬彛彜彝彞役忆怈怡怿恞悒悘悥意憶懌懿扅扆抑拸挹捙掜揖撎攺敡敼斁旑旖易晹暆曀曎杙枍枻柂栘栧栺桋棭椅椸榏槸檍檥檹欥欭欹歝殔殪殹毅毉沂沶泆洂洢浂浥浳湙溢漪潩澺瀷炈焲熠熤熪熼燚燡燱狋猗獈玴珆瑿瓵異疑疫痍痬瘗瘞瘱癔益眙睪瞖矣硛礒祎禕秇移稦穓竩笖箷簃縊繄繶繹绎缢羛羠義羿翊翌翳翼耛耴肄肊肔胰膉臆舣艗艤艺芅苅苡苢萓蓺薏藙藝蘙虉蚁蛜蛡蛦蜴螔螘螠蟻衣衤衪衵袘袣裔裛裿褹襼觺訑訲訳詍詑詒詣誃誼謻譩譯議讉讛议译诒诣谊豙豛豷貤貽賹贀贻跇跠踦軼輢轙轶辷迆迤迻逘逸遗遺邑郼酏醫醳醷釔釴鈠鉯銥鎰鏔鐿钇铱镒镱陭隿霬靾頉頤顊顗颐飴饐饴駅驛驿骮鮨鯣鳦鶂鶃鷁鷊鷖鷧鷾鸃鹝鹢鹥黓黟黳齮齸",
+	"yin":    "乑乚侌冘凐印吟吲喑噖噾嚚因圁垔垠堙夤姻婣婬寅尹峾崟崯嶾廴引愔慇摿斦朄栶檃檭櫽歅殥殷氤泿洇淫淾溵滛濥濦烎犾狺珢璌瘖瘾癮碒磤禋秵筃絪緸苂茵荫荶蔩蔭蘟蚓螾蟫裀訔訚訡誾諲讔赺趛輑鄞鈏鈝銀銦铟银闉阥阴陰陻隂隐隠隱霒霠霪靷鞇音韾飮飲饮駰骃鷣齗龂",
+	"ying":   "偀僌啨営嘤噟嚶塋婴媖媵嫈嬰嬴孆孾巊应廮影応愥應摬撄攍攖映暎朠桜梬楹樱櫻櫿浧渶溁溋滢潁潆濙濚濴瀅瀛瀠瀯瀴煐熒營珱瑛瑩璄璎瓔甇甖瘿癭盁盈矨硬碤礯穎籝籯緓縈纓绬缨罂罃罌膡膺英茔荧莹莺萤营萦萾蓥藀蘡蛍蝇蝧蝿螢蠳褮覮謍譍譻賏贏赢迎郢鍈鎣鐛鑍锳霙鞕韺頴颍颕颖鱦鴬鶑鶧鶯鷪鷹鸎鸚鹦鹰",
+	"yo":     "哟",
+	"yong":   "佣俑傛傭勇勈咏喁嗈噰埇塎墉壅嫞嵱庸廱彮恿悀惥愑愹慂慵拥揘擁柡栐槦永泳涌湧滽澭灉牅用甬痈癕癰硧禜臃蛹詠踊踴邕郺鄘鏞镛雍雝顒颙饔鯒鰫鱅鲬鳙鷛",
+	"you":    "丣亴优佑侑偤優卣又友右呦哊唀嚘囿姷孧宥尢尤峟峳幼幽庮忧怣怮悠憂懮扜攸斿有柚栯梄楢槱櫌櫾沋油泑浟游湵滺瀀牖牗牰犹狖猶猷由疣祐禉秞糿纋纡羐羑耰聈肬脜苃莜莠莸蒏蕕蚰蚴蜏蝣訧誘诱貁輏輶迂迶逌逰遊邮郵鄾酉酭釉鈾銪铀铕駀魷鮋鱿鲉麀黝鼬",
+	"yu":     "与乻予于亐伃伛余俁俞俣俼偊傴兪匬吁喅喐喩喻噊噳圄圉圫域堉堣堬妤妪娛娯娱媀嫗嬩宇寓寙屿峪峿崳嵎嵛嶎嶼庽庾彧御忬悆惐愈愉愚慾懙戫扵揄敔斔斞於旕旟昱杅桙棛棜棫楀楡楰榆欤欲歈歟歶毓毺浴淢淤淯渔渝湡滪漁潏澞焴煜牏狱狳獄玉玗玙琙瑀瑜璵畭瘀瘉瘐盂盓睮矞砡硢硲祤禹禺秗稢稶窬窳竽箊籅緎罭羭羽聿肀育腴臾舁舆與艅艈芋芌茟茰萭萮萸蒮蓣蓹蕍薁蘌蘛虞蜟蜮蝓螸衧裕褕覦觎誉語諛謣语谀谕豫貐踰輍輿逳逾遇邘郁鄅酑鈺銉鋙鍝钰阈隅雓雨雩預頨预飫餘饫馀馭騟驭骬髃魚鮽鰅鱼鷠鸆鹆麌齬龉",
+	"yuan":   "傆元円冤原厡厵员員园圆圎園圓垣垸塬夗妴媛媴嫄嬽寃怨悁惌愿掾援杬棩榞榬橼櫞沅淵渁渆渊渕湲源溒灁爰猨猿獂瑗盶眢禐笎箢緣縁缘羱肙苑茒葾蒝蒬薗蚖蜎蜵蝝蝯螈衏袁裷謜貟贠轅辕远逺遠邍邧鋺鎱院駌騵魭鳶鴛鵷鶢鶰鸳鹓黿鼋鼘鼝",
+	"yue":    "刖妜岄岳彟彠恱悅悦戉抈捳曰曱月玥矱礿箹粤約约蚎蚏越跀跃軏钥钺阅",
+	"yun":    "云傊允勻匀喗囩夽奫妘孕恽惲愠愪慍抎昀晕暈枟榲橒殒殞氲氳沄涢溳澐煴熅熉熨狁畇眃磒秐筠筼篔紜緷緼縕縜纭缊耘耺腪芸荺蒀蒕蒷蕓蕴蝹褞賱贇赟运運郓郧鄆鄖酝鈗鋆阭陨隕雲霣韫韵頵饂馧馻齳",
+	"za":     "匝咂拶杂沞砸紥紮臜臢迊鉔魳",
+	"zai":    "侢再哉在宰崽扗栽洅渽灾烖睵菑賳载",
+	"zan":    "儧儹咱噆寁揝撍攅攒攢昝暂暫桚沯礸賛赞趱趲",
+	"zang":   "塟奘弉脏臧葬蔵賍賘贓贜赃駔驵髒",
+	"zao":    "凿唕唣喿噪慥早枣梍棗澡灶燥璪皁皂竃簉糟繰艁薻藻蚤譟趮蹧躁造遭醩鑿",
+	"ze":     "则択择沢泎泽责",
+	"zei":    "贼",
+	"zen":    "怎",
+	"zeng":   "增憎曾橧熷璔甑矰磳繒缯罾譄赠鋥锃",
+	"zha":    "乍偧劄厏吒咋咤哳喳奓宱扎抯拃挓揸搩搾摣札柤査栅楂榨樝渣溠灹炸煠牐甴痄皶皻眨砟箚耫苲蚱蚻觰詐譇譗诈踷轧鍘铡閘闸鮓鮺鲊鲝齄齇",
+	"zhai":   "债債宅寨摘斋斎榸檡砦窄鉙齋",
+	"zhan":   "佔偡占噡嫸展崭嶃嶄嶘嶦惉战戦戰搌斩斬旃旜栈栴桟棧椫榐橏毡氈氊沾湛琖盏盞瞻站粘綻绽菚薝蘸虥虦蛅覱詀詹譧譫讝谵趈輚輾轏辗邅醆閚霑颭飐飦饘驙魙鱣鳣鸇鹯黵",
+	"zhang":  "丈仉仗傽墇嫜嶂帐帳幛张張彰慞扙掌暲杖樟涨涱漲漳獐璋痮瘬瘴礃章粻胀脹蔁蟑賬账遧鄣長障餦騿鱆麞",
+	"zhao":   "兆召啁垗找招旐昭枛棹沼炤照狣瑵皽盄窼笊罩肁肇詔诏赵釗鉊鍣駋",
+	"zhe":    "乽厇哲啠啫喆嚞埑悊折摺晢晣柘歽浙矺砓磔禇籷粍者蔗虴蛰蟄袩褶襵詟謫謺讁讋谪赭輒輙轍辄辙这遮銸锗馲鮿",
+	"zhen":   "侦侲偵圳塦嫃寊屒帪弫抮挋振揕搸敶斟昣朕枕栕栚桢桭楨榛樼殝浈潧澵獉珍珎瑧瑱甄甽畛疹眕眞真眹砧碪祯禎禛稹箴籈紖紾絼縥纼缜聄胗臻葴蒖蓁薽袗裖診誫诊貞賑贞赈軫轃轸遉酖酙針鉁錱鍼针镇阵陣震靕駗鬒鱵鸩黰",
+	"zheng":  "争佂埩姃媜峥崝崢帧征徰徴徵怔愸抍拯挣掙掟揁撜政整晸正氶炡烝爭狰猙症癥眐睁睜筝箏篜糽聇蒸证诤踭郑鉦錚钲铮鬇鯖",
+	"zhi":    "之乿侄倁値值偫傂儨制劧卮厔只吱咫嗭址坁坧垁埴執墌夂妷姪娡嬂寘峙崻巵帋帙帜庢庤廌彘徏徝志忮怾恉慹戠执扺扻抧挃指挚掷搘搱摭支旨晊智枝枳柣栀栉桎梔梽植椥楖榰樴止殖汁汥汦沚治泜洔洷淔淽滍滞漐炙犆狾猘瓡畤疷疻痔痣直知砋祉祑祗祬禃禔秓秖秩秪秲秷稙稚窒筫紙紩絷綕縶織纸织置翐聀职職肢胑胝脂膱至致臸芖芝芷藢蘵蛭蜘蟙衹衼袟袠褁襧觗訨豸貭质贽趾跖踯蹠躑軄軹軽轵轾迣郅酯釞鉄铚阤阯陟隻馶馽骘鳷鴲鵄鸷黹鼅",
+	"zhong":  "中仲伀众冢刣喠塚塜妐妕尰幒彸忠柊歱汷泈炂煄狆瘇盅祌种種穜籦終终肿腫舯茽蔠螤螽衳衶衷踵蹱重鈡銿鍾鐘钟锺鼨",
+	"zhou":   "伷侜僽冑周呪咒咮喌噣妯宙州帚徟掫昼晝晭洲淍烐珘甃疛皱皺盩睭矪箒籀籒籕粙粥紂縐纣绉肘胄舟荮菷葤詋詶謅譸诌诪賙赒軸輈輖轴辀週郮酎銂霌駎騆驟骤鯞鵃鸼",
+	"zhu":    "丶主伫佇住侏劚助劯嘱囑坾壴孎宔嵀拄斸曯朱杼柱株槠橥櫧櫫欘殶泏注洙渚潴濐瀦灟炢炷烛煑煮燭爥猪珠疰瘃眝瞩矚砫硃祝祩秼窋竚竹竺笁笜筑築紵紸絑纻罜羜舳苎茱茿莇著蛀蛛蝫蠋蠩蠾袾註詝誅諸诛诸豬貯贮跓跦躅軴迬逐邾銖铢铸陼駯驻鮢鯺鱁鴸麈鼄",
+	"zhua":   "抓爪",
+	"zhuai":  "拽",
+	"zhuan":  "专僎叀啭堟塼嫥孨専專撰灷瑑瑼甎砖磗磚竱篆腞膞蒃蟤諯赚転轉转鄟顓颛鱄",
+	"zhuang": "壮壯壵妆妝娤庄撞桩梉樁湷漴焋状狀粧糚荘莊装裝",
+	"zhui":   "坠墜娷惴桘椎沝甀畷硾礈笍縋缀缒膇諈譵贅赘轛追醊錐錣鑆锥餟騅骓鵻",
+	"zhun":   "准衠諄谆",
+	"zhuo":   "丵倬卓叕啄圴妰彴拙捉斫桌棁棳汋浊浞涿灼炪烵犳琢着穛穱茁蠿诼酌",
+	"zi":     "仔倳兹剚吇呰咨啙嗞姉姊姕姿子字孜孳孶嵫恣杍栥梓椔榟淄渍湽滋滓漬澬牸玆璾眥眦矷禌秄秭秶稵笫籽粢紎紫緇缁耔胏胔胾自芓茊茡茲葘蓻虸觜訾訿諮谘貲資赀资趑趦輜輺辎鄑釨鈭錙鍿鎡锱镃頾頿髭鯔鰦鲻鶅鼒齍龇",
+	"zong":   "倧偬傯堫宗嵏嵕嵸总惣惾愡捴揔搃摠朡棕椶熧猣磫稯綜緃総緵縂總纵综翪腙葼蓗蝬豵踨踪蹤鍐鏓鑁騌騣骔鬃鬉鬷鯮鯼",
+	"zou":    "奏揍棷棸箃緅菆諏诹走赱邹郰鄒鄹陬騶驺鯫鲰黀齱齺",
+	"zu":     "俎傶卆卒哫崒崪族爼珇祖租箤组葅蒩诅足踤踿鏃镞阻",
+	"zuan":   "繤纂缵躜鑽钻",
+	"zui":    "嘴噿嶵晬最栬槜璻祽稡絊罪蕞辠酔酻醉",
+	"zun":    "墫壿尊嶟遵",
+	"zuo":    "佐作侳做唑唨坐岝岞左座怍昨柞祚繓胙袏阼",
+}
@@ -0,0 +1,83 @@
+// Package slug 为分类、标签等名称生成可以放在地址里的别名：
+// 英文字母转小写、数字保留，汉字转为不带声调的拼音，各段之间用 - 连接，其余字符视为分隔符
+package slug
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// MaxLength 别名的最大长度
+const MaxLength = 64
+
+var (
+	pinyinOnce sync.Once
+	pinyinOf   map[rune]string
+)
+
+// loadPinyin 把拼音表展开为汉字到拼音的映射
+func loadPinyin() {
+	pinyinOf = make(map[rune]string, 20000)
+	for syllable, chars := range pinyinTable {
+		for _, c := range chars {
+			pinyinOf[c] = syllable
+		}
+	}
+}
+
+// Pinyin 返回汉字不带声调的拼音，不在拼音表中时返回空字符串
+func Pinyin(c rune) string {
+	pinyinOnce.Do(loadPinyin)
+	return pinyinOf[c]
+}
+
+// Make 根据名称生成别名，如「Go 并发编程」生成 go-bing-fa-bian-cheng；
+// 名称中没有可用字符时返回空字符串，由调用方决定如何兜底
+func Make(name string) string {
+	var parts []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			parts = append(parts, word.String())
+			word.Reset()
+		}
+	}
+	for _, c := range name {
+		switch {
+		case c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)):
+			word.WriteRune(unicode.ToLower(c))
+		case unicode.Is(unicode.Han, c):
+			flush()
+			if syllable := Pinyin(c); syllable != "" {
+				parts = append(parts, syllable)
+			}
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	slug := strings.Join(parts, "-")
+	if len(slug) > MaxLength {
+		slug = strings.TrimRight(slug[:MaxLength], "-")
+	}
+	return slug
+}
+
+// Valid 判断别名是否只由小写字母、数字和单个 - 组成，且不以 - 开头或结尾
+func Valid(slug string) bool {
+	if slug == "" || len(slug) > MaxLength || slug[0] == '-' || slug[len(slug)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(slug); i++ {
+		c := slug[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-' && slug[i-1] != '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
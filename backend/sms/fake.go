@@ -0,0 +1,62 @@
+package sms
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Message 已发送的短信
+type Message struct {
+	Phone   string
+	Content string
+	SentAt  time.Time
+}
+
+// FakeSender 本地假短信服务，不真正发送短信，只记录已发送的内容，用于本地开发和测试
+type FakeSender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewFakeSender 创建假短信服务
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+// Send 记录一条短信
+func (s *FakeSender) Send(ctx context.Context, phone, content string) error {
+	s.mu.Lock()
+	s.messages = append(s.messages, Message{Phone: phone, Content: content, SentAt: time.Now()})
+	s.mu.Unlock()
+
+	log.Printf("短信(未实际发送) To: %s, 内容: %s", phone, content)
+	return nil
+}
+
+// Messages 返回已记录的全部短信
+func (s *FakeSender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// LastMessage 返回发给某个手机号的最后一条短信
+func (s *FakeSender) LastMessage(phone string) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if s.messages[i].Phone == phone {
+			return s.messages[i], true
+		}
+	}
+	return Message{}, false
+}
+
+// Reset 清空已记录的短信
+func (s *FakeSender) Reset() {
+	s.mu.Lock()
+	s.messages = nil
+	s.mu.Unlock()
+}
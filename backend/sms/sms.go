@@ -0,0 +1,74 @@
+// Package sms 提供发送短信的抽象和手机号规范化，具体短信服务商通过实现 Sender 接入
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// ErrInvalidPhone 手机号格式不正确
+var ErrInvalidPhone = errors.New("手机号格式不正确")
+
+// DefaultCountryCode 手机号不带国家码时使用的国家码，由 main 根据配置设置
+var DefaultCountryCode = "86"
+
+// Sender 短信发送接口
+type Sender interface {
+	// Send 向 E.164 格式的手机号发送一条短信
+	Send(ctx context.Context, phone, content string) error
+}
+
+// New 根据配置创建短信发送器
+func New(cfg *config.Config) (Sender, error) {
+	switch cfg.SMSDriver {
+	case "fake", "":
+		return NewFakeSender(), nil
+	default:
+		return nil, fmt.Errorf("不支持的短信驱动: %s", cfg.SMSDriver)
+	}
+}
+
+// NormalizePhone 把手机号规范化为 E.164 格式（如 +8613800138000）
+// 支持 +国家码、00国家码 开头的国际号码，不带国家码的号码使用 defaultCountryCode
+func NormalizePhone(phone, defaultCountryCode string) (string, error) {
+	phone = strings.TrimSpace(phone)
+
+	var digits strings.Builder
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(phone, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		number = strings.TrimPrefix(number, "0")
+		// 国内手机号为11位且以1开头
+		if defaultCountryCode == "86" && (len(number) != 11 || number[0] != '1') {
+			return "", ErrInvalidPhone
+		}
+		number = defaultCountryCode + number
+	}
+
+	// E.164 规定号码（含国家码）最长15位，国家码不以0开头
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+	if strings.HasPrefix(number, "86") && (len(number) != 13 || number[2] != '1') {
+		return "", ErrInvalidPhone
+	}
+	return "+" + number, nil
+}
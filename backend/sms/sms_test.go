@@ -0,0 +1,113 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+func TestFakeSender(t *testing.T) {
+	s := NewFakeSender()
+	ctx := context.Background()
+
+	if _, ok := s.LastMessage("+8613800138000"); ok {
+		t.Fatal("LastMessage found a message before anything was sent")
+	}
+	for _, msg := range []Message{
+		{Phone: "+8613800138000", Content: "验证码 111111"},
+		{Phone: "+8613900139000", Content: "验证码 222222"},
+		{Phone: "+8613800138000", Content: "验证码 333333"},
+	} {
+		if err := s.Send(ctx, msg.Phone, msg.Content); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	if got := len(s.Messages()); got != 3 {
+		t.Fatalf("len(Messages()) = %d, want 3", got)
+	}
+	last, ok := s.LastMessage("+8613800138000")
+	if !ok || last.Content != "验证码 333333" || last.SentAt.IsZero() {
+		t.Errorf("LastMessage = %+v, %v, want the latest message to the phone", last, ok)
+	}
+
+	// Messages 返回副本，修改不影响记录
+	s.Messages()[0].Content = "changed"
+	if s.Messages()[0].Content != "验证码 111111" {
+		t.Error("Messages returned the internal slice")
+	}
+
+	s.Reset()
+	if len(s.Messages()) != 0 {
+		t.Error("Messages not empty after Reset")
+	}
+	if _, ok := s.LastMessage("+8613800138000"); ok {
+		t.Error("LastMessage found a message after Reset")
+	}
+}
+
+func TestFakeSenderConcurrent(t *testing.T) {
+	s := NewFakeSender()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = s.Send(context.Background(), fmt.Sprintf("+86138001380%02d", i), "验证码")
+		}(i)
+	}
+	wg.Wait()
+	if got := len(s.Messages()); got != 20 {
+		t.Errorf("len(Messages()) = %d, want 20", got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, driver := range []string{"", "fake"} {
+		sender, err := New(&config.Config{SMSDriver: driver})
+		if err != nil {
+			t.Fatalf("New(%q): %v", driver, err)
+		}
+		if _, ok := sender.(*FakeSender); !ok {
+			t.Errorf("New(%q) = %T, want *FakeSender", driver, sender)
+		}
+	}
+	if _, err := New(&config.Config{SMSDriver: "unknown"}); err == nil {
+		t.Error("New with an unknown driver returned no error")
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, country, want string
+	}{
+		{"13800138000", "86", "+8613800138000"},
+		{"138-0013-8000", "86", "+8613800138000"},
+		{"+86 138 0013 8000", "86", "+8613800138000"},
+		{"008613800138000", "86", "+8613800138000"},
+		{"+1 (415) 555-2671", "86", "+14155552671"},
+		{"04155552671", "1", "+14155552671"},
+		{"12345", "86", ""},
+		{"23800138000", "86", ""},
+		{"+8623800138000", "86", ""},
+		{"1380013800a", "86", ""},
+		{"138+00138000", "86", ""},
+		{"+1234567890123456", "86", ""},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.phone, tt.country)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidPhone) {
+				t.Errorf("NormalizePhone(%q, %q) = %q, %v, want ErrInvalidPhone", tt.phone, tt.country, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizePhone(%q, %q) = %q, %v, want %q", tt.phone, tt.country, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package spamfilter 用户发布内容（评论、留言、聊天消息）的垃圾内容过滤：违禁词、链接数量和大段重复字符
+package spamfilter
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+var (
+	// ErrBannedWord 内容包含违禁词
+	ErrBannedWord = errors.New("内容包含违禁词")
+	// ErrTooManyLinks 内容包含过多链接
+	ErrTooManyLinks = errors.New("内容包含过多链接")
+	// ErrRepeated 内容包含大段重复字符
+	ErrRepeated = errors.New("内容包含大段重复字符")
+)
+
+// maxRepeat 同一字符连续出现的最大次数
+const maxRepeat = 30
+
+// Filter 垃圾内容过滤器
+type Filter struct {
+	words    []string // 小写的违禁词
+	maxLinks int      // 允许的最大链接数，小于0时不限制
+}
+
+var defaultFilter = New(nil, -1)
+
+// Init 根据配置初始化全局过滤器
+func Init(cfg *config.Config) {
+	defaultFilter = New(strings.Split(cfg.SpamWords, ","), cfg.SpamMaxLinks)
+}
+
+// Check 使用全局过滤器检查内容
+func Check(content string) error {
+	return defaultFilter.Check(content)
+}
+
+// New 创建过滤器，空白的违禁词会被忽略
+func New(words []string, maxLinks int) *Filter {
+	f := &Filter{maxLinks: maxLinks}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			f.words = append(f.words, word)
+		}
+	}
+	return f
+}
+
+// Check 检查内容，命中任一规则时返回对应错误
+func (f *Filter) Check(content string) error {
+	lower := strings.ToLower(content)
+	for _, word := range f.words {
+		if strings.Contains(lower, word) {
+			return ErrBannedWord
+		}
+	}
+	if f.maxLinks >= 0 && countLinks(lower) > f.maxLinks {
+		return ErrTooManyLinks
+	}
+	if longestRun(content) > maxRepeat {
+		return ErrRepeated
+	}
+	return nil
+}
+
+// countLinks 统计内容中的链接数量
+func countLinks(lower string) int {
+	return strings.Count(lower, "http://") + strings.Count(lower, "https://") +
+		strings.Count(strings.NewReplacer("http://www.", "", "https://www.", "").Replace(lower), "www.")
+}
+
+// longestRun 返回同一字符连续出现的最大次数，空白字符不计
+func longestRun(content string) int {
+	longest, run := 0, 0
+	var last rune = utf8.RuneError
+	for _, c := range content {
+		if c == ' ' || c == '\n' || c == '\t' || c == '\r' {
+			last, run = utf8.RuneError, 0
+			continue
+		}
+		if c == last {
+			run++
+		} else {
+			last, run = c, 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
@@ -12,6 +12,13 @@ if %errorlevel% neq 0 (
     exit /b 1
 )
 
+REM 检查是否设置了 JWT 签名密钥
+if "%JWT_SECRET%"=="" (
+    echo 错误: 未设置环境变量 JWT_SECRET，请先设置一个随机的长字符串作为签名密钥
+    pause
+    exit /b 1
+)
+
 REM 安装依赖
 echo 正在安装依赖...
 go mod tidy
@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testBucket    = "blog"
+	testRegion    = "us-east-1"
+	testAccessKey = "test-access-key"
+	testSecretKey = "test-secret-key"
+)
+
+// fakeS3 本地的 S3 替代服务，把对象保存在内存中，并按 Signature V4 校验每个请求
+type fakeS3 struct {
+	mu           sync.Mutex
+	objects      map[string][]byte
+	contentTypes map[string]string
+	paths        []string // 收到的原始请求路径
+	rejected     []string // 签名校验不通过的原因
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{objects: make(map[string][]byte), contentTypes: make(map[string]string)}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if msg := f.verify(r, body); msg != "" {
+		f.mu.Lock()
+		f.rejected = append(f.rejected, r.Method+" "+r.URL.Path+": "+msg)
+		f.mu.Unlock()
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	prefix := "/" + testBucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "<Error><Code>NoSuchBucket</Code></Error>", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, r.URL.EscapedPath())
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = body
+		f.contentTypes[key] = r.Header.Get("Content-Type")
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.contentTypes[key])
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify 按服务端的方式重新计算签名，返回不通过的原因
+func (f *fakeS3) verify(r *http.Request, body []byte) string {
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	sum := sha256.Sum256(body)
+	if payloadHash != hex.EncodeToString(sum[:]) {
+		return "X-Amz-Content-Sha256 does not match the body"
+	}
+
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	fields := make(map[string]string)
+	for _, part := range strings.Split(auth, ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		fields[name] = value
+	}
+	credential := strings.Split(fields["Credential"], "/")
+	if len(credential) != 5 || credential[0] != testAccessKey || credential[2] != testRegion {
+		return "bad credential " + fields["Credential"]
+	}
+	day := credential[1]
+	amzDate := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(amzDate, day) {
+		return "X-Amz-Date does not match the credential scope"
+	}
+
+	signed := strings.Split(fields["SignedHeaders"], ";")
+	if !sort.StringsAreSorted(signed) {
+		return "SignedHeaders not sorted"
+	}
+	var canonicalHeaders strings.Builder
+	for _, name := range signed {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.Query().Encode(),
+		canonicalHeaders.String(), fields["SignedHeaders"], payloadHash,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := day + "/" + testRegion + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+testSecretKey), day)
+	for _, part := range []string{testRegion, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	if fields["Signature"] != hex.EncodeToString(hmacSHA256(key, stringToSign)) {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+func newTestS3(t *testing.T, srv *httptest.Server) *S3Storage {
+	t.Helper()
+	s, err := NewS3(S3Options{
+		Endpoint:  srv.URL + "/",
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	})
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	return s
+}
+
+func TestS3Storage(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	s := newTestS3(t, srv)
+	ctx := context.Background()
+	const key = "images/2026/10/图片 a+b.png"
+	data := []byte("\x89PNG fake image data")
+
+	if ok, err := s.Exists(ctx, key); err != nil || ok {
+		t.Fatalf("Exists before Put = %v, %v, want false", ok, err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get before Put err = %v, want ErrNotFound", err)
+	}
+
+	if err := s.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := fake.contentTypes[key]; got != "image/png" {
+		t.Errorf("stored Content-Type = %q, want image/png", got)
+	}
+	if ok, err := s.Exists(ctx, key); err != nil || !ok {
+		t.Errorf("Exists after Put = %v, %v, want true", ok, err)
+	}
+
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(rc)
+	rc.Close()
+	if !bytes.Equal(got, data) {
+		t.Errorf("Get = %q, want %q", got, data)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if ok, err := s.Exists(ctx, key); err != nil || ok {
+		t.Errorf("Exists after Delete = %v, %v, want false", ok, err)
+	}
+	// 删除不存在的对象不报错
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete missing object: %v", err)
+	}
+
+	if len(fake.rejected) > 0 {
+		t.Errorf("requests rejected by the fake S3: %v", fake.rejected)
+	}
+	// 路径按 S3 规范编码，+ 和空格都不能原样出现
+	for _, p := range fake.paths {
+		if strings.ContainsAny(p, "+ ") {
+			t.Errorf("request path %q is not fully escaped", p)
+		}
+	}
+}
+
+func TestS3StorageWrongSecret(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	s, err := NewS3(S3Options{Endpoint: srv.URL, Bucket: testBucket, AccessKey: testAccessKey, SecretKey: "wrong"})
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	err = s.Put(context.Background(), "a.txt", strings.NewReader("a"), 1, "text/plain")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Put with a wrong secret: err = %v, want a 403 error", err)
+	}
+	if len(fake.rejected) != 1 || len(fake.objects) != 0 {
+		t.Errorf("rejected = %v, objects = %d, want the request rejected", fake.rejected, len(fake.objects))
+	}
+}
+
+func TestS3URL(t *testing.T) {
+	s, err := NewS3(S3Options{Endpoint: "http://127.0.0.1:9000/", Bucket: testBucket, AccessKey: "a", SecretKey: "b"})
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	if got, want := s.URL("/a/图 1.png"), "http://127.0.0.1:9000/blog/a/%E5%9B%BE%201.png"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+
+	s, err = NewS3(S3Options{Endpoint: "http://127.0.0.1:9000", Bucket: testBucket, AccessKey: "a", SecretKey: "b", PublicURL: "https://cdn.example.com/"})
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	if got, want := s.URL("a.png"), "https://cdn.example.com/a.png"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+}
+
+func TestNewS3InvalidOptions(t *testing.T) {
+	for _, opts := range []S3Options{
+		{Bucket: testBucket, AccessKey: "a", SecretKey: "b"},
+		{Endpoint: "http://127.0.0.1:9000", AccessKey: "a", SecretKey: "b"},
+		{Endpoint: "http://127.0.0.1:9000", Bucket: testBucket},
+		{Endpoint: "not a url", Bucket: testBucket, AccessKey: "a", SecretKey: "b"},
+	} {
+		if _, err := NewS3(opts); err == nil {
+			t.Errorf("NewS3(%+v) returned no error", opts)
+		}
+	}
+}
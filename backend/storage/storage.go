@@ -0,0 +1,47 @@
+// Package storage 文件存储后端：本地文件系统或 S3 兼容的对象存储
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jayden/personal-blog-backend/config"
+)
+
+// ErrNotFound 文件不存在
+var ErrNotFound = errors.New("文件不存在")
+
+// Storage 文件存储后端，key 为以 / 分隔的相对路径
+type Storage interface {
+	// Put 写入文件，已存在时覆盖
+	Put(ctx context.Context, key string, r io.ReadSeeker, size int64, contentType string) error
+	// Get 读取文件，不存在时返回 ErrNotFound，调用方负责关闭
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Exists 判断文件是否存在
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete 删除文件，不存在时不报错
+	Delete(ctx context.Context, key string) error
+	// URL 返回文件的公开访问地址
+	URL(key string) string
+}
+
+// New 根据配置创建存储后端
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.UploadDriver {
+	case "", "local":
+		return NewLocal(cfg.UploadLocalDir, cfg.UploadBaseURL)
+	case "s3":
+		return NewS3(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.S3PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("不支持的存储驱动: %s", cfg.UploadDriver)
+	}
+}
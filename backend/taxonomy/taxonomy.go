@@ -0,0 +1,70 @@
+// Package taxonomy 分类和标签的计数校正任务：文章增删改时计数已在事务中维护，
+// 这里定期按公开文章重新计算一遍，修正直接改库等途径造成的偏差，并补齐缺少的别名
+package taxonomy
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/models"
+)
+
+var (
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex // 避免定期任务和手动触发同时执行
+)
+
+// Init 根据配置启动定期校正，间隔为0时只在后台管理中手动触发
+func Init(cfg *config.Config) {
+	if cfg.TaxonomyReconcileInterval <= 0 {
+		return
+	}
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.Background())
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(cfg.TaxonomyReconcileInterval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := Reconcile(); err != nil {
+				log.Printf("校正分类和标签计数失败: %v", err)
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期校正
+func Stop() {
+	if cancel != nil {
+		cancel()
+	}
+	wg.Wait()
+}
+
+// Reconcile 立即校正一次，有修正时记录日志
+func Reconcile() (*models.TaxonomyReconcileResult, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result, err := models.ReconcileTaxonomy()
+	if err != nil {
+		return nil, err
+	}
+	if result.CategoryFixed > 0 || result.TagFixed > 0 || result.CategorySlugFilled > 0 || result.TagSlugFilled > 0 {
+		log.Printf("校正分类和标签: 修正分类计数 %d 个，标签计数 %d 个，补齐分类别名 %d 个，标签别名 %d 个",
+			result.CategoryFixed, result.TagFixed, result.CategorySlugFilled, result.TagSlugFilled)
+	}
+	return result, nil
+}
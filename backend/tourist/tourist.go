@@ -0,0 +1,131 @@
+// Package tourist 签发和校验游客ID，记录游客的访问，并在游客注册或登录后把游客身份下的数据合并到账号
+package tourist
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+)
+
+// HeaderName 前端携带游客ID的请求头
+const HeaderName = "X-Terminal-Id"
+
+const (
+	nonceSize = 16 // 随机部分的字节数
+	macSize   = 8  // 签名部分保留的字节数
+	// maxTouched 记录的最近访问游客数超过该值时清理过期记录
+	maxTouched = 10000
+)
+
+var (
+	secret        []byte // Init 时从配置读取
+	touchInterval = 5 * time.Minute
+
+	mu      sync.Mutex
+	touched = make(map[string]time.Time)
+)
+
+// Init 从配置中读取签名密钥和访问记录间隔
+func Init(cfg *config.Config) {
+	secret = []byte(cfg.TouristSecret)
+	touchInterval = cfg.TouristTouchInterval
+}
+
+// NewID 签发新的游客ID，格式为 hex(随机数)-hex(HMAC-SHA256 前缀)，只包含前端终端ID允许的字符
+func NewID() (string, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	encoded := hex.EncodeToString(nonce)
+	return encoded + "-" + sign(encoded), nil
+}
+
+// Verify 校验游客ID是否由本站签发
+func Verify(id string) bool {
+	encoded, mac, ok := strings.Cut(id, "-")
+	if !ok || len(encoded) != nonceSize*2 || len(mac) != macSize*2 {
+		return false
+	}
+	if _, err := hex.DecodeString(encoded); err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(mac), []byte(sign(encoded)))
+}
+
+// sign 计算随机部分的签名
+func sign(encoded string) string {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(encoded))
+	return hex.EncodeToString(h.Sum(nil)[:macSize])
+}
+
+// FromRequest 读取请求携带的游客ID，未携带或签名无效时返回空字符串
+func FromRequest(r *http.Request) string {
+	id := strings.TrimSpace(r.Header.Get(HeaderName))
+	if !Verify(id) {
+		return ""
+	}
+	return id
+}
+
+// Touch 记录游客的一次访问；同一游客在间隔内只写一次数据库，避免每个请求都更新
+func Touch(r *http.Request, id string) {
+	now := time.Now()
+	mu.Lock()
+	if last, ok := touched[id]; ok && now.Sub(last) < touchInterval {
+		mu.Unlock()
+		return
+	}
+	if len(touched) >= maxTouched {
+		for key, last := range touched {
+			if now.Sub(last) >= touchInterval {
+				delete(touched, key)
+			}
+		}
+	}
+	touched[id] = now
+	mu.Unlock()
+
+	if err := models.TouchTourist(id, netutil.ClientIP(r), r.UserAgent()); err != nil {
+		log.Printf("记录游客 %s 访问失败: %v", id, err)
+	}
+}
+
+// Middleware 记录携带有效游客ID的请求
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := FromRequest(r); id != "" {
+			Touch(r, id)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Merge 把请求携带的游客身份下的点赞、留言和聊天消息合并到用户名下。
+// 合并失败不影响登录，只记录日志
+func Merge(r *http.Request, userID int) {
+	id := FromRequest(r)
+	if id == "" || userID <= 0 {
+		return
+	}
+	merged, err := models.MergeTourist(id, userID)
+	if err != nil {
+		log.Printf("合并游客 %s 到用户 %d 失败: %v", id, userID, err)
+		return
+	}
+	if merged.Likes > 0 || merged.Remarks > 0 || merged.Messages > 0 {
+		log.Printf("游客 %s 合并到用户 %d: 点赞 %d 条，留言 %d 条，聊天消息 %d 条",
+			id, userID, merged.Likes, merged.Remarks, merged.Messages)
+	}
+}
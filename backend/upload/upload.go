@@ -0,0 +1,261 @@
+// Package upload 处理文件上传：限制大小和类型、按内容识别类型、按内容摘要去重，并写入存储后端
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/imageproc"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/storage"
+)
+
+// 上传错误
+var (
+	ErrEmptyFile      = errors.New("文件不能为空")
+	ErrTooLarge       = errors.New("文件大小超过限制")
+	ErrTypeNotAllowed = errors.New("不支持的文件类型")
+	ErrInvalidPath    = errors.New("无效的文件路径")
+	ErrInvalidImage   = errors.New("图片文件已损坏")
+)
+
+// DefaultDir 未指定目录时使用的目录
+const DefaultDir = "default"
+
+// extensions 按内容识别出的类型对应的扩展名，不使用客户端提供的扩展名
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/bmp":       ".bmp",
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+	"video/mp4":       ".mp4",
+	"audio/mpeg":      ".mp3",
+	"application/zip": ".zip",
+}
+
+// Options 上传限制
+type Options struct {
+	MaxSize      int64
+	MaxFiles     int
+	AllowedTypes map[string]bool
+}
+
+// Service 文件上传服务
+type Service struct {
+	store storage.Storage
+	opts  Options
+}
+
+var defaultService *Service
+
+// Init 根据配置创建存储后端并初始化全局上传服务
+func Init(cfg *config.Config) error {
+	store, err := storage.New(cfg)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[string]bool)
+	for _, t := range strings.Split(cfg.UploadAllowedTypes, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			allowed[t] = true
+		}
+	}
+	defaultService = NewService(store, Options{
+		MaxSize:      cfg.UploadMaxSize,
+		MaxFiles:     cfg.UploadMaxFiles,
+		AllowedTypes: allowed,
+	})
+	return nil
+}
+
+// Default 返回全局上传服务
+func Default() *Service {
+	return defaultService
+}
+
+// NewService 创建上传服务
+func NewService(store storage.Storage, opts Options) *Service {
+	return &Service{store: store, opts: opts}
+}
+
+// Storage 返回存储后端
+func (s *Service) Storage() storage.Storage {
+	return s.store
+}
+
+// MaxSize 返回单个文件大小上限
+func (s *Service) MaxSize() int64 {
+	return s.opts.MaxSize
+}
+
+// MaxFiles 返回批量上传一次最多的文件数
+func (s *Service) MaxFiles() int {
+	return s.opts.MaxFiles
+}
+
+// Save 保存上传的文件并写入上传记录。图片会先去掉元数据，相同内容在存储后端只保存一份，
+// 同一用户在同一目录下重复上传相同内容时更新自己的已有记录
+func (s *Service) Save(ctx context.Context, userID int, dir string, fh *multipart.FileHeader) (*models.UploadFile, error) {
+	return s.save(ctx, userID, dir, fh, false)
+}
+
+// SaveImage 与 Save 相同，但只接受图片
+func (s *Service) SaveImage(ctx context.Context, userID int, dir string, fh *multipart.FileHeader) (*models.UploadFile, error) {
+	return s.save(ctx, userID, dir, fh, true)
+}
+
+func (s *Service) save(ctx context.Context, userID int, dir string, fh *multipart.FileHeader, imageOnly bool) (*models.UploadFile, error) {
+	dir, err := CleanDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if fh.Size == 0 {
+		return nil, ErrEmptyFile
+	}
+	if fh.Size > s.opts.MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return nil, fmt.Errorf("读取上传文件失败: %w", err)
+	}
+	defer f.Close()
+
+	contentType, err := sniff(f)
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := extensions[contentType]
+	if !ok || !s.opts.AllowedTypes[contentType] || (imageOnly && !strings.HasPrefix(contentType, "image/")) {
+		return nil, ErrTypeNotAllowed
+	}
+
+	// 读取全部内容，以实际读取的字节数为准再检查一次大小
+	data, err := io.ReadAll(io.LimitReader(f, s.opts.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("读取上传文件失败: %w", err)
+	}
+	if int64(len(data)) > s.opts.MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	// 图片先去掉 EXIF/GPS 等元数据再保存，原图也不会泄露拍摄位置
+	data, err = imageproc.Sanitize(data, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	size := int64(len(data))
+	hash := sha256.Sum256(data)
+	sum := hex.EncodeToString(hash[:])
+
+	key := sum[:2] + "/" + sum + ext
+	exists, err := s.store.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := s.store.Put(ctx, key, bytes.NewReader(data), size, contentType); err != nil {
+			return nil, err
+		}
+	}
+
+	file := &models.UploadFile{
+		UserID:     userID,
+		FilePath:   dir + "/" + sum + ext,
+		FileName:   cleanFileName(fh.Filename),
+		FileType:   contentType,
+		FileSize:   size,
+		FileURL:    s.store.URL(key),
+		FileHash:   sum,
+		StorageKey: key,
+	}
+	if err := models.SaveUploadFile(file); err != nil {
+		return nil, err
+	}
+
+	// 异步生成缩略图和中图，已存在的变体会被跳过，只更新记录中的地址
+	imageproc.Enqueue(key, contentType)
+	return file, nil
+}
+
+// Delete 删除上传记录，没有其他记录引用同一存储对象时一并删除存储对象和它的缩略图、中图
+func (s *Service) Delete(ctx context.Context, file *models.UploadFile) error {
+	refs, err := models.DeleteUploadFile(file.ID, file.StorageKey)
+	if err != nil {
+		return err
+	}
+	if refs > 0 {
+		return nil
+	}
+	for _, key := range append(imageproc.VariantKeys(file.StorageKey), file.StorageKey) {
+		if err := s.store.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sniff 读取文件开头识别内容类型，并把读取位置重置到开头
+func sniff(f multipart.File) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("读取上传文件失败: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("读取上传文件失败: %w", err)
+	}
+	contentType := http.DetectContentType(head[:n])
+	// 去掉 charset 等参数
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+	return contentType, nil
+}
+
+var dirSegment = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// CleanDir 规范化上传目录：由字母、数字、下划线和短横线组成，最多4级，为空时使用默认目录
+func CleanDir(dir string) (string, error) {
+	dir = strings.Trim(strings.TrimSpace(dir), "/")
+	if dir == "" {
+		return DefaultDir, nil
+	}
+	segments := strings.Split(dir, "/")
+	if len(segments) > 4 {
+		return "", ErrInvalidPath
+	}
+	for _, segment := range segments {
+		if !dirSegment.MatchString(segment) {
+			return "", ErrInvalidPath
+		}
+	}
+	return dir, nil
+}
+
+// cleanFileName 只保留原始文件名的最后一段，并限制长度
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	if r := []rune(name); len(r) > 100 {
+		name = string(r[len(r)-100:])
+	}
+	return name
+}
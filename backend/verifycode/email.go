@@ -0,0 +1,49 @@
+package verifycode
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/mailer"
+)
+
+// sceneNames 验证码场景对应的中文说明
+var sceneNames = map[string]string{
+	SceneRegister:      "注册账号",
+	SceneResetPassword: "重置密码",
+	SceneBindEmail:     "绑定邮箱",
+	SceneBindPhone:     "绑定手机号",
+	SceneLogin:         "登录",
+	SceneUnbindPhone:   "解绑手机号",
+}
+
+// EmailSender 通过邮件投递验证码
+type EmailSender struct {
+	mailer mailer.Mailer
+}
+
+// NewEmailSender 创建邮件验证码投递器
+func NewEmailSender(m mailer.Mailer) *EmailSender {
+	return &EmailSender{mailer: m}
+}
+
+// SendCode 发送验证码邮件
+func (s *EmailSender) SendCode(ctx context.Context, target, scene, code string, ttl time.Duration) error {
+	subject := fmt.Sprintf("【博客】%s验证码", sceneNames[scene])
+	body := fmt.Sprintf("您正在进行%s操作，验证码为：%s\n\n验证码%d分钟内有效，请勿泄露给他人。如非本人操作，请忽略本邮件。",
+		sceneNames[scene], code, int(ttl.Minutes()))
+	return s.mailer.Send(ctx, target, subject, body)
+}
+
+// NormalizeEmail 校验并规范化邮箱地址（去除空白、转为小写），不合法时返回 false
+func NormalizeEmail(email string) (string, bool) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > 254 {
+		return "", false
+	}
+	return email, true
+}
@@ -0,0 +1,26 @@
+package verifycode
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/sms"
+)
+
+// SMSSender 通过短信投递验证码
+type SMSSender struct {
+	sender sms.Sender
+}
+
+// NewSMSSender 创建短信验证码投递器
+func NewSMSSender(s sms.Sender) *SMSSender {
+	return &SMSSender{sender: s}
+}
+
+// SendCode 发送验证码短信
+func (s *SMSSender) SendCode(ctx context.Context, target, scene, code string, ttl time.Duration) error {
+	content := fmt.Sprintf("【博客】您正在进行%s操作，验证码%s，%d分钟内有效，请勿泄露给他人。",
+		sceneNames[scene], code, int(ttl.Minutes()))
+	return s.sender.Send(ctx, target, content)
+}
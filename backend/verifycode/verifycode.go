@@ -0,0 +1,222 @@
+// Package verifycode 负责验证码的生成、存储、频率限制与校验，邮件和短信验证码共用同一套逻辑
+package verifycode
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// 验证码使用场景
+const (
+	SceneRegister      = "register"
+	SceneResetPassword = "reset_password"
+	SceneBindEmail     = "bind_email"
+	SceneBindPhone     = "bind_phone"
+	SceneLogin         = "login"
+	SceneUnbindPhone   = "unbind_phone"
+)
+
+var (
+	// ErrInvalidScene 不支持的验证码场景
+	ErrInvalidScene = errors.New("不支持的验证码类型")
+	// ErrTooFrequent 发送过于频繁
+	ErrTooFrequent = errors.New("验证码发送过于频繁，请稍后再试")
+	// ErrDailyLimit 超过每日发送上限
+	ErrDailyLimit = errors.New("今日验证码发送次数已达上限")
+	// ErrCodeNotFound 验证码不存在或已过期
+	ErrCodeNotFound = errors.New("验证码不存在或已过期")
+	// ErrCodeMismatch 验证码错误
+	ErrCodeMismatch = errors.New("验证码错误")
+	// ErrTooManyAttempts 验证码错误次数过多，已失效
+	ErrTooManyAttempts = errors.New("验证码错误次数过多，请重新获取")
+)
+
+// Sender 验证码投递接口
+type Sender interface {
+	// SendCode 把验证码投递到目标地址（邮箱或手机号）
+	SendCode(ctx context.Context, target, scene, code string, ttl time.Duration) error
+}
+
+// Options 验证码服务配置
+type Options struct {
+	CodeLength  int           // 验证码位数
+	TTL         time.Duration // 验证码有效期
+	MaxAttempts int           // 最多允许校验失败的次数
+	Interval    time.Duration // 同一地址两次发送的最小间隔
+	DailyLimit  int           // 同一地址每天最多发送次数
+}
+
+// record 已发送的验证码，只保存哈希
+type record struct {
+	hash     [sha256.Size]byte
+	expireAt time.Time
+	attempts int
+}
+
+// sendState 某个地址的发送频率状态
+type sendState struct {
+	lastSentAt time.Time
+	day        string
+	count      int
+}
+
+// Service 验证码服务
+type Service struct {
+	mu      sync.Mutex
+	sender  Sender
+	opts    Options
+	scenes  map[string]bool
+	records map[string]*record    // 场景|地址 -> 验证码
+	sends   map[string]*sendState // 地址 -> 发送频率状态
+}
+
+// 由 main 初始化的全局验证码服务
+var (
+	// EmailCodes 邮件验证码服务
+	EmailCodes *Service
+	// PhoneCodes 短信验证码服务
+	PhoneCodes *Service
+)
+
+// NewService 创建验证码服务，scenes 为该服务支持的场景
+func NewService(sender Sender, opts Options, scenes ...string) *Service {
+	s := &Service{
+		sender:  sender,
+		opts:    opts,
+		scenes:  make(map[string]bool, len(scenes)),
+		records: make(map[string]*record),
+		sends:   make(map[string]*sendState),
+	}
+	for _, scene := range scenes {
+		s.scenes[scene] = true
+	}
+	return s
+}
+
+// Send 生成验证码并投递到目标地址，同一场景下新验证码会覆盖旧验证码
+func (s *Service) Send(ctx context.Context, scene, target string) error {
+	if !s.scenes[scene] {
+		return ErrInvalidScene
+	}
+
+	code, err := generateCode(s.opts.CodeLength)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.cleanupLocked(now)
+	if err := s.checkRateLocked(target, now); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.records[recordKey(scene, target)] = &record{
+		hash:     hashCode(scene, target, code),
+		expireAt: now.Add(s.opts.TTL),
+	}
+	s.mu.Unlock()
+
+	if err := s.sender.SendCode(ctx, target, scene, code, s.opts.TTL); err != nil {
+		// 投递失败时删除验证码，但保留发送记录，避免借失败绕过频率限制
+		s.mu.Lock()
+		delete(s.records, recordKey(scene, target))
+		s.mu.Unlock()
+		return fmt.Errorf("发送验证码失败: %w", err)
+	}
+	return nil
+}
+
+// Verify 校验验证码，校验成功后验证码立即失效
+func (s *Service) Verify(scene, target, code string) error {
+	key := recordKey(scene, target)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[key]
+	if !ok || time.Now().After(rec.expireAt) {
+		delete(s.records, key)
+		return ErrCodeNotFound
+	}
+
+	hash := hashCode(scene, target, code)
+	if subtle.ConstantTimeCompare(hash[:], rec.hash[:]) != 1 {
+		rec.attempts++
+		if rec.attempts >= s.opts.MaxAttempts {
+			delete(s.records, key)
+			return ErrTooManyAttempts
+		}
+		return ErrCodeMismatch
+	}
+
+	delete(s.records, key)
+	return nil
+}
+
+// checkRateLocked 检查并记录目标地址的发送频率，调用方需持有锁
+func (s *Service) checkRateLocked(target string, now time.Time) error {
+	day := now.Format("2006-01-02")
+	state := s.sends[target]
+	if state == nil {
+		state = &sendState{}
+		s.sends[target] = state
+	}
+	if state.day != day {
+		state.day = day
+		state.count = 0
+	}
+	if now.Sub(state.lastSentAt) < s.opts.Interval {
+		return ErrTooFrequent
+	}
+	if state.count >= s.opts.DailyLimit {
+		return ErrDailyLimit
+	}
+	state.lastSentAt = now
+	state.count++
+	return nil
+}
+
+// cleanupLocked 清理过期的验证码和已经失去意义的发送记录，调用方需持有锁
+func (s *Service) cleanupLocked(now time.Time) {
+	for key, rec := range s.records {
+		if now.After(rec.expireAt) {
+			delete(s.records, key)
+		}
+	}
+	day := now.Format("2006-01-02")
+	for target, state := range s.sends {
+		if state.day != day && now.Sub(state.lastSentAt) >= s.opts.Interval {
+			delete(s.sends, target)
+		}
+	}
+}
+
+func recordKey(scene, target string) string {
+	return scene + "|" + target
+}
+
+// hashCode 计算验证码哈希，把场景和地址一并纳入，避免验证码跨场景复用
+func hashCode(scene, target, code string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(scene + "|" + target + "|" + code))
+}
+
+// generateCode 使用 crypto/rand 生成指定位数的数字验证码
+func generateCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("生成验证码失败: %w", err)
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
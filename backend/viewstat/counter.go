@@ -0,0 +1,256 @@
+// Package viewstat 负责浏览量统计：访问在内存中去重、累积，由后台协程批量写回数据库
+package viewstat
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jayden/personal-blog-backend/config"
+	"github.com/jayden/personal-blog-backend/models"
+	"github.com/jayden/personal-blog-backend/netutil"
+	"github.com/jayden/personal-blog-backend/tourist"
+)
+
+const dayLayout = "2006-01-02"
+
+// dayDelta 某一天尚未写回的 PV/UV 增量
+type dayDelta struct {
+	pv int64
+	uv int64
+}
+
+// Counter 浏览量计数器
+type Counter struct {
+	mu sync.Mutex
+
+	window        time.Duration
+	flushInterval time.Duration
+	flushBatch    int
+
+	seen         map[string]time.Time // 去重键 -> 过期时间
+	articleViews map[string]int64     // 文章ID -> 待写回浏览量
+	days         map[string]*dayDelta // 日期 -> 待写回 PV/UV
+	visitorDay   string               // visitors 所属的日期
+	visitors     map[string]struct{}  // 当天已出现过的访客
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+var defaultCounter *Counter
+
+// Init 初始化全局计数器并启动后台写回协程
+func Init(cfg *config.Config) {
+	defaultCounter = NewCounter(cfg.ViewDedupWindow, cfg.ViewFlushInterval, cfg.ViewFlushBatch)
+	go defaultCounter.run()
+}
+
+// Stop 停止全局计数器，并把剩余数据写回数据库
+func Stop() {
+	if defaultCounter != nil {
+		defaultCounter.Stop()
+	}
+}
+
+// RecordArticleView 使用全局计数器记录一次文章浏览
+func RecordArticleView(r *http.Request, articleID string) {
+	if defaultCounter != nil {
+		defaultCounter.RecordArticleView(VisitorKey(r), articleID)
+	}
+}
+
+// RecordPageView 使用全局计数器记录一次页面浏览
+func RecordPageView(r *http.Request) {
+	if defaultCounter != nil {
+		defaultCounter.RecordPageView(VisitorKey(r))
+	}
+}
+
+// Totals 返回累计 PV/UV，包含尚未写回数据库的部分
+func Totals() (pv, uv int64, err error) {
+	pv, uv, err = models.GetViewTotals()
+	if err != nil || defaultCounter == nil {
+		return pv, uv, err
+	}
+	pendingPV, pendingUV := defaultCounter.pendingTotals()
+	return pv + pendingPV, uv + pendingUV, nil
+}
+
+// VisitorKey 生成访客标识，优先使用游客ID，没有时根据客户端IP和User-Agent生成
+func VisitorKey(r *http.Request) string {
+	if id := tourist.FromRequest(r); id != "" {
+		return id
+	}
+	sum := sha256.Sum256([]byte(netutil.ClientIP(r) + "|" + r.UserAgent()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// NewCounter 创建计数器，window 为去重窗口，flushInterval 为写回间隔，flushBatch 为触发立即写回的累积条数；
+// 写回间隔和条数不是正数时使用默认值 30s 和 500
+func NewCounter(window, flushInterval time.Duration, flushBatch int) *Counter {
+	if flushInterval <= 0 {
+		flushInterval = 30 * time.Second
+	}
+	if flushBatch <= 0 {
+		flushBatch = 500
+	}
+	return &Counter{
+		window:        window,
+		flushInterval: flushInterval,
+		flushBatch:    flushBatch,
+		seen:          make(map[string]time.Time),
+		articleViews:  make(map[string]int64),
+		days:          make(map[string]*dayDelta),
+		visitors:      make(map[string]struct{}),
+		flushCh:       make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// RecordArticleView 记录一次文章浏览，同一访客在去重窗口内重复浏览同一文章只计一次文章浏览量
+func (c *Counter) RecordArticleView(visitor, articleID string) {
+	now := time.Now()
+	key := visitor + "|" + articleID
+
+	c.mu.Lock()
+	c.recordPageViewLocked(visitor, now)
+	if expireAt, ok := c.seen[key]; !ok || now.After(expireAt) {
+		c.seen[key] = now.Add(c.window)
+		c.articleViews[articleID]++
+	}
+	full := len(c.articleViews) >= c.flushBatch
+	c.mu.Unlock()
+
+	if full {
+		c.triggerFlush()
+	}
+}
+
+// RecordPageView 记录一次页面浏览
+func (c *Counter) RecordPageView(visitor string) {
+	c.mu.Lock()
+	c.recordPageViewLocked(visitor, time.Now())
+	c.mu.Unlock()
+}
+
+// recordPageViewLocked 累加当天 PV，访客当天首次出现时累加 UV，调用方需持有锁
+func (c *Counter) recordPageViewLocked(visitor string, now time.Time) {
+	day := now.Format(dayLayout)
+	if day != c.visitorDay {
+		c.visitorDay = day
+		c.visitors = make(map[string]struct{})
+	}
+
+	delta := c.days[day]
+	if delta == nil {
+		delta = &dayDelta{}
+		c.days[day] = delta
+	}
+	delta.pv++
+	if _, ok := c.visitors[visitor]; !ok {
+		c.visitors[visitor] = struct{}{}
+		delta.uv++
+	}
+}
+
+// pendingTotals 返回尚未写回的 PV/UV 合计
+func (c *Counter) pendingTotals() (pv, uv int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, delta := range c.days {
+		pv += delta.pv
+		uv += delta.uv
+	}
+	return pv, uv
+}
+
+// triggerFlush 通知后台协程尽快写回，已有待处理通知时直接返回
+func (c *Counter) triggerFlush() {
+	select {
+	case c.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// run 后台写回协程
+func (c *Counter) run() {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.flush()
+		case <-c.flushCh:
+			c.flush()
+		case <-c.stopCh:
+			c.flush()
+			return
+		}
+	}
+}
+
+// Stop 停止后台协程并等待最后一次写回完成
+func (c *Counter) Stop() {
+	close(c.stopCh)
+	<-c.doneCh
+}
+
+// flush 把累积的数据写回数据库，写回失败的数据会放回内存等待下次写回
+func (c *Counter) flush() {
+	now := time.Now()
+
+	c.mu.Lock()
+	articleViews := c.articleViews
+	days := c.days
+	c.articleViews = make(map[string]int64)
+	c.days = make(map[string]*dayDelta)
+	for key, expireAt := range c.seen {
+		if now.After(expireAt) {
+			delete(c.seen, key)
+		}
+	}
+	c.mu.Unlock()
+
+	if err := models.AddArticleViews(articleViews); err != nil {
+		log.Printf("写回文章浏览量失败: %v", err)
+		c.restoreArticleViews(articleViews)
+	}
+
+	for day, delta := range days {
+		if err := models.AddDailyViewStat(day, delta.pv, delta.uv); err != nil {
+			log.Printf("写回每日访问统计失败: %v", err)
+			c.restoreDay(day, delta)
+		}
+	}
+}
+
+// restoreArticleViews 把写回失败的文章浏览量合并回内存
+func (c *Counter) restoreArticleViews(views map[string]int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for articleID, count := range views {
+		c.articleViews[articleID] += count
+	}
+}
+
+// restoreDay 把写回失败的 PV/UV 合并回内存
+func (c *Counter) restoreDay(day string, delta *dayDelta) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current := c.days[day]
+	if current == nil {
+		c.days[day] = delta
+		return
+	}
+	current.pv += delta.pv
+	current.uv += delta.uv
+}